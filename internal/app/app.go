@@ -1,12 +1,19 @@
 package app
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/go-to-k/delstack/internal/config"
 	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/journal"
 	"github.com/go-to-k/delstack/internal/operation"
 	"github.com/go-to-k/delstack/internal/resourcetype"
 	"github.com/go-to-k/delstack/pkg/client"
@@ -15,24 +22,67 @@ import (
 
 type App struct {
 	Cli             *cli.App
-	StackName       string
+	StackNames      *cli.StringSlice
+	StackNamesFile  string
 	Profile         string
-	Region          string
+	Regions         *cli.StringSlice
+	AllRegions      bool
+	RoleArns        *cli.StringSlice
+	ExternalId      string
+	RoleSessionName string
 	InteractiveMode bool
+	DryRun          bool
+	IncludeTypes    *cli.StringSlice
+	ExcludeTypes    *cli.StringSlice
+	Pattern         string
+	Tags            *cli.StringSlice
+	Yes             bool
+	Cascade         bool
+	Concurrency     int
+	ProtectedStacks []string
+	Resume          bool
+	JournalFile     string
+	Timeout         time.Duration
+	DeletionMode    string
+	CfnRoleArn      string
+	MaxPasses       int
+
+	DisableTerminationProtection bool
+	PurgeRetained                bool
+
+	StackSetNames              *cli.StringSlice
+	CallAs                     string
+	MaxConcurrentPercentage    int
+	FailureTolerancePercentage int
+	RegionConcurrencyType      string
 }
 
 func NewApp(version string) *App {
-	app := App{}
+	app := App{
+		StackNames:   cli.NewStringSlice(),
+		IncludeTypes: cli.NewStringSlice(),
+		ExcludeTypes: cli.NewStringSlice(),
+		Tags:         cli.NewStringSlice(),
+		Regions:      cli.NewStringSlice(),
+		RoleArns:     cli.NewStringSlice(),
+
+		StackSetNames: cli.NewStringSlice(),
+	}
 
 	app.Cli = &cli.App{
 		Name:  "delstack",
 		Usage: "A CLI tool to force delete the entire CloudFormation stack.",
 		Flags: []cli.Flag{
-			&cli.StringFlag{
+			&cli.StringSliceFlag{
 				Name:        "stackName",
 				Aliases:     []string{"s"},
-				Usage:       "CloudFormation stack name",
-				Destination: &app.StackName,
+				Usage:       "CloudFormation stack name (can be specified multiple times)",
+				Destination: app.StackNames,
+			},
+			&cli.StringFlag{
+				Name:        "stack-names-file",
+				Usage:       "File containing CloudFormation stack names (one per line)",
+				Destination: &app.StackNamesFile,
 			},
 			&cli.StringFlag{
 				Name:        "profile",
@@ -40,11 +90,32 @@ func NewApp(version string) *App {
 				Usage:       "AWS profile name",
 				Destination: &app.Profile,
 			},
-			&cli.StringFlag{
+			&cli.StringSliceFlag{
 				Name:        "region",
 				Aliases:     []string{"r"},
-				Usage:       "AWS region",
-				Destination: &app.Region,
+				Usage:       "AWS region (can be specified multiple times or comma-separated to delete in multiple regions)",
+				Destination: app.Regions,
+			},
+			&cli.BoolFlag{
+				Name:        "all-regions",
+				Value:       false,
+				Usage:       "Delete in all regions enabled for the account",
+				Destination: &app.AllRegions,
+			},
+			&cli.StringSliceFlag{
+				Name:        "role-arn",
+				Usage:       "IAM role ARN to assume (can be specified multiple times or comma-separated to delete in multiple accounts)",
+				Destination: app.RoleArns,
+			},
+			&cli.StringFlag{
+				Name:        "external-id",
+				Usage:       "External ID to assume the role",
+				Destination: &app.ExternalId,
+			},
+			&cli.StringFlag{
+				Name:        "role-session-name",
+				Usage:       "Session name to assume the role (default: delstack)",
+				Destination: &app.RoleSessionName,
 			},
 			&cli.BoolFlag{
 				Name:        "interactive",
@@ -53,9 +124,104 @@ func NewApp(version string) *App {
 				Usage:       "Interactive Mode",
 				Destination: &app.InteractiveMode,
 			},
+			&cli.BoolFlag{
+				Name:        "dry-run",
+				Value:       false,
+				Usage:       "Report resources to be force deleted without deleting anything",
+				Destination: &app.DryRun,
+			},
+			&cli.StringSliceFlag{
+				Name:        "include-type",
+				Usage:       "Resource types to force delete even if DELETE_FAILED (default: all supported types except CloudControl)",
+				Destination: app.IncludeTypes,
+			},
+			&cli.StringSliceFlag{
+				Name:        "exclude-type",
+				Usage:       "Resource types not to force delete even if DELETE_FAILED",
+				Destination: app.ExcludeTypes,
+			},
+			&cli.StringFlag{
+				Name:        "pattern",
+				Usage:       "Glob pattern (or regular expression with the \"re:\" prefix) of root stack names to delete",
+				Destination: &app.Pattern,
+			},
+			&cli.StringSliceFlag{
+				Name:        "tag",
+				Usage:       "Stack tag (key=value) of root stacks to delete (can be specified multiple times, all tags must match)",
+				Destination: app.Tags,
+			},
+			&cli.BoolFlag{
+				Name:        "yes",
+				Aliases:     []string{"y"},
+				Value:       false,
+				Usage:       "Skip the confirmation of stacks matching the pattern or tags, or stacks to be deleted by --cascade",
+				Destination: &app.Yes,
+			},
+			&cli.BoolFlag{
+				Name:        "cascade",
+				Value:       false,
+				Usage:       "Delete the stacks importing the exports of the stacks first (recursively)",
+				Destination: &app.Cascade,
+			},
+			&cli.IntFlag{
+				Name:        "concurrency",
+				Usage:       "Maximum number of stacks deleted concurrently in each region (default: number of CPUs)",
+				Destination: &app.Concurrency,
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Value:       client.DefaultStackDeletionTimeout,
+				Usage:       "Timeout to wait for the deletion of each stack, e.g. 30m, 2h",
+				Destination: &app.Timeout,
+			},
+			&cli.StringFlag{
+				Name:        "deletion-mode",
+				Value:       string(operation.StackDeletionModeRetain),
+				Usage:       "How to delete stacks after the force deletion: retain, force (FORCE_DELETE_STACK) or abandon-unsupported",
+				Destination: &app.DeletionMode,
+			},
+			&cli.IntFlag{
+				Name:        "max-passes",
+				Value:       operation.DefaultMaxDeletionPasses,
+				Usage:       "Maximum number of passes of the force deletion for each stack, retried while the DELETE_FAILED resources change",
+				Destination: &app.MaxPasses,
+			},
+			&cli.StringFlag{
+				Name:        "cfn-role-arn",
+				Usage:       "CloudFormation service role ARN passed to every stack deletion (default: the role recorded in each stack)",
+				Destination: &app.CfnRoleArn,
+			},
+			&cli.BoolFlag{
+				Name:        "disable-termination-protection",
+				Value:       false,
+				Usage:       "Disable the termination protection of the stacks after typing each stack name to confirm",
+				Destination: &app.DisableTerminationProtection,
+			},
+			&cli.BoolFlag{
+				Name:        "purge-retained",
+				Value:       false,
+				Usage:       "Force delete the resources retained by DeletionPolicy after the deletion of the stacks (only the supported types)",
+				Destination: &app.PurgeRetained,
+			},
+			&cli.BoolFlag{
+				Name:        "resume",
+				Value:       false,
+				Usage:       "Resume the interrupted deletion, skipping the steps recorded in the journal file",
+				Destination: &app.Resume,
+			},
+			&cli.StringFlag{
+				Name:        "journal-file",
+				Usage:       "Journal file recording the steps of the deletion (default: a file for the stacks, regions and accounts in $XDG_STATE_HOME/delstack)",
+				Destination: &app.JournalFile,
+			},
 		},
 	}
 
+	app.Cli.Commands = []*cli.Command{
+		app.newInspectCommand(),
+		app.newStackSetCommand(),
+	}
+
 	app.Cli.Version = version
 	app.Cli.Action = app.getAction()
 	app.Cli.HideHelpCommand = true
@@ -69,67 +235,597 @@ func (a *App) Run(ctx context.Context) error {
 
 func (a *App) getAction() func(c *cli.Context) error {
 	return func(c *cli.Context) error {
-		if !a.InteractiveMode && a.StackName == "" {
-			errMsg := fmt.Sprintln("The stack name must be specified in command options (-s) or a flow of the interactive mode (-i).")
+		if err := a.loadConfig(c); err != nil {
+			return err
+		}
+
+		stackNames, err := a.getStackNames()
+		if err != nil {
+			return err
+		}
+
+		tags, err := parseTags(a.Tags.Value())
+		if err != nil {
+			return err
+		}
+
+		stackDeletionMode, err := operation.ParseStackDeletionMode(a.DeletionMode)
+		if err != nil {
+			return err
+		}
+
+		var cfnRoleArn *string
+		if a.CfnRoleArn != "" {
+			if !arn.IsARN(a.CfnRoleArn) {
+				errMsg := fmt.Sprintf("--cfn-role-arn must be an IAM role ARN: %v", a.CfnRoleArn)
+				return fmt.Errorf("InvalidOptionError: %v", errMsg)
+			}
+			cfnRoleArn = aws.String(a.CfnRoleArn)
+		}
+		hasFilters := a.Pattern != "" || len(tags) > 0
+
+		if !a.InteractiveMode && len(stackNames) == 0 && !hasFilters {
+			errMsg := fmt.Sprintln("The stack name must be specified in command options (-s, --stack-names-file, --pattern or --tag) or a flow of the interactive mode (-i).")
+			return fmt.Errorf("StackNameNotSpecifiedError: %v", errMsg)
+		}
+
+		targets, err := a.newDeletionTargets(c.Context)
+		if err != nil {
+			return err
+		}
+
+		selectsStackName := len(stackNames) == 0 && !hasFilters
+		if selectsStackName && len(targets) > 1 {
+			errMsg := fmt.Sprintln("The stack name must be specified in command options (-s, --stack-names-file, --pattern or --tag) for multiple regions or accounts.")
 			return fmt.Errorf("StackNameNotSpecifiedError: %v", errMsg)
 		}
 
-		config, err := client.LoadAWSConfig(c.Context, a.Region, a.Profile)
+		targetResourceTypes, err := resourcetype.FilterResourceTypes(a.IncludeTypes.Value(), a.ExcludeTypes.Value())
 		if err != nil {
 			return err
 		}
 
-		var targetResourceTypes []string
 		var keyword string
 		continuation := true
 		if a.InteractiveMode {
-			targetResourceTypes, keyword, continuation = a.doInteractiveMode()
-		} else {
-			targetResourceTypes = resourcetype.GetResourceTypes()
+			// the opt-in CloudControl is offered unchecked as well as the other types
+			selectableResourceTypes, err := resourcetype.FilterSelectableResourceTypes(a.IncludeTypes.Value(), a.ExcludeTypes.Value())
+			if err != nil {
+				return err
+			}
+			targetResourceTypes, keyword, continuation = a.doInteractiveMode(selectableResourceTypes, selectsStackName)
 		}
 
 		if !continuation {
 			return nil
 		}
 
-		operatorFactory := operation.NewOperatorFactory(config)
-		cloudformationStackOperator := operatorFactory.CreateCloudFormationStackOperator(targetResourceTypes)
+		for _, target := range targets {
+			operatorFactory := operation.NewOperatorFactory(target.config, a.Timeout, stackDeletionMode, cfnRoleArn, a.MaxPasses)
+			target.cloudformationStackOperator = operatorFactory.CreateCloudFormationStackOperator(targetResourceTypes)
+			target.cloudformationStackOperator.SetConcurrency(a.Concurrency)
+			target.cloudformationStackOperator.SetPurgeRetained(a.PurgeRetained)
+
+			target.stackNames = stackNames
+			if hasFilters {
+				filteredStackNames, err := a.listStacksFilteredByPatternAndTags(target.ctx, target.cloudformationStackOperator, tags)
+				if err != nil {
+					return err
+				}
+				filteredStackNames, err = a.excludeProtectedStacks(target.ctx, filteredStackNames)
+				if err != nil {
+					return err
+				}
+				target.stackNames = appendUnique(target.stackNames, filteredStackNames)
+			}
+
+			if a.InteractiveMode && selectsStackName {
+				stackName, err := a.selectStackNameByKeyword(target.ctx, target.cloudformationStackOperator, keyword)
+				if err != nil {
+					return err
+				}
+				if stackName == "" {
+					return nil
+				}
+				target.stackNames = []string{stackName}
+			}
+
+			if err := a.checkProtectedStacks(target.stackNames); err != nil {
+				return err
+			}
+		}
 
-		if a.InteractiveMode && a.StackName == "" {
-			stackNames, err := cloudformationStackOperator.ListStacksFilteredByKeyword(c.Context, aws.String(keyword))
+		if err := a.skipNotExistingStacks(targets); err != nil {
+			return err
+		}
+
+		if hasFilters {
+			continuation, err := a.confirmFilteredStacks(targets)
 			if err != nil {
 				return err
 			}
-			if len(stackNames) == 0 {
-				errMsg := fmt.Sprintf("No stacks matching the keyword %s.", keyword)
-				return fmt.Errorf("NotExistsError: %v", errMsg)
+			if !continuation {
+				return nil
 			}
+		}
+
+		continuation, err = a.checkStackImports(targets)
+		if err != nil {
+			return err
+		}
+		if !continuation {
+			return nil
+		}
+
+		continuation, err = a.confirmTerminationProtection(targets)
+		if err != nil {
+			return err
+		}
+		if !continuation {
+			return nil
+		}
 
-			stackName := a.selectStackName(stackNames)
-			if stackName == "" {
+		if a.InteractiveMode && !a.DryRun {
+			continuation, err = a.confirmStackTrees(targets)
+			if err != nil {
+				return err
+			}
+			if !continuation {
 				return nil
 			}
+		}
 
-			a.StackName = stackName
+		if a.DryRun {
+			return a.planTargets(targets)
 		}
 
-		isRootStack := true
-		operatorCollection := operation.NewOperatorCollection(config, operatorFactory, targetResourceTypes)
-		operatorManager := operation.NewOperatorManager(operatorCollection)
+		j, err := a.openJournal(a.journalKey(targets, stackNames))
+		if err != nil {
+			return err
+		}
+		for _, target := range targets {
+			target.ctx = journal.ContextWithJournal(target.ctx, j.WithScope(target.label()))
+		}
 
-		io.Logger.Info().Msgf("Start deletion, %v", a.StackName)
 		io.Logger.Info().Msg("Please wait a few minutes...")
 
-		if err := cloudformationStackOperator.DeleteCloudFormationStack(c.Context, aws.String(a.StackName), isRootStack, operatorManager); err != nil {
+		if err := a.deleteTargets(targets); err != nil {
+			if j != nil {
+				io.Logger.Info().Msg("The deletion can be resumed with --resume.")
+			}
+			if closeErr := j.Close(); closeErr != nil {
+				io.Logger.Warn().Msg(closeErr.Error())
+			}
 			return err
 		}
 
-		io.Logger.Info().Msgf("Successfully deleted, %v", a.StackName)
-		return nil
+		return j.Remove()
 	}
 }
 
-func (a *App) doInteractiveMode() ([]string, string, bool) {
+// openJournal opens the journal file to record the steps of the deletion. Without --resume, the deletion goes on
+// without the journal if the file cannot be opened.
+func (a *App) openJournal(key string) (*journal.Journal, error) {
+	var err error
+	path := a.JournalFile
+	if path == "" {
+		path, err = journal.DefaultPath(key)
+	}
+
+	var j *journal.Journal
+	if err == nil {
+		if _, statErr := os.Stat(path); statErr != nil && a.Resume {
+			io.Logger.Warn().Msgf("No journal file of the previous run, start from the beginning: %v", path)
+		}
+		j, err = journal.Open(path, a.Resume)
+	}
+	if err != nil && a.Resume {
+		return nil, err
+	}
+	if err != nil {
+		io.Logger.Warn().Msgf("The deletion cannot be resumed: %v", err)
+		return nil, nil
+	}
+
+	if a.Resume && j.Len() > 0 {
+		io.Logger.Info().Msgf("Resume from %d steps recorded in the journal file, %v", j.Len(), path)
+	}
+	io.Logger.Debug().Msgf("Recording the steps in the journal file, %v", path)
+
+	return j, nil
+}
+
+// journalKey returns the key of the default journal file identifying the deletion by the profile, the targets and
+// the stacks specified in the command options. The stacks selected in the interactive mode are used without them.
+// The stacks found by --pattern or --tag are not used, because they change as the deletion goes on.
+func (a *App) journalKey(targets []*deletionTarget, stackNames []string) string {
+	selectors := []string{}
+	for _, stackName := range stackNames {
+		selectors = append(selectors, "stack="+stackName)
+	}
+	if a.Pattern != "" {
+		selectors = append(selectors, "pattern="+a.Pattern)
+	}
+	for _, tag := range a.Tags.Value() {
+		selectors = append(selectors, "tag="+tag)
+	}
+
+	keys := []string{}
+	for _, target := range targets {
+		targetSelectors := selectors
+		if len(targetSelectors) == 0 {
+			for _, stackName := range target.stackNames {
+				targetSelectors = append(targetSelectors, "stack="+stackName)
+			}
+		}
+		sort.Strings(targetSelectors)
+		keys = append(keys, fmt.Sprintf("%v:%v", target.label(), strings.Join(targetSelectors, ",")))
+	}
+	sort.Strings(keys)
+
+	return fmt.Sprintf("profile=%v\n%v", a.Profile, strings.Join(keys, "\n"))
+}
+
+// loadConfig applies delstack.yaml to the options not specified in the command line.
+func (a *App) loadConfig(c *cli.Context) error {
+	cfg, path, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if path != "" {
+		io.Logger.Debug().Msgf("Loaded the config file, %v", path)
+	}
+
+	if !c.IsSet("profile") && cfg.Profile != "" {
+		a.Profile = cfg.Profile
+	}
+	if !c.IsSet("region") && !c.IsSet("all-regions") && len(cfg.Region) > 0 {
+		a.Regions = cli.NewStringSlice(cfg.Region...)
+	}
+	if !c.IsSet("include-type") && len(cfg.AllowedResourceTypes) > 0 {
+		a.IncludeTypes = cli.NewStringSlice(cfg.AllowedResourceTypes...)
+	}
+	if !c.IsSet("concurrency") {
+		a.Concurrency = cfg.Concurrency
+	}
+	a.ProtectedStacks = cfg.ProtectedStacks
+
+	if a.Timeout <= 0 {
+		errMsg := fmt.Sprintf("--timeout must be a positive duration: %v", a.Timeout)
+		return fmt.Errorf("InvalidOptionError: %v", errMsg)
+	}
+	if a.Concurrency < 0 {
+		errMsg := fmt.Sprintf("--concurrency must be a positive number: %d", a.Concurrency)
+		return fmt.Errorf("InvalidOptionError: %v", errMsg)
+	}
+	if a.MaxPasses <= 0 {
+		errMsg := fmt.Sprintf("--max-passes must be a positive number: %d", a.MaxPasses)
+		return fmt.Errorf("InvalidOptionError: %v", errMsg)
+	}
+
+	return nil
+}
+
+// getProtectionPattern returns the pattern of protectedStacks in the config file that matches the stack name.
+func (a *App) getProtectionPattern(stackName string) (string, error) {
+	for _, pattern := range a.ProtectedStacks {
+		match, err := operation.NewStackNameMatcher(pattern)
+		if err != nil {
+			return "", fmt.Errorf("ConfigError: protectedStacks: %v", err)
+		}
+		if match(stackName) {
+			return pattern, nil
+		}
+	}
+	return "", nil
+}
+
+func (a *App) checkProtectedStacks(stackNames []string) error {
+	for _, stackName := range stackNames {
+		pattern, err := a.getProtectionPattern(stackName)
+		if err != nil {
+			return err
+		}
+		if pattern != "" {
+			errMsg := fmt.Sprintf("%s stack is protected by %s in protectedStacks of the config file.", stackName, pattern)
+			return fmt.Errorf("ProtectedStackError: %v", errMsg)
+		}
+	}
+	return nil
+}
+
+func (a *App) excludeProtectedStacks(ctx context.Context, stackNames []string) ([]string, error) {
+	unprotectedStackNames := []string{}
+	for _, stackName := range stackNames {
+		pattern, err := a.getProtectionPattern(stackName)
+		if err != nil {
+			return nil, err
+		}
+		if pattern != "" {
+			io.LoggerFromContext(ctx).Warn().Msgf("%s stack is protected by %s in protectedStacks of the config file, skipped.", stackName, pattern)
+			continue
+		}
+		unprotectedStackNames = append(unprotectedStackNames, stackName)
+	}
+	return unprotectedStackNames, nil
+}
+
+func (a *App) selectStackNameByKeyword(ctx context.Context, cloudformationStackOperator *operation.CloudFormationStackOperator, keyword string) (string, error) {
+	filteredStackNames, err := cloudformationStackOperator.ListStacksFilteredByKeyword(ctx, aws.String(keyword))
+	if err != nil {
+		return "", err
+	}
+	if len(filteredStackNames) == 0 {
+		errMsg := fmt.Sprintf("No stacks matching the keyword %s.", keyword)
+		return "", fmt.Errorf("NotExistsError: %v", errMsg)
+	}
+
+	protectedStacks, err := cloudformationStackOperator.ListTerminationProtectedStacks(ctx, filteredStackNames)
+	if err != nil {
+		return "", err
+	}
+
+	return a.selectStackName(filteredStackNames, protectedStacks), nil
+}
+
+// listStacksFilteredByPatternAndTags returns root stacks matching both the pattern and the tags.
+func (a *App) listStacksFilteredByPatternAndTags(ctx context.Context, cloudformationStackOperator *operation.CloudFormationStackOperator, tags map[string]string) ([]string, error) {
+	var filteredStackNames []string
+
+	if a.Pattern != "" {
+		stackNames, err := cloudformationStackOperator.ListStacksFilteredByPattern(ctx, a.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		filteredStackNames = stackNames
+	}
+
+	if len(tags) > 0 {
+		stackNames, err := cloudformationStackOperator.ListStacksFilteredByTags(ctx, tags)
+		if err != nil {
+			return nil, err
+		}
+		if filteredStackNames == nil {
+			filteredStackNames = stackNames
+		} else {
+			filteredStackNames = intersectStackNames(filteredStackNames, stackNames)
+		}
+	}
+
+	return filteredStackNames, nil
+}
+
+// confirmFilteredStacks displays the stacks matching the pattern and the tags and requires the confirmation.
+func (a *App) confirmFilteredStacks(targets []*deletionTarget) (bool, error) {
+	conditions := []string{}
+	if a.Pattern != "" {
+		conditions = append(conditions, fmt.Sprintf("the pattern %s", a.Pattern))
+	}
+	if len(a.Tags.Value()) > 0 {
+		conditions = append(conditions, fmt.Sprintf("the tags %s", strings.Join(a.Tags.Value(), ",")))
+	}
+	condition := strings.Join(conditions, " and ")
+
+	stacksCount := 0
+	for _, target := range targets {
+		stacksCount += len(target.stackNames)
+	}
+	if stacksCount == 0 {
+		errMsg := fmt.Sprintf("No stacks matching %s.", condition)
+		return false, fmt.Errorf("NotExistsError: %v", errMsg)
+	}
+
+	for _, target := range targets {
+		if len(target.stackNames) == 0 {
+			continue
+		}
+		io.LoggerFromContext(target.ctx).Info().Msgf("Stacks matching %s:\n%v", condition, strings.Join(target.stackNames, "\n"))
+	}
+
+	if !a.DryRun && !a.Yes && !io.GetTypedConfirmation(fmt.Sprintf("Delete these %d stacks?", stacksCount), "delete") {
+		io.Logger.Info().Msg("Finished...")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// checkStackImports displays the stacks importing the exports of the target stacks, which cannot be deleted
+// before the importing stacks. With --cascade, the importing stacks and recursively their importing stacks are
+// added to the targets after the confirmation, so that they are deleted first.
+func (a *App) checkStackImports(targets []*deletionTarget) (bool, error) {
+	cascadedStacksCount := 0
+	for _, target := range targets {
+		if len(target.stackNames) == 0 {
+			continue
+		}
+
+		stackImports, err := target.cloudformationStackOperator.ListStackImports(target.ctx, target.stackNames, a.Cascade)
+		if err != nil {
+			return false, err
+		}
+		if len(stackImports) == 0 {
+			continue
+		}
+
+		io.LoggerFromContext(target.ctx).Warn().Msgf(
+			"The exports of the stacks are imported by other stacks:\n%v",
+			*operation.StackImportsToTableFormat(stackImports),
+		)
+
+		if !a.Cascade {
+			if a.DryRun {
+				continue
+			}
+			errMsg := "the exports of the stacks are imported by other stacks, so delete them first or use --cascade option."
+			return false, fmt.Errorf("ExportImportedError: %v", errMsg)
+		}
+
+		importingStackNames := []string{}
+		for _, stackImport := range stackImports {
+			importingStackNames = appendUnique(importingStackNames, []string{stackImport.ImportingStackName})
+		}
+		if err := a.checkProtectedStacks(importingStackNames); err != nil {
+			return false, err
+		}
+		target.stackNames = appendUnique(target.stackNames, importingStackNames)
+		cascadedStacksCount += len(importingStackNames)
+	}
+
+	if cascadedStacksCount > 0 && !a.DryRun && !a.Yes && !io.GetTypedConfirmation(fmt.Sprintf("Delete the %d importing stacks first?", cascadedStacksCount), "delete") {
+		io.Logger.Info().Msg("Finished...")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// confirmTerminationProtection requires typing the name of each target stack with the termination protection to
+// disable it with --disable-termination-protection. The confirmation is not skipped by -y, because the protection
+// is enabled on purpose.
+func (a *App) confirmTerminationProtection(targets []*deletionTarget) (bool, error) {
+	if !a.DisableTerminationProtection || a.DryRun {
+		return true, nil
+	}
+
+	for _, target := range targets {
+		if len(target.stackNames) == 0 {
+			continue
+		}
+
+		protectedStacks, err := target.cloudformationStackOperator.ListTerminationProtectedStacks(target.ctx, target.stackNames)
+		if err != nil {
+			return false, err
+		}
+
+		for _, stackName := range protectedStacks {
+			label := fmt.Sprintf("TerminationProtection of %v is enabled. Disable it and delete the stack?", stackName)
+			if !io.GetTypedConfirmation(label, stackName) {
+				io.LoggerFromContext(target.ctx).Info().Msgf("The confirmation did not match %v.", stackName)
+				io.Logger.Info().Msg("Finished...")
+				return false, nil
+			}
+		}
+
+		target.cloudformationStackOperator.SetTerminationProtectionDisabledStacks(protectedStacks)
+	}
+
+	return true, nil
+}
+
+// confirmStackTrees displays the tree of each target stack as the summary before the deletion in the interactive
+// mode, and requires the confirmation.
+func (a *App) confirmStackTrees(targets []*deletionTarget) (bool, error) {
+	if err := a.inspectTargets(targets); err != nil {
+		return false, err
+	}
+
+	if !io.GetYesNo("Delete the stacks?") {
+		io.Logger.Info().Msg("Finished...")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func containsStackName(stackNames []string, target string) bool {
+	for _, stackName := range stackNames {
+		if stackName == target {
+			return true
+		}
+	}
+	return false
+}
+
+func intersectStackNames(stackNames []string, otherStackNames []string) []string {
+	intersection := []string{}
+	for _, stackName := range stackNames {
+		for _, other := range otherStackNames {
+			if stackName == other {
+				intersection = append(intersection, stackName)
+				break
+			}
+		}
+	}
+	return intersection
+}
+
+// parseTags parses tags in the key=value format.
+func parseTags(rawTags []string) (map[string]string, error) {
+	tags := map[string]string{}
+
+	for _, rawTag := range rawTags {
+		kv := strings.SplitN(rawTag, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("InvalidTagError: tags must be specified in the key=value format: %v", rawTag)
+		}
+		tags[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+
+	return tags, nil
+}
+
+// getStackNames returns stack names from the command options and the file, without duplicates.
+func (a *App) getStackNames() ([]string, error) {
+	stackNames := []string{}
+
+	candidates := a.StackNames.Value()
+	if a.StackNamesFile != "" {
+		namesInFile, err := readStackNamesFile(a.StackNamesFile)
+		if err != nil {
+			return stackNames, err
+		}
+		candidates = append(candidates, namesInFile...)
+	}
+
+	return appendUnique(stackNames, candidates), nil
+}
+
+// appendUnique appends non-empty candidates that are not already contained.
+func appendUnique(values []string, candidates []string) []string {
+	exists := map[string]bool{}
+	for _, value := range values {
+		exists[value] = true
+	}
+
+	for _, value := range candidates {
+		value = strings.TrimSpace(value)
+		if value == "" || exists[value] {
+			continue
+		}
+		exists[value] = true
+		values = append(values, value)
+	}
+
+	return values
+}
+
+// readStackNamesFile reads stack names line by line, ignoring empty lines and lines starting with '#'.
+func readStackNamesFile(path string) ([]string, error) {
+	stackNames := []string{}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return stackNames, fmt.Errorf("StackNamesFileError: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		stackNames = append(stackNames, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return stackNames, fmt.Errorf("StackNamesFileError: %v", err)
+	}
+
+	return stackNames, nil
+}
+
+func (a *App) doInteractiveMode(opts []string, selectsStackName bool) ([]string, string, bool) {
 	var checkboxes []string
 	var keyword string
 
@@ -137,9 +833,8 @@ func (a *App) doInteractiveMode() ([]string, string, bool) {
 		"\n" +
 		"However, if a resource can be deleted without becoming DELETE_FAILED by the normal CloudFormation stack deletion feature, the resource will be deleted even if you do not select that resource type. " +
 		"\n"
-	opts := resourcetype.GetResourceTypes()
 
-	if a.StackName == "" {
+	if selectsStackName {
 		stackNameLabel := "Filter a keyword of stack names(case-insensitive): "
 		keyword = io.InputKeywordForFilter(stackNameLabel)
 	}
@@ -169,15 +864,33 @@ func (a *App) doInteractiveMode() ([]string, string, bool) {
 	}
 }
 
-func (a *App) selectStackName(stackNames []string) string {
+// terminationProtectionSuffix marks the stacks with the termination protection in the selection of stack names.
+const terminationProtectionSuffix = " (TerminationProtection: enabled)"
+
+func (a *App) selectStackName(stackNames []string, protectedStacks []string) string {
 	var stackName string
 
 	label := "Select StackName." + "\n" +
-		"Nested child stacks and XXX_IN_PROGRESS(e.g. ROLLBACK_IN_PROGRESS) status stacks are not displayed." +
+		"Nested child stacks are not displayed." +
 		"\n"
+	if len(protectedStacks) > 0 && !a.DisableTerminationProtection {
+		label += "Stacks with TerminationProtection cannot be deleted without --disable-termination-protection option." + "\n"
+	}
+
+	// show the protection state next to the stack names
+	opts := []string{}
+	optToStackName := map[string]string{}
+	for _, name := range stackNames {
+		opt := name
+		if containsStackName(protectedStacks, name) {
+			opt = name + terminationProtectionSuffix
+		}
+		opts = append(opts, opt)
+		optToStackName[opt] = name
+	}
 
 	for {
-		stackName = io.GetSelection(label, stackNames)
+		stackName = optToStackName[io.GetSelection(label, opts)]
 
 		if stackName == "" {
 			io.Logger.Warn().Msg("Select StackName!")
@@ -195,3 +908,40 @@ func (a *App) selectStackName(stackNames []string) string {
 		}
 	}
 }
+
+func (a *App) outputStackPlans(ctx context.Context, stackNames []string, stackPlans []operation.StackPlan) {
+	forceDeletionHeader := []string{"Stack", "ResourceType", "Resource", "Status"}
+	forceDeletionData := [][]string{}
+	unsupportedHeader := []string{"Stack", "ResourceType", "Resource", "Status"}
+	unsupportedData := [][]string{}
+	protectedStacks := []string{}
+
+	for _, stackPlan := range stackPlans {
+		if stackPlan.TerminationProtection {
+			protectedStacks = append(protectedStacks, stackPlan.StackName)
+		}
+		for _, resource := range stackPlan.ForceDeletionResources {
+			forceDeletionData = append(forceDeletionData, []string{stackPlan.StackName, *resource.ResourceType, *resource.LogicalResourceId, string(resource.ResourceStatus)})
+		}
+		for _, resource := range stackPlan.UnsupportedResources {
+			unsupportedData = append(unsupportedData, []string{stackPlan.StackName, *resource.ResourceType, *resource.LogicalResourceId, string(resource.ResourceStatus)})
+		}
+	}
+
+	io.LoggerFromContext(ctx).Info().Msgf("[Dry Run] No resources are deleted, %v", strings.Join(stackNames, ", "))
+
+	if len(protectedStacks) > 0 && a.DisableTerminationProtection {
+		io.LoggerFromContext(ctx).Warn().Msgf("TerminationProtection is enabled, so it will be disabled after the confirmation of these stacks: %v", strings.Join(protectedStacks, ", "))
+	} else if len(protectedStacks) > 0 {
+		io.LoggerFromContext(ctx).Warn().Msgf("TerminationProtection is enabled, so these stacks will not be deleted: %v", strings.Join(protectedStacks, ", "))
+	}
+
+	io.LoggerFromContext(ctx).Info().Msg(
+		"These resources will be force deleted if they become DELETE_FAILED:\n" +
+			*io.ToStringAsTableFormat(forceDeletionHeader, forceDeletionData),
+	)
+	io.LoggerFromContext(ctx).Info().Msg(
+		"These resources are unsupported (or you did not selected in the interactive prompt), so the deletion fails if they become DELETE_FAILED:\n" +
+			*io.ToStringAsTableFormat(unsupportedHeader, unsupportedData),
+	)
+}
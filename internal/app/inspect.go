@@ -0,0 +1,127 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/operation"
+	"github.com/go-to-k/delstack/internal/resourcetype"
+	"github.com/urfave/cli/v2"
+)
+
+// newInspectCommand returns the command displaying the tree of the stacks and their nested child stacks without
+// deleting anything.
+func (a *App) newInspectCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "inspect",
+		Usage: "Display the tree of the stacks and their nested child stacks with the operators to force delete the resources",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "stackName",
+				Aliases:     []string{"s"},
+				Usage:       "CloudFormation stack name (can be specified multiple times)",
+				Destination: a.StackNames,
+			},
+			&cli.StringFlag{
+				Name:        "stack-names-file",
+				Usage:       "File containing CloudFormation stack names (one per line)",
+				Destination: &a.StackNamesFile,
+			},
+			&cli.StringFlag{
+				Name:        "profile",
+				Aliases:     []string{"p"},
+				Usage:       "AWS profile name",
+				Destination: &a.Profile,
+			},
+			&cli.StringSliceFlag{
+				Name:        "region",
+				Aliases:     []string{"r"},
+				Usage:       "AWS region (can be specified multiple times or comma-separated)",
+				Destination: a.Regions,
+			},
+			&cli.StringSliceFlag{
+				Name:        "role-arn",
+				Usage:       "IAM role ARN to assume (can be specified multiple times or comma-separated)",
+				Destination: a.RoleArns,
+			},
+			&cli.StringFlag{
+				Name:        "external-id",
+				Usage:       "External ID to assume the role",
+				Destination: &a.ExternalId,
+			},
+			&cli.StringFlag{
+				Name:        "role-session-name",
+				Usage:       "Session name to assume the role (default: delstack)",
+				Destination: &a.RoleSessionName,
+			},
+			&cli.StringSliceFlag{
+				Name:        "include-type",
+				Usage:       "Resource types to force delete even if DELETE_FAILED (default: all supported types except CloudControl)",
+				Destination: a.IncludeTypes,
+			},
+			&cli.StringSliceFlag{
+				Name:        "exclude-type",
+				Usage:       "Resource types not to force delete even if DELETE_FAILED",
+				Destination: a.ExcludeTypes,
+			},
+		},
+		Action: a.getInspectAction(),
+	}
+}
+
+func (a *App) getInspectAction() func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		if err := a.loadConfig(c); err != nil {
+			return err
+		}
+
+		stackNames, err := a.getStackNames()
+		if err != nil {
+			return err
+		}
+		if len(stackNames) == 0 {
+			errMsg := fmt.Sprintln("The stack name must be specified in command options (-s or --stack-names-file).")
+			return fmt.Errorf("StackNameNotSpecifiedError: %v", errMsg)
+		}
+
+		targetResourceTypes, err := resourcetype.FilterResourceTypes(a.IncludeTypes.Value(), a.ExcludeTypes.Value())
+		if err != nil {
+			return err
+		}
+
+		targets, err := a.newDeletionTargets(c.Context)
+		if err != nil {
+			return err
+		}
+
+		for _, target := range targets {
+			operatorFactory := operation.NewOperatorFactory(target.config, a.Timeout, operation.StackDeletionModeRetain, nil, 0)
+			target.cloudformationStackOperator = operatorFactory.CreateCloudFormationStackOperator(targetResourceTypes)
+			target.stackNames = stackNames
+		}
+
+		return a.inspectTargets(targets)
+	}
+}
+
+// inspectTargets displays the tree of each target stack and its nested child stacks.
+func (a *App) inspectTargets(targets []*deletionTarget) error {
+	isRootStack := true
+
+	for _, target := range targets {
+		for _, stackName := range target.stackNames {
+			stackTree, err := target.cloudformationStackOperator.InspectCloudFormationStack(target.ctx, aws.String(stackName), isRootStack)
+			if err != nil {
+				return err
+			}
+			io.LoggerFromContext(target.ctx).Info().Msgf(
+				"The tree of %v and its nested child stacks (the resources are handled by the operators if DELETE_FAILED):\n%v",
+				stackName,
+				*operation.StackTreeToTreeFormat(stackTree),
+			)
+		}
+	}
+
+	return nil
+}
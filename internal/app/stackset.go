@@ -0,0 +1,235 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/operation"
+	"github.com/go-to-k/delstack/pkg/client"
+	"github.com/urfave/cli/v2"
+)
+
+// newStackSetCommand returns the command deleting all the stack instances of StackSets and then the StackSets.
+func (a *App) newStackSetCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "stackset",
+		Usage: "Delete all the stack instances of the StackSets across accounts and regions, and then the StackSets",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:        "stackset-name",
+				Aliases:     []string{"n"},
+				Usage:       "StackSet name (can be specified multiple times)",
+				Destination: a.StackSetNames,
+			},
+			&cli.StringFlag{
+				Name:        "profile",
+				Aliases:     []string{"p"},
+				Usage:       "AWS profile name",
+				Destination: &a.Profile,
+			},
+			&cli.StringSliceFlag{
+				Name:        "region",
+				Aliases:     []string{"r"},
+				Usage:       "AWS region of the StackSets (can be specified multiple times or comma-separated)",
+				Destination: a.Regions,
+			},
+			&cli.StringSliceFlag{
+				Name:        "role-arn",
+				Usage:       "IAM role ARN to assume (can be specified multiple times or comma-separated)",
+				Destination: a.RoleArns,
+			},
+			&cli.StringFlag{
+				Name:        "external-id",
+				Usage:       "External ID to assume the role",
+				Destination: &a.ExternalId,
+			},
+			&cli.StringFlag{
+				Name:        "role-session-name",
+				Usage:       "Session name to assume the role (default: delstack)",
+				Destination: &a.RoleSessionName,
+			},
+			&cli.StringFlag{
+				Name:        "call-as",
+				Value:       "SELF",
+				Usage:       "SELF, or DELEGATED_ADMIN for service-managed StackSets in a delegated administrator account",
+				Destination: &a.CallAs,
+			},
+			&cli.IntFlag{
+				Name:        "max-concurrent-percentage",
+				Value:       operation.DefaultMaxConcurrentPercentage,
+				Usage:       "Maximum percentage of accounts in which stack instances are deleted at one time in each region",
+				Destination: &a.MaxConcurrentPercentage,
+			},
+			&cli.IntFlag{
+				Name:        "failure-tolerance-percentage",
+				Value:       operation.DefaultFailureTolerancePercentage,
+				Usage:       "Percentage of accounts in which the deletion can fail before it is stopped in each region",
+				Destination: &a.FailureTolerancePercentage,
+			},
+			&cli.StringFlag{
+				Name:        "region-concurrency-type",
+				Value:       "PARALLEL",
+				Usage:       "Whether stack instances are deleted in the regions in PARALLEL or SEQUENTIAL",
+				Destination: &a.RegionConcurrencyType,
+			},
+			&cli.BoolFlag{
+				Name:        "yes",
+				Aliases:     []string{"y"},
+				Value:       false,
+				Usage:       "Skip the confirmation of the StackSets and the stack instances to delete",
+				Destination: &a.Yes,
+			},
+			&cli.DurationFlag{
+				Name:        "timeout",
+				Value:       client.DefaultStackDeletionTimeout,
+				Usage:       "Timeout to wait for each operation deleting stack instances, e.g. 30m, 2h",
+				Destination: &a.Timeout,
+			},
+		},
+		Action: a.getStackSetAction(),
+	}
+}
+
+func (a *App) getStackSetAction() func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		if err := a.loadConfig(c); err != nil {
+			return err
+		}
+
+		stackSetNames := appendUnique([]string{}, a.StackSetNames.Value())
+		if len(stackSetNames) == 0 {
+			errMsg := fmt.Sprintln("The StackSet name must be specified in command options (-n).")
+			return fmt.Errorf("StackSetNameNotSpecifiedError: %v", errMsg)
+		}
+
+		callAs, err := operation.ParseCallAs(a.CallAs)
+		if err != nil {
+			return err
+		}
+		operationPreferences, err := operation.NewStackSetOperationPreferences(
+			a.MaxConcurrentPercentage,
+			a.FailureTolerancePercentage,
+			a.RegionConcurrencyType,
+		)
+		if err != nil {
+			return err
+		}
+
+		targets, err := a.newDeletionTargets(c.Context)
+		if err != nil {
+			return err
+		}
+
+		stackSetTargets := []*stackSetTarget{}
+		for _, target := range targets {
+			operatorFactory := operation.NewOperatorFactory(target.config, a.Timeout, operation.StackDeletionModeRetain, nil, 0)
+			stackSetOperator := operatorFactory.CreateCloudFormationStackSetOperator()
+			stackSetOperator.SetCallAs(callAs)
+			stackSetOperator.SetOperationPreferences(operationPreferences)
+
+			stackSetTargets = append(stackSetTargets, &stackSetTarget{
+				deletionTarget:   target,
+				stackSetOperator: stackSetOperator,
+			})
+		}
+
+		continuation, err := a.confirmStackSets(stackSetTargets, stackSetNames)
+		if err != nil {
+			return err
+		}
+		if !continuation {
+			return nil
+		}
+
+		return a.deleteStackSets(stackSetTargets)
+	}
+}
+
+// stackSetTarget holds the StackSets to delete and the operator for an account (role) and a region.
+type stackSetTarget struct {
+	*deletionTarget
+	stackSetOperator     *operation.CloudFormationStackSetOperator
+	stackSetNames        []string
+	skippedStackSetNames []string // not existing in the target
+}
+
+// confirmStackSets displays the stack instances of the StackSets in each target and requires the confirmation,
+// which is skipped by -y. The StackSets not existing in a target are skipped.
+func (a *App) confirmStackSets(targets []*stackSetTarget, stackSetNames []string) (bool, error) {
+	stackSetsCount := 0
+	for _, target := range targets {
+		for _, stackSetName := range stackSetNames {
+			stackInstances, exists, err := target.stackSetOperator.ListStackSetInstances(target.ctx, aws.String(stackSetName))
+			if err != nil {
+				return false, err
+			}
+			if !exists {
+				io.LoggerFromContext(target.ctx).Info().Msgf("%v does not exist", stackSetName)
+				target.skippedStackSetNames = append(target.skippedStackSetNames, stackSetName)
+				continue
+			}
+
+			io.LoggerFromContext(target.ctx).Info().Msgf(
+				"Stack instances of %v:\n%v",
+				stackSetName,
+				*operation.StackInstancesToTableFormat(stackInstances),
+			)
+			target.stackSetNames = append(target.stackSetNames, stackSetName)
+			stackSetsCount++
+		}
+	}
+
+	if stackSetsCount == 0 {
+		io.Logger.Info().Msg("Finished...")
+		return false, nil
+	}
+
+	label := fmt.Sprintf("Delete these %d StackSets with all the stack instances?", stackSetsCount)
+	if !a.Yes && !io.GetTypedConfirmation(label, "delete") {
+		io.Logger.Info().Msg("Finished...")
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// deleteStackSets deletes the StackSets in all the targets. The deletion continues after a failure, and the result
+// of each StackSet is displayed at the end, so that it is clear which StackSets remain.
+func (a *App) deleteStackSets(targets []*stackSetTarget) error {
+	header := []string{"Account", "Region", "StackSet", "Result"}
+	data := [][]string{}
+	failedStackSets := []string{}
+
+	for _, target := range targets {
+		accountId := target.accountId
+		if accountId == "" {
+			accountId = "-"
+		}
+
+		for _, stackSetName := range target.stackSetNames {
+			result := "SUCCEEDED"
+			if err := target.stackSetOperator.DeleteStackSet(target.ctx, aws.String(stackSetName)); err != nil {
+				io.LoggerFromContext(target.ctx).Error().Msg(err.Error())
+				result = "FAILED"
+				failedStackSets = append(failedStackSets, target.label()+"/"+stackSetName)
+			} else {
+				io.LoggerFromContext(target.ctx).Info().Msgf("Successfully deleted StackSet, %v", stackSetName)
+			}
+			data = append(data, []string{accountId, target.region, stackSetName, result})
+		}
+		for _, stackSetName := range target.skippedStackSetNames {
+			data = append(data, []string{accountId, target.region, stackSetName, "SKIPPED (not found)"})
+		}
+	}
+
+	io.Logger.Info().Msg("Summary of the deletion of the StackSets:\n" + *io.ToStringAsTableFormat(header, data))
+
+	if len(failedStackSets) > 0 {
+		errMsg := fmt.Sprintf("failed to delete %d StackSets: %v", len(failedStackSets), strings.Join(failedStackSets, ", "))
+		return fmt.Errorf("StackSetDeletionError: %v", errMsg)
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/operation"
+	"github.com/go-to-k/delstack/pkg/client"
+)
+
+// deletionTarget holds the stacks to delete and the operator for an account (role) and a region.
+type deletionTarget struct {
+	accountId                   string // empty if no role is assumed
+	region                      string
+	ctx                         context.Context
+	config                      aws.Config
+	cloudformationStackOperator *operation.CloudFormationStackOperator
+	stackNames                  []string
+	skippedStackNames           []string // not existing in the target
+}
+
+func (t *deletionTarget) label() string {
+	if t.accountId == "" {
+		return t.region
+	}
+	return t.accountId + "/" + t.region
+}
+
+// newDeletionTargets returns the targets for each role and region. For multiple targets, logs in the context of
+// each target are prefixed with the account and the region.
+func (a *App) newDeletionTargets(ctx context.Context) ([]*deletionTarget, error) {
+	targets := []*deletionTarget{}
+
+	roleArns := appendUnique([]string{}, a.RoleArns.Value())
+	if len(roleArns) == 0 && (a.ExternalId != "" || a.RoleSessionName != "") {
+		errMsg := fmt.Sprintln("--external-id and --role-session-name require --role-arn.")
+		return nil, fmt.Errorf("InvalidOptionError: %v", errMsg)
+	}
+	if len(roleArns) == 0 {
+		roleArns = []string{""}
+	}
+
+	for _, roleArn := range roleArns {
+		var accountId string
+		var assumeRoleOption *client.AssumeRoleOption
+		if roleArn != "" {
+			parsedArn, err := arn.Parse(roleArn)
+			if err != nil {
+				return nil, fmt.Errorf("InvalidOptionError: invalid role ARN %v: %v", roleArn, err)
+			}
+			accountId = parsedArn.AccountID
+			assumeRoleOption = &client.AssumeRoleOption{
+				RoleArn:         roleArn,
+				ExternalId:      a.ExternalId,
+				RoleSessionName: a.RoleSessionName,
+			}
+		}
+
+		regions, err := a.getRegions(ctx, assumeRoleOption)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, region := range regions {
+			config, err := client.LoadAWSConfig(ctx, region, a.Profile, assumeRoleOption)
+			if err != nil {
+				return nil, err
+			}
+
+			targets = append(targets, &deletionTarget{
+				accountId:  accountId,
+				region:     config.Region,
+				ctx:        ctx,
+				config:     config,
+				stackNames: []string{},
+			})
+		}
+	}
+
+	if len(targets) > 1 {
+		for _, target := range targets {
+			target.ctx = io.ContextWithLogger(target.ctx, io.NewPrefixedLogger(target.label()))
+		}
+	}
+
+	return targets, nil
+}
+
+// getRegions returns the regions specified in the command options, or all enabled regions with --all-regions.
+// It returns an empty region (the region of the profile or the default) if no region is specified.
+func (a *App) getRegions(ctx context.Context, assumeRoleOption *client.AssumeRoleOption) ([]string, error) {
+	if a.AllRegions {
+		if len(a.Regions.Value()) > 0 {
+			errMsg := fmt.Sprintln("--region and --all-regions cannot be specified at the same time.")
+			return nil, fmt.Errorf("InvalidOptionError: %v", errMsg)
+		}
+
+		config, err := client.LoadAWSConfig(ctx, "", a.Profile, assumeRoleOption)
+		if err != nil {
+			return nil, err
+		}
+		ec2Client := client.NewEc2(ec2.NewFromConfig(config), a.Timeout)
+
+		return ec2Client.DescribeRegions(ctx)
+	}
+
+	regions := appendUnique([]string{}, a.Regions.Value())
+	if len(regions) == 0 {
+		return []string{""}, nil
+	}
+	return regions, nil
+}
+
+// skipNotExistingStacks drops the stacks not existing in each target for multiple targets, since the same stack
+// names are specified for all the regions and accounts. It returns NotExistsError if the stacks are not found in
+// any target.
+func (a *App) skipNotExistingStacks(targets []*deletionTarget) error {
+	if len(targets) <= 1 {
+		return nil
+	}
+
+	found := false
+	skippedStackNames := []string{}
+	for _, target := range targets {
+		if len(target.stackNames) == 0 {
+			continue
+		}
+
+		existingStackNames, err := target.cloudformationStackOperator.ListExistingStacks(target.ctx, target.stackNames)
+		if err != nil {
+			return err
+		}
+
+		target.skippedStackNames = []string{}
+		for _, stackName := range target.stackNames {
+			if !containsString(existingStackNames, stackName) {
+				target.skippedStackNames = append(target.skippedStackNames, stackName)
+			}
+		}
+		if len(target.skippedStackNames) > 0 {
+			io.LoggerFromContext(target.ctx).Info().Msgf("Skip the stacks not found: %v", strings.Join(target.skippedStackNames, ", "))
+		}
+
+		target.stackNames = existingStackNames
+		found = found || len(existingStackNames) > 0
+		skippedStackNames = appendUnique(skippedStackNames, target.skippedStackNames)
+	}
+
+	if !found && len(skippedStackNames) > 0 {
+		errMsg := fmt.Sprintf("%v stacks not found in any target.", strings.Join(skippedStackNames, ", "))
+		return fmt.Errorf("NotExistsError: %v", errMsg)
+	}
+
+	return nil
+}
+
+func (a *App) planTargets(targets []*deletionTarget) error {
+	isRootStack := true
+
+	for _, target := range targets {
+		if len(target.stackNames) == 0 {
+			continue
+		}
+
+		stackPlans := []operation.StackPlan{}
+		for _, stackName := range target.stackNames {
+			plans, err := target.cloudformationStackOperator.PlanCloudFormationStack(target.ctx, aws.String(stackName), isRootStack)
+			if err != nil {
+				return err
+			}
+			stackPlans = append(stackPlans, plans...)
+		}
+		a.outputStackPlans(target.ctx, target.stackNames, stackPlans)
+	}
+
+	return nil
+}
+
+// deleteTargets deletes the stacks of each target concurrently, and outputs the summary for multiple targets.
+func (a *App) deleteTargets(targets []*deletionTarget) error {
+	if len(targets) == 1 {
+		return targets[0].cloudformationStackOperator.DeleteCloudFormationStacks(targets[0].ctx, targets[0].stackNames)
+	}
+
+	errs := make([]error, len(targets))
+	wg := sync.WaitGroup{}
+
+	for i, target := range targets {
+		if len(target.stackNames) == 0 {
+			continue
+		}
+
+		i, target := i, target
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			errs[i] = target.cloudformationStackOperator.DeleteCloudFormationStacks(target.ctx, target.stackNames)
+			if errs[i] != nil {
+				io.LoggerFromContext(target.ctx).Error().Msg(errs[i].Error())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return a.outputTargetsSummary(targets, errs)
+}
+
+func (a *App) outputTargetsSummary(targets []*deletionTarget, errs []error) error {
+	header := []string{"Account", "Region", "Stacks", "Result"}
+	data := [][]string{}
+	failedTargets := []string{}
+
+	for i, target := range targets {
+		accountId := target.accountId
+		if accountId == "" {
+			accountId = "-"
+		}
+
+		if len(target.stackNames) > 0 {
+			result := "SUCCEEDED"
+			if errs[i] != nil {
+				result = "FAILED"
+				failedTargets = append(failedTargets, target.label())
+			}
+			data = append(data, []string{accountId, target.region, strings.Join(target.stackNames, "\n"), result})
+		}
+		if len(target.skippedStackNames) > 0 {
+			data = append(data, []string{accountId, target.region, strings.Join(target.skippedStackNames, "\n"), "SKIPPED (not found)"})
+		}
+	}
+
+	io.Logger.Info().Msg("Summary of the deletion in all targets:\n" + *io.ToStringAsTableFormat(header, data))
+
+	if len(failedTargets) > 0 {
+		return fmt.Errorf("MultiTargetDeletionError: failed to delete in %d targets: %v", len(failedTargets), strings.Join(failedTargets, ", "))
+	}
+
+	return nil
+}
+
+func containsString(list []string, target string) bool {
+	for _, v := range list {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const FileName = "delstack.yaml"
+
+// Config is the project configuration in delstack.yaml. Each field is a default value of the command options,
+// except ProtectedStacks, which cannot be overridden.
+type Config struct {
+	Profile              string     `yaml:"profile"`
+	Region               StringList `yaml:"region"`
+	AllowedResourceTypes StringList `yaml:"allowedResourceTypes"`
+	ProtectedStacks      StringList `yaml:"protectedStacks"`
+	Concurrency          int        `yaml:"concurrency"`
+}
+
+// StringList is a list of strings that can also be written as a single string in YAML.
+type StringList []string
+
+func (l *StringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = StringList{value.Value}
+		return nil
+	}
+
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// Load reads delstack.yaml in the working directory, or in $XDG_CONFIG_HOME/delstack if not found.
+// It returns an empty config if no file is found.
+func Load() (*Config, string, error) {
+	for _, path := range SearchPaths() {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("ConfigError: %v", err)
+		}
+
+		cfg, err := Parse(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("ConfigError: %v: %v", path, err)
+		}
+		return cfg, path, nil
+	}
+
+	return &Config{}, "", nil
+}
+
+// SearchPaths returns the paths of the config file in order of priority.
+func SearchPaths() []string {
+	paths := []string{FileName}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return paths
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+
+	return append(paths, filepath.Join(configHome, "delstack", FileName))
+}
+
+func Parse(data []byte) (*Config, error) {
+	cfg := &Config{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if cfg.Concurrency < 0 {
+		return nil, fmt.Errorf("concurrency must be a positive number: %d", cfg.Concurrency)
+	}
+
+	return cfg, nil
+}
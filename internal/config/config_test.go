@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+/*
+	Test Cases
+*/
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name    string
+		data    string
+		want    *Config
+		wantErr bool
+	}{
+		{
+			name: "all fields",
+			data: `
+profile: dev
+region:
+  - us-east-1
+  - ap-northeast-1
+allowedResourceTypes:
+  - AWS::S3::Bucket
+  - AWS::ECR::Repository
+protectedStacks:
+  - prod-*
+  - re:^shared-
+concurrency: 4
+`,
+			want: &Config{
+				Profile:              "dev",
+				Region:               StringList{"us-east-1", "ap-northeast-1"},
+				AllowedResourceTypes: StringList{"AWS::S3::Bucket", "AWS::ECR::Repository"},
+				ProtectedStacks:      StringList{"prod-*", "re:^shared-"},
+				Concurrency:          4,
+			},
+			wantErr: false,
+		},
+		{
+			name: "single strings for lists",
+			data: `
+region: us-east-1
+protectedStacks: prod-*
+`,
+			want: &Config{
+				Region:          StringList{"us-east-1"},
+				ProtectedStacks: StringList{"prod-*"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty file",
+			data:    "",
+			want:    &Config{},
+			wantErr: false,
+		},
+		{
+			name:    "unknown field",
+			data:    "profiles: dev\n",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "negative concurrency",
+			data:    "concurrency: -1\n",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	cfg, path, err := Load()
+	if err != nil {
+		t.Fatalf("error = %#v", err)
+	}
+	if path != "" || !reflect.DeepEqual(cfg, &Config{}) {
+		t.Errorf("got = %#v, %v, want an empty config", cfg, path)
+	}
+
+	wantPath := filepath.Join(configHome, "delstack", FileName)
+	if err := os.MkdirAll(filepath.Dir(wantPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(wantPath, []byte("profile: dev\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, path, err = Load()
+	if err != nil {
+		t.Fatalf("error = %#v", err)
+	}
+	if path != wantPath || cfg.Profile != "dev" {
+		t.Errorf("got = %#v, %v, want profile dev in %v", cfg, path, wantPath)
+	}
+}
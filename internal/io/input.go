@@ -71,3 +71,14 @@ func GetYesNo(label string) bool {
 		}
 	}
 }
+
+// GetTypedConfirmation returns true only if the user types the expected text exactly.
+func GetTypedConfirmation(label string, expected string) bool {
+	r := bufio.NewReader(os.Stdin)
+
+	fmt.Fprintf(os.Stderr, "%s (type \"%s\" to confirm) ", label, expected)
+	s, _ := r.ReadString('\n')
+	fmt.Fprintln(os.Stderr)
+
+	return strings.TrimSpace(s) == expected
+}
@@ -1,6 +1,8 @@
 package io
 
 import (
+	"context"
+	"fmt"
 	"os"
 
 	"github.com/rs/zerolog"
@@ -8,12 +10,39 @@ import (
 
 var Logger *zerolog.Logger
 
+type loggerKey struct{}
+
 func NewLogger(isDebug bool) {
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	if isDebug {
 		zerolog.SetGlobalLevel(zerolog.DebugLevel)
 	}
 
+	l := zerolog.New(newConsoleWriter(""))
+
+	Logger = &l
+}
+
+// NewPrefixedLogger returns a logger that prefixes every message, e.g. with a region.
+func NewPrefixedLogger(prefix string) *zerolog.Logger {
+	l := zerolog.New(newConsoleWriter(prefix))
+	return &l
+}
+
+// ContextWithLogger returns a context from which LoggerFromContext returns the logger.
+func ContextWithLogger(ctx context.Context, logger *zerolog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext returns the logger set by ContextWithLogger, or the default Logger.
+func LoggerFromContext(ctx context.Context) *zerolog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zerolog.Logger); ok {
+		return logger
+	}
+	return Logger
+}
+
+func newConsoleWriter(prefix string) *zerolog.ConsoleWriter {
 	consoleWriter := zerolog.ConsoleWriter{
 		Out: os.Stderr,
 		PartsExclude: []string{
@@ -21,7 +50,14 @@ func NewLogger(isDebug bool) {
 		},
 	}
 
-	l := zerolog.New(&consoleWriter)
+	if prefix != "" {
+		consoleWriter.FormatMessage = func(i interface{}) string {
+			if i == nil {
+				return fmt.Sprintf("[%s]", prefix)
+			}
+			return fmt.Sprintf("[%s] %s", prefix, i)
+		}
+	}
 
-	Logger = &l
+	return &consoleWriter
 }
@@ -0,0 +1,229 @@
+package journal
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const FileName = "journal.jsonl"
+
+type Step string
+
+const (
+	// StepNormalDeleteAttempted means the normal DeleteStack of a stack finished with DELETE_FAILED.
+	StepNormalDeleteAttempted Step = "NormalDeleteAttempted"
+	// StepResourceDeleted means a DELETE_FAILED resource was force deleted by an operator.
+	StepResourceDeleted Step = "ResourceDeleted"
+	// StepStackDeleted means a root or nested stack was deleted, normally or by the final DeleteStack retaining
+	// the force deleted resources.
+	StepStackDeleted Step = "StackDeleted"
+)
+
+// Entry is a step recorded in the journal. Scope distinguishes the account and the region of the step.
+type Entry struct {
+	Scope              string `json:"scope,omitempty"`
+	Step               Step   `json:"step"`
+	StackName          string `json:"stackName,omitempty"`
+	ResourceType       string `json:"resourceType,omitempty"`
+	PhysicalResourceId string `json:"physicalResourceId,omitempty"`
+}
+
+type journalState struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	entries map[Entry]struct{}
+}
+
+// Journal records the steps of the deletion in a local file, so that an interrupted run can skip them on resume.
+// All methods of a nil Journal are no-ops.
+type Journal struct {
+	scope string
+	state *journalState
+}
+
+// DefaultPath returns journal-<hash of key>.jsonl in $XDG_STATE_HOME/delstack, or ~/.local/state/delstack if
+// XDG_STATE_HOME is not set. The key identifies the deletion by the stacks, the regions and the accounts, so that
+// the runs of other deletions do not start over the journal of an interrupted one.
+func DefaultPath(key string) (string, error) {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("JournalError: %v", err)
+		}
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(stateHome, "delstack", fmt.Sprintf("journal-%x.jsonl", sum[:8])), nil
+}
+
+// Open opens the journal file. With resume, the steps recorded in the previous run are loaded and new steps are
+// appended to them; otherwise the file is started over.
+func Open(path string, resume bool) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("JournalError: %v", err)
+	}
+
+	entries := map[Entry]struct{}{}
+	flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+
+	if resume {
+		loaded, err := load(path)
+		if err != nil {
+			return nil, err
+		}
+		entries = loaded
+		flag = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	file, err := os.OpenFile(path, flag, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("JournalError: %v", err)
+	}
+
+	return &Journal{
+		state: &journalState{
+			path:    path,
+			file:    file,
+			entries: entries,
+		},
+	}, nil
+}
+
+func load(path string) (map[Entry]struct{}, error) {
+	entries := map[Entry]struct{}{}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("JournalError: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// The last line may be broken if the previous run was killed while writing it.
+			continue
+		}
+		entries[entry] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("JournalError: %v", err)
+	}
+
+	return entries, nil
+}
+
+// WithScope returns the journal whose entries are recorded and looked up in the scope.
+func (j *Journal) WithScope(scope string) *Journal {
+	if j == nil {
+		return nil
+	}
+	return &Journal{
+		scope: scope,
+		state: j.state,
+	}
+}
+
+// Len returns the number of the steps recorded in all scopes, including the previous run.
+func (j *Journal) Len() int {
+	if j == nil {
+		return 0
+	}
+
+	j.state.mu.Lock()
+	defer j.state.mu.Unlock()
+
+	return len(j.state.entries)
+}
+
+func (j *Journal) IsDone(entry Entry) bool {
+	if j == nil {
+		return false
+	}
+	entry.Scope = j.scope
+
+	j.state.mu.Lock()
+	defer j.state.mu.Unlock()
+
+	_, ok := j.state.entries[entry]
+	return ok
+}
+
+// Record writes the step to the file and syncs it, so that the step survives the interruption of the process.
+func (j *Journal) Record(entry Entry) error {
+	if j == nil {
+		return nil
+	}
+	entry.Scope = j.scope
+
+	j.state.mu.Lock()
+	defer j.state.mu.Unlock()
+
+	if _, ok := j.state.entries[entry]; ok {
+		return nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("JournalError: %v", err)
+	}
+	if _, err := j.state.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("JournalError: %v", err)
+	}
+	if err := j.state.file.Sync(); err != nil {
+		return fmt.Errorf("JournalError: %v", err)
+	}
+
+	j.state.entries[entry] = struct{}{}
+	return nil
+}
+
+func (j *Journal) Close() error {
+	if j == nil {
+		return nil
+	}
+	if err := j.state.file.Close(); err != nil {
+		return fmt.Errorf("JournalError: %v", err)
+	}
+	return nil
+}
+
+// Remove closes and removes the journal file, when all the deletion has been completed.
+func (j *Journal) Remove() error {
+	if j == nil {
+		return nil
+	}
+	if err := j.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(j.state.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("JournalError: %v", err)
+	}
+	return nil
+}
+
+type contextKey struct{}
+
+func ContextWithJournal(ctx context.Context, journal *Journal) context.Context {
+	return context.WithValue(ctx, contextKey{}, journal)
+}
+
+// FromContext returns the journal in the context, or nil if no journal is set.
+func FromContext(ctx context.Context) *Journal {
+	journal, _ := ctx.Value(contextKey{}).(*Journal)
+	return journal
+}
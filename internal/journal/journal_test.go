@@ -0,0 +1,136 @@
+package journal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/*
+	Test Cases
+*/
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "delstack", FileName)
+	entry := Entry{Step: StepResourceDeleted, ResourceType: "AWS::S3::Bucket", PhysicalResourceId: "bucket"}
+
+	j, err := Open(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scoped := j.WithScope("us-east-1")
+	if err := scoped.Record(entry); err != nil {
+		t.Fatal(err)
+	}
+	if err := scoped.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name   string
+		resume bool
+		scope  string
+		want   bool
+	}{
+		{
+			name:   "done in the same scope on resume",
+			resume: true,
+			scope:  "us-east-1",
+			want:   true,
+		},
+		{
+			name:   "not done in another scope on resume",
+			resume: true,
+			scope:  "us-west-2",
+			want:   false,
+		},
+		{
+			name:   "not done without resume",
+			resume: false,
+			scope:  "us-east-1",
+			want:   false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			j, err := Open(path, tt.resume)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer j.Close()
+
+			got := j.WithScope(tt.scope).IsDone(entry)
+			if got != tt.want {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+
+			// re-record so that the next case can read it even after the truncation
+			if err := j.WithScope("us-east-1").Record(entry); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestJournal_Remove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+
+	j, err := Open(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("journal file is not removed: %v", err)
+	}
+}
+
+func TestJournal_Nil(t *testing.T) {
+	j := FromContext(context.Background())
+	if j != nil {
+		t.Fatalf("got = %#v, want nil", j)
+	}
+
+	entry := Entry{Step: StepStackDeleted, StackName: "test"}
+	if err := j.WithScope("us-east-1").Record(entry); err != nil {
+		t.Errorf("error = %#v", err)
+	}
+	if j.IsDone(entry) {
+		t.Errorf("nil journal is done")
+	}
+	if err := j.Remove(); err != nil {
+		t.Errorf("error = %#v", err)
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	path, err := DefaultPath("us-east-1:stack=test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(path) != filepath.Join(os.Getenv("XDG_STATE_HOME"), "delstack") {
+		t.Errorf("path = %#v, not in the state directory", path)
+	}
+
+	samePath, err := DefaultPath("us-east-1:stack=test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if samePath != path {
+		t.Errorf("path = %#v, want %#v for the same key", samePath, path)
+	}
+
+	otherPath, err := DefaultPath("us-west-2:stack=test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otherPath == path {
+		t.Errorf("path = %#v, want another path for another key", otherPath)
+	}
+}
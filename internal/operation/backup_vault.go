@@ -44,7 +44,9 @@ func (o *BackupVaultOperator) DeleteResources(ctx context.Context) error {
 		eg.Go(func() error {
 			defer sem.Release(1)
 
-			return o.DeleteBackupVault(ctx, backupVault.PhysicalResourceId)
+			return deleteResourceWithJournal(ctx, backupVault, func() error {
+				return o.DeleteBackupVault(ctx, backupVault.PhysicalResourceId)
+			})
 		})
 	}
 
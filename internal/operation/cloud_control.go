@@ -0,0 +1,88 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cloudControlTypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+var _ IOperator = (*CloudControlOperator)(nil)
+
+// CloudControlOperator deletes the DELETE_FAILED resources of the types without dedicated operators by Cloud Control
+// API, as the fallback selected by the CloudControl type.
+type CloudControlOperator struct {
+	client    client.ICloudControl
+	resources []*types.StackResourceSummary
+}
+
+func NewCloudControlOperator(client client.ICloudControl) *CloudControlOperator {
+	return &CloudControlOperator{
+		client:    client,
+		resources: []*types.StackResourceSummary{},
+	}
+}
+
+func (o *CloudControlOperator) AddResource(resource *types.StackResourceSummary) {
+	o.resources = append(o.resources, resource)
+}
+
+func (o *CloudControlOperator) GetResourcesLength() int {
+	return len(o.resources)
+}
+
+func (o *CloudControlOperator) DeleteResources(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	sem := semaphore.NewWeighted(int64(runtime.NumCPU()))
+
+	for _, resource := range o.resources {
+		resource := resource
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return err
+		}
+		eg.Go(func() error {
+			defer sem.Release(1)
+
+			return deleteResourceWithJournal(ctx, resource, func() error {
+				return o.DeleteResource(ctx, resource.ResourceType, resource.PhysicalResourceId)
+			})
+		})
+	}
+
+	return eg.Wait()
+}
+
+// DeleteResource deletes the resource of the type by its physical ID and waits for the request to finish.
+// The resource that no longer exists is regarded as deleted.
+func (o *CloudControlOperator) DeleteResource(ctx context.Context, typeName *string, identifier *string) error {
+	progressEvent, err := o.client.DeleteResource(ctx, typeName, identifier)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case progressEvent.OperationStatus == cloudControlTypes.OperationStatusSuccess:
+		io.LoggerFromContext(ctx).Info().Msgf("Deleted %v (%v) by Cloud Control", aws.ToString(identifier), aws.ToString(typeName))
+		return nil
+	case progressEvent.ErrorCode == cloudControlTypes.HandlerErrorCodeNotFound:
+		io.LoggerFromContext(ctx).Info().Msgf("%v (%v) does not exist", aws.ToString(identifier), aws.ToString(typeName))
+		return nil
+	default:
+		errMsg := fmt.Sprintf(
+			"%v (%v): the request is %v: %v: %v",
+			aws.ToString(identifier),
+			aws.ToString(typeName),
+			progressEvent.OperationStatus,
+			progressEvent.ErrorCode,
+			aws.ToString(progressEvent.StatusMessage),
+		)
+		return fmt.Errorf("CloudControlDeletionError: %v", errMsg)
+	}
+}
@@ -0,0 +1,189 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cloudControlTypes "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	cfnTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestCloudControlOperator_DeleteResource(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx        context.Context
+		typeName   *string
+		identifier *string
+	}
+
+	cases := []struct {
+		name          string
+		args          args
+		prepareMockFn func(m *client.MockICloudControl)
+		want          error
+		wantErr       bool
+	}{
+		{
+			name: "delete resource successfully",
+			args: args{
+				ctx:        context.Background(),
+				typeName:   aws.String("AWS::SNS::Topic"),
+				identifier: aws.String("test"),
+			},
+			prepareMockFn: func(m *client.MockICloudControl) {
+				m.EXPECT().DeleteResource(gomock.Any(), aws.String("AWS::SNS::Topic"), aws.String("test")).Return(
+					&cloudControlTypes.ProgressEvent{OperationStatus: cloudControlTypes.OperationStatusSuccess}, nil,
+				)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete resource successfully for resource not exists",
+			args: args{
+				ctx:        context.Background(),
+				typeName:   aws.String("AWS::SNS::Topic"),
+				identifier: aws.String("test"),
+			},
+			prepareMockFn: func(m *client.MockICloudControl) {
+				m.EXPECT().DeleteResource(gomock.Any(), aws.String("AWS::SNS::Topic"), aws.String("test")).Return(
+					&cloudControlTypes.ProgressEvent{
+						OperationStatus: cloudControlTypes.OperationStatusFailed,
+						ErrorCode:       cloudControlTypes.HandlerErrorCodeNotFound,
+					}, nil,
+				)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete resource failure for failed request",
+			args: args{
+				ctx:        context.Background(),
+				typeName:   aws.String("AWS::SNS::Topic"),
+				identifier: aws.String("test"),
+			},
+			prepareMockFn: func(m *client.MockICloudControl) {
+				m.EXPECT().DeleteResource(gomock.Any(), aws.String("AWS::SNS::Topic"), aws.String("test")).Return(
+					&cloudControlTypes.ProgressEvent{
+						OperationStatus: cloudControlTypes.OperationStatusFailed,
+						ErrorCode:       cloudControlTypes.HandlerErrorCodeAccessDenied,
+						StatusMessage:   aws.String("Access denied"),
+					}, nil,
+				)
+			},
+			want:    fmt.Errorf("CloudControlDeletionError: test (AWS::SNS::Topic): the request is FAILED: AccessDenied: Access denied"),
+			wantErr: true,
+		},
+		{
+			name: "delete resource failure",
+			args: args{
+				ctx:        context.Background(),
+				typeName:   aws.String("AWS::SNS::Topic"),
+				identifier: aws.String("test"),
+			},
+			prepareMockFn: func(m *client.MockICloudControl) {
+				m.EXPECT().DeleteResource(gomock.Any(), aws.String("AWS::SNS::Topic"), aws.String("test")).Return(nil, fmt.Errorf("DeleteResourceError"))
+			},
+			want:    fmt.Errorf("DeleteResourceError"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudControlMock := client.NewMockICloudControl(ctrl)
+			tt.prepareMockFn(cloudControlMock)
+
+			cloudControlOperator := NewCloudControlOperator(cloudControlMock)
+
+			err := cloudControlOperator.DeleteResource(tt.args.ctx, tt.args.typeName, tt.args.identifier)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
+				return
+			}
+		})
+	}
+}
+
+func TestCloudControlOperator_DeleteResourcesForCloudControl(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx context.Context
+	}
+
+	cases := []struct {
+		name          string
+		args          args
+		prepareMockFn func(m *client.MockICloudControl)
+		want          error
+		wantErr       bool
+	}{
+		{
+			name: "delete resources successfully",
+			args: args{
+				ctx: context.Background(),
+			},
+			prepareMockFn: func(m *client.MockICloudControl) {
+				m.EXPECT().DeleteResource(gomock.Any(), aws.String("AWS::SNS::Topic"), aws.String("PhysicalResourceId1")).Return(
+					&cloudControlTypes.ProgressEvent{OperationStatus: cloudControlTypes.OperationStatusSuccess}, nil,
+				)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete resources failure",
+			args: args{
+				ctx: context.Background(),
+			},
+			prepareMockFn: func(m *client.MockICloudControl) {
+				m.EXPECT().DeleteResource(gomock.Any(), aws.String("AWS::SNS::Topic"), aws.String("PhysicalResourceId1")).Return(nil, fmt.Errorf("DeleteResourceError"))
+			},
+			want:    fmt.Errorf("DeleteResourceError"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudControlMock := client.NewMockICloudControl(ctrl)
+			tt.prepareMockFn(cloudControlMock)
+
+			cloudControlOperator := NewCloudControlOperator(cloudControlMock)
+			cloudControlOperator.AddResource(&cfnTypes.StackResourceSummary{
+				LogicalResourceId:  aws.String("LogicalResourceId1"),
+				ResourceStatus:     "DELETE_FAILED",
+				ResourceType:       aws.String("AWS::SNS::Topic"),
+				PhysicalResourceId: aws.String("PhysicalResourceId1"),
+			})
+
+			err := cloudControlOperator.DeleteResources(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
+				return
+			}
+		})
+	}
+}
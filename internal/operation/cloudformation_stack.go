@@ -3,13 +3,17 @@ package operation
 import (
 	"context"
 	"fmt"
+	"path"
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/journal"
+	"github.com/go-to-k/delstack/internal/resourcetype"
 	"github.com/go-to-k/delstack/pkg/client"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
@@ -17,23 +21,118 @@ import (
 
 var _ IOperator = (*CloudFormationStackOperator)(nil)
 
-const StackNameRule = `^arn:aws:cloudformation:[^:]*:[0-9]*:stack/([^/]*)/.*$`
+// StackNameRule matches the stack IDs in all partitions, e.g. arn:aws-us-gov:cloudformation:..., and extracts the
+// stack names.
+const StackNameRule = `^arn:[^:]+:cloudformation:[^:]*:[0-9]*:stack/([^/]*)/.*$`
+
+// RegexpPatternPrefix is the prefix of a stack name pattern to be handled as a regular expression instead of a glob.
+const RegexpPatternPrefix = "re:"
 
 var StackNameRuleRegExp = regexp.MustCompile(StackNameRule)
 
+// DefaultMaxDeletionPasses is the default maximum number of passes of the force deletion for a stack.
+const DefaultMaxDeletionPasses = 3
+
+// StackPlan is the result of classifying the resources of a stack without deleting anything.
+type StackPlan struct {
+	StackName              string
+	StackStatus            types.StackStatus
+	TerminationProtection  bool
+	ForceDeletionResources []types.StackResourceSummary
+	UnsupportedResources   []types.StackResourceSummary
+}
+
+// StackDeletionMode is how a stack is deleted after the force deletion of the DELETE_FAILED resources.
+type StackDeletionMode string
+
+const (
+	// StackDeletionModeRetain deletes the stack retaining the force deleted resources by RetainResources.
+	StackDeletionModeRetain StackDeletionMode = "retain"
+	// StackDeletionModeForce deletes the stack by FORCE_DELETE_STACK, which abandons the DELETE_FAILED resources.
+	StackDeletionModeForce StackDeletionMode = "force"
+	// StackDeletionModeAbandonUnsupported also abandons the resources of unsupported types by FORCE_DELETE_STACK
+	// instead of failing, and reports their physical IDs as leftovers.
+	StackDeletionModeAbandonUnsupported StackDeletionMode = "abandon-unsupported"
+)
+
+// ParseStackDeletionMode returns the StackDeletionMode of the value, or StackDeletionModeRetain if it is empty.
+func ParseStackDeletionMode(value string) (StackDeletionMode, error) {
+	switch mode := StackDeletionMode(value); mode {
+	case "":
+		return StackDeletionModeRetain, nil
+	case StackDeletionModeRetain, StackDeletionModeForce, StackDeletionModeAbandonUnsupported:
+		return mode, nil
+	default:
+		errMsg := fmt.Sprintf("--deletion-mode must be one of %v, %v and %v: %v", StackDeletionModeRetain, StackDeletionModeForce, StackDeletionModeAbandonUnsupported, value)
+		return "", fmt.Errorf("InvalidOptionError: %v", errMsg)
+	}
+}
+
 type CloudFormationStackOperator struct {
-	config              aws.Config
-	client              client.ICloudFormation
-	resources           []*types.StackResourceSummary
-	targetResourceTypes []string
+	config               aws.Config
+	client               client.ICloudFormation
+	resources            []*types.StackResourceSummary
+	targetResourceTypes  []string
+	stackDeletionTimeout time.Duration
+	stackDeletionMode    StackDeletionMode
+	cfnRoleArn           *string
+	iamClient            client.IIam
+	stsClient            client.ISts
+	concurrency          int
+	purgeRetained        bool
+	maxDeletionPasses    int
+
+	terminationProtectionDisabledStacks map[string]bool
 }
 
-func NewCloudFormationStackOperator(config aws.Config, client client.ICloudFormation, targetResourceTypes []string) *CloudFormationStackOperator {
+func NewCloudFormationStackOperator(config aws.Config, client client.ICloudFormation, targetResourceTypes []string, stackDeletionTimeout time.Duration) *CloudFormationStackOperator {
 	return &CloudFormationStackOperator{
-		config:              config,
-		client:              client,
-		resources:           []*types.StackResourceSummary{},
-		targetResourceTypes: targetResourceTypes,
+		config:               config,
+		client:               client,
+		resources:            []*types.StackResourceSummary{},
+		targetResourceTypes:  targetResourceTypes,
+		stackDeletionTimeout: stackDeletionTimeout,
+		stackDeletionMode:    StackDeletionModeRetain,
+	}
+}
+
+// SetConcurrency sets the maximum number of root stacks deleted concurrently in DeleteCloudFormationStacks.
+// The number of CPUs is used if it is not positive.
+func (o *CloudFormationStackOperator) SetConcurrency(concurrency int) {
+	o.concurrency = concurrency
+}
+
+// SetMaxDeletionPasses sets the maximum number of passes of the force deletion of the DELETE_FAILED resources and
+// DeleteStack for a stack. A pass is retried while it makes progress. Only one pass is made if it is not positive.
+func (o *CloudFormationStackOperator) SetMaxDeletionPasses(maxDeletionPasses int) {
+	o.maxDeletionPasses = maxDeletionPasses
+}
+
+// SetStackDeletionMode sets how stacks are deleted after the force deletion of the DELETE_FAILED resources.
+func (o *CloudFormationStackOperator) SetStackDeletionMode(stackDeletionMode StackDeletionMode) {
+	o.stackDeletionMode = stackDeletionMode
+}
+
+// SetServiceRole sets the service role passed to every DeleteStack, or nil to use the role recorded in the stack.
+// The IAM and STS clients are used to find the role of the caller to delete with if the recorded role no longer exists.
+func (o *CloudFormationStackOperator) SetServiceRole(cfnRoleArn *string, iamClient client.IIam, stsClient client.ISts) {
+	o.cfnRoleArn = cfnRoleArn
+	o.iamClient = iamClient
+	o.stsClient = stsClient
+}
+
+// SetPurgeRetained sets whether the resources retained by DeletionPolicy are deleted by the operators after the
+// deletion of the root stacks in DeleteCloudFormationStacks.
+func (o *CloudFormationStackOperator) SetPurgeRetained(purgeRetained bool) {
+	o.purgeRetained = purgeRetained
+}
+
+// SetTerminationProtectionDisabledStacks sets the root stacks whose termination protection is disabled before the
+// deletion. The deletion of the other stacks with the termination protection fails as before.
+func (o *CloudFormationStackOperator) SetTerminationProtectionDisabledStacks(stackNames []string) {
+	o.terminationProtectionDisabledStacks = map[string]bool{}
+	for _, stackName := range stackNames {
+		o.terminationProtectionDisabledStacks[stackName] = true
 	}
 }
 
@@ -59,7 +158,7 @@ func (o *CloudFormationStackOperator) DeleteResources(ctx context.Context) error
 			stackName := StackNameRuleRegExp.ReplaceAllString(aws.ToString(stack.PhysicalResourceId), `$1`)
 
 			isRootStack := false
-			operatorFactory := NewOperatorFactory(o.config)
+			operatorFactory := NewOperatorFactory(o.config, o.stackDeletionTimeout, o.stackDeletionMode, o.cfnRoleArn, o.maxDeletionPasses)
 			operatorCollection := NewOperatorCollection(o.config, operatorFactory, o.targetResourceTypes)
 			operatorManager := NewOperatorManager(operatorCollection)
 
@@ -71,36 +170,387 @@ func (o *CloudFormationStackOperator) DeleteResources(ctx context.Context) error
 }
 
 func (o *CloudFormationStackOperator) DeleteCloudFormationStack(ctx context.Context, stackName *string, isRootStack bool, operatorManager IOperatorManager) error {
-	isSuccess, err := o.deleteStackNormally(ctx, stackName, isRootStack)
-	if err != nil {
-		return err
+	j := journal.FromContext(ctx)
+	stackDeletedEntry := journal.Entry{
+		Step:      journal.StepStackDeleted,
+		StackName: aws.ToString(stackName),
 	}
-	if isSuccess {
+	normalDeleteAttemptedEntry := journal.Entry{
+		Step:      journal.StepNormalDeleteAttempted,
+		StackName: aws.ToString(stackName),
+	}
+
+	if j.IsDone(stackDeletedEntry) {
+		io.LoggerFromContext(ctx).Info().Msgf("Already deleted in the previous run, %v", *stackName)
 		return nil
 	}
 
-	stackResourceSummaries, err := o.client.ListStackResources(ctx, stackName)
-	if err != nil {
-		return err
+	ctx = contextWithStackPath(ctx, aws.ToString(stackName))
+	stackPath := stackPathFromContext(ctx)
+
+	// the nested stacks deleted by the operators share the streaming of the events with the root stack
+	if isRootStack {
+		var stopStreaming func()
+		ctx, stopStreaming = contextWithStackEventsStreaming(ctx)
+		defer stopStreaming()
 	}
 
-	operatorManager.SetOperatorCollection(stackName, stackResourceSummaries)
+	if j.IsDone(normalDeleteAttemptedEntry) {
+		io.LoggerFromContext(ctx).Info().Msgf("Resume the force deletion from the previous run, %v", *stackName)
 
-	if err := operatorManager.CheckResourceCounts(); err != nil {
-		return err
+		isDeleted, err := o.checkStackBeforeResume(ctx, stackName)
+		if err != nil {
+			return err
+		}
+		if isDeleted {
+			return j.Record(stackDeletedEntry)
+		}
+	} else {
+		isSuccess, err := o.deleteStackNormally(ctx, stackName, isRootStack)
+		if err != nil {
+			return err
+		}
+		if isSuccess {
+			return j.Record(stackDeletedEntry)
+		}
+		if err := j.Record(normalDeleteAttemptedEntry); err != nil {
+			return err
+		}
+	}
+
+	maxDeletionPasses := o.maxDeletionPasses
+	if maxDeletionPasses <= 0 {
+		maxDeletionPasses = 1
+	}
+
+	abandonedResources := []types.StackResourceSummary{}
+	retainedResources := []RetainedResource{}
+	previousDeleteFailedResources := []string{}
+	var passErr error
+	isOperatorFailure := false
+
+	for pass := 1; ; pass++ {
+		stackResourceSummaries, err := o.client.ListStackResources(ctx, stackName)
+		if err != nil {
+			return err
+		}
+
+		deleteFailedResources := listDeleteFailedLogicalResourceIds(stackResourceSummaries)
+		if pass > 1 {
+			resolved := subtractStrings(previousDeleteFailedResources, deleteFailedResources)
+			newlyFailed := subtractStrings(deleteFailedResources, previousDeleteFailedResources)
+			// the operators may succeed on the next try even for the same resources, e.g. a bucket refilled by a
+			// log delivery, but DeleteStack fails the same way again if nothing changed
+			if !isOperatorFailure && len(resolved) == 0 && len(newlyFailed) == 0 {
+				io.LoggerFromContext(ctx).Warn().Msgf("No progress in the pass %d of %v, so stop retrying the deletion", pass-1, stackPath)
+				return passErr
+			}
+			io.LoggerFromContext(ctx).Info().Msgf(
+				"Retry the deletion of %v (pass %d/%d): resolved: %v, newly DELETE_FAILED: %v",
+				stackPath,
+				pass,
+				maxDeletionPasses,
+				resolved,
+				newlyFailed,
+			)
+		}
+		previousDeleteFailedResources = deleteFailedResources
+
+		if err := o.setDeleteFailedReasons(ctx, stackName, stackResourceSummaries); err != nil {
+			return err
+		}
+
+		operatorManager.SetOperatorCollection(aws.String(stackPath), stackResourceSummaries)
+
+		if o.stackDeletionMode == StackDeletionModeAbandonUnsupported {
+			// the same resources are unsupported in every pass, so they are collected once
+			abandonedResources = appendUniqueResources(abandonedResources, operatorManager.GetUnsupportedResources())
+		} else if err := operatorManager.CheckResourceCounts(); err != nil {
+			return err
+		}
+
+		if err := operatorManager.DeleteResourceCollection(ctx); err != nil {
+			passErr = newForceDeletionError(stackPath, stackResourceSummaries, err)
+			isOperatorFailure = true
+		} else {
+			// FORCE_DELETE_STACK abandons all DELETE_FAILED resources, so they do not have to be retained
+			retainResources := operatorManager.GetLogicalResourceIds()
+			deletionMode := types.DeletionModeStandard
+			if o.stackDeletionMode != StackDeletionModeRetain {
+				retainResources = []string{}
+				deletionMode = types.DeletionModeForceDeleteStack
+			}
+
+			result, err := o.deleteStack(ctx, stackName, retainResources, deletionMode)
+			if err != nil {
+				return err
+			}
+			retainedResources = append(retainedResources, o.listRetainedCustomResources(stackName, stackResourceSummaries)...)
+			if result.Status != client.StackDeletionStatusFailed {
+				break
+			}
+			passErr = fmt.Errorf("StackDeletionFailedError: %v: %v", *stackName, result.StatusReason)
+			isOperatorFailure = false
+		}
+
+		if pass >= maxDeletionPasses {
+			return passErr
+		}
+		io.LoggerFromContext(ctx).Warn().Msgf("The pass %d/%d of %v failed: %v", pass, maxDeletionPasses, stackPath, passErr)
+	}
+
+	if len(abandonedResources) > 0 {
+		io.LoggerFromContext(ctx).Warn().Msgf(
+			"%v was deleted, but these resources unsupported were abandoned and remain:\n%v",
+			*stackName,
+			*abandonedResourcesToTableFormat(abandonedResources),
+		)
 	}
 
-	if err := operatorManager.DeleteResourceCollection(ctx); err != nil {
-		return err
+	if len(retainedResources) > 0 {
+		io.LoggerFromContext(ctx).Warn().Msgf(
+			"%v was deleted, but these custom resources failed to delete and remain:\n%v",
+			*stackName,
+			*RetainedResourcesToTableFormat(retainedResources),
+		)
 	}
 
-	if err := o.client.DeleteStack(ctx, stackName, operatorManager.GetLogicalResourceIds()); err != nil {
-		return err
+	return j.Record(stackDeletedEntry)
+}
+
+// appendUniqueResources appends the resources whose logical IDs are not in resources yet.
+func appendUniqueResources(resources []types.StackResourceSummary, newResources []types.StackResourceSummary) []types.StackResourceSummary {
+	for _, newResource := range newResources {
+		isDuplicated := false
+		for _, resource := range resources {
+			if aws.ToString(resource.LogicalResourceId) == aws.ToString(newResource.LogicalResourceId) {
+				isDuplicated = true
+				break
+			}
+		}
+		if !isDuplicated {
+			resources = append(resources, newResource)
+		}
+	}
+	return resources
+}
+
+func abandonedResourcesToTableFormat(resources []types.StackResourceSummary) *string {
+	header := []string{"ResourceType", "Resource", "PhysicalResourceId"}
+	data := [][]string{}
+	for _, resource := range resources {
+		data = append(data, []string{
+			aws.ToString(resource.ResourceType),
+			aws.ToString(resource.LogicalResourceId),
+			aws.ToString(resource.PhysicalResourceId),
+		})
+	}
+	return io.ToStringAsTableFormat(header, data)
+}
+
+// DeleteCloudFormationStacks deletes root stacks in parallel, but deletes stacks importing the exports of
+// other target stacks before the exporting stacks.
+func (o *CloudFormationStackOperator) DeleteCloudFormationStacks(ctx context.Context, stackNames []string) error {
+	// stacks deleted in the previous run no longer exist, so they are excluded from the dependencies
+	remainingStackNames := []string{}
+	for _, stackName := range stackNames {
+		if journal.FromContext(ctx).IsDone(journal.Entry{Step: journal.StepStackDeleted, StackName: stackName}) {
+			io.LoggerFromContext(ctx).Info().Msgf("Already deleted in the previous run, %v", stackName)
+			continue
+		}
+		remainingStackNames = append(remainingStackNames, stackName)
+	}
+	stackNames = remainingStackNames
+
+	dependencies := map[string][]string{}
+	if len(stackNames) > 1 {
+		var err error
+		dependencies, err = o.GetStackDependencies(ctx, stackNames)
+		if err != nil {
+			return err
+		}
+	}
+
+	deletedChannels := map[string]chan struct{}{}
+	for _, stackName := range stackNames {
+		deletedChannels[stackName] = make(chan struct{})
+	}
+
+	concurrency := o.concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.NumCPU()
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	sem := semaphore.NewWeighted(int64(concurrency))
+
+	for _, stackName := range stackNames {
+		stackName := stackName
+		eg.Go(func() error {
+			// wait for the deletion of stacks importing the exports of this stack
+			for _, importingStackName := range dependencies[stackName] {
+				select {
+				case <-deletedChannels[importingStackName]:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+
+			if err := sem.Acquire(ctx, 1); err != nil {
+				return err
+			}
+			defer sem.Release(1)
+
+			isRootStack := true
+			operatorFactory := NewOperatorFactory(o.config, o.stackDeletionTimeout, o.stackDeletionMode, o.cfnRoleArn, o.maxDeletionPasses)
+			operatorCollection := NewOperatorCollection(o.config, operatorFactory, o.targetResourceTypes)
+			operatorManager := NewOperatorManager(operatorCollection)
+
+			io.LoggerFromContext(ctx).Info().Msgf("Start deletion, %v", stackName)
+
+			retainedResources, err := o.ListRetainedResources(ctx, aws.String(stackName))
+			if err != nil {
+				return err
+			}
+
+			if err := o.DeleteCloudFormationStack(ctx, aws.String(stackName), isRootStack, operatorManager); err != nil {
+				return err
+			}
+
+			io.LoggerFromContext(ctx).Info().Msgf("Successfully deleted, %v", stackName)
+
+			if err := o.reportRetainedResources(ctx, aws.String(stackName), retainedResources); err != nil {
+				return err
+			}
+			close(deletedChannels[stackName])
+			return nil
+		})
+	}
+
+	return eg.Wait()
+}
+
+// GetStackDependencies returns, for each target stack, the target stacks that import its exports.
+func (o *CloudFormationStackOperator) GetStackDependencies(ctx context.Context, stackNames []string) (map[string][]string, error) {
+	dependencies := map[string][]string{}
+
+	targetStacks := map[string]bool{}
+	for _, stackName := range stackNames {
+		targetStacks[stackName] = true
+	}
+
+	for _, stackName := range stackNames {
+		stacks, err := o.client.DescribeStacks(ctx, aws.String(stackName))
+		if err != nil {
+			return dependencies, err
+		}
+		if len(stacks) == 0 {
+			errMsg := fmt.Sprintf("%s stack not found.", stackName)
+			return dependencies, fmt.Errorf("NotExistsError: %v", errMsg)
+		}
+
+		for _, output := range stacks[0].Outputs {
+			if output.ExportName == nil {
+				continue
+			}
+
+			importingStackNames, err := o.client.ListImports(ctx, output.ExportName)
+			if err != nil {
+				return dependencies, err
+			}
+
+			for _, importingStackName := range importingStackNames {
+				if !targetStacks[importingStackName] || importingStackName == stackName {
+					continue
+				}
+				if !containsString(dependencies[stackName], importingStackName) {
+					dependencies[stackName] = append(dependencies[stackName], importingStackName)
+				}
+			}
+		}
+	}
+
+	if err := checkCircularDependencies(stackNames, dependencies); err != nil {
+		return dependencies, err
+	}
+
+	return dependencies, nil
+}
+
+func checkCircularDependencies(stackNames []string, dependencies map[string][]string) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	states := map[string]int{}
+
+	var visit func(stackName string) error
+	visit = func(stackName string) error {
+		switch states[stackName] {
+		case visiting:
+			return fmt.Errorf("CircularDependencyError: the exports and imports of the stacks are circular: %v", stackName)
+		case visited:
+			return nil
+		}
+
+		states[stackName] = visiting
+		for _, importingStackName := range dependencies[stackName] {
+			if err := visit(importingStackName); err != nil {
+				return err
+			}
+		}
+		states[stackName] = visited
+
+		return nil
+	}
+
+	for _, stackName := range stackNames {
+		if err := visit(stackName); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+func containsString(list []string, target string) bool {
+	for _, v := range list {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStackBeforeResume reports whether the stack has been deleted since the previous run,
+// e.g. CloudFormation finished the deletion in the background, waiting for any in-flight operation.
+func (o *CloudFormationStackOperator) checkStackBeforeResume(ctx context.Context, stackName *string) (bool, error) {
+	stacks, err := o.client.DescribeStacks(ctx, stackName)
+	if err != nil {
+		return false, err
+	}
+	if len(stacks) == 0 || stacks[0].StackStatus == types.StackStatusDeleteComplete {
+		io.LoggerFromContext(ctx).Info().Msgf("Already deleted since the previous run, %v", *stackName)
+		return true, nil
+	}
+	if !client.IsStackInProgress(stacks[0].StackStatus) {
+		return false, nil
+	}
+
+	io.LoggerFromContext(ctx).Info().Msgf("%v is %v, waiting for the operation to complete.", *stackName, stacks[0].StackStatus)
+
+	settledStack, err := o.waitStackOperationComplete(ctx, stackName)
+	if err != nil {
+		return false, err
+	}
+	if settledStack == nil || settledStack.StackStatus == types.StackStatusDeleteComplete {
+		io.LoggerFromContext(ctx).Info().Msgf("Already deleted since the previous run, %v", *stackName)
+		return true, nil
+	}
+	return false, nil
+}
+
 func (o *CloudFormationStackOperator) deleteStackNormally(ctx context.Context, stackName *string, isRootStack bool) (bool, error) {
 	stacksBeforeDelete, err := o.client.DescribeStacks(ctx, stackName)
 	if err != nil {
@@ -114,43 +564,283 @@ func (o *CloudFormationStackOperator) deleteStackNormally(ctx context.Context, s
 		return true, nil
 	}
 
-	if stacksBeforeDelete[0].EnableTerminationProtection != nil && *stacksBeforeDelete[0].EnableTerminationProtection {
+	stack := stacksBeforeDelete[0]
+	if client.IsStackInProgress(stack.StackStatus) {
+		io.LoggerFromContext(ctx).Info().Msgf("%v is %v, waiting for the operation to complete.", *stackName, stack.StackStatus)
+
+		settledStack, err := o.waitStackOperationComplete(ctx, stackName)
+		if err != nil {
+			return false, err
+		}
+		if settledStack == nil {
+			io.LoggerFromContext(ctx).Info().Msg("No resources were DELETE_FAILED.")
+			return true, nil
+		}
+		// the in-flight deletion has already failed, so the force deletion follows without deleting it again
+		if stack.StackStatus == types.StackStatusDeleteInProgress && settledStack.StackStatus == types.StackStatusDeleteFailed {
+			return false, nil
+		}
+		stack = *settledStack
+	}
+
+	terminationProtectionEnabled := stack.EnableTerminationProtection != nil && *stack.EnableTerminationProtection
+	if terminationProtectionEnabled && !o.terminationProtectionDisabledStacks[*stackName] {
 		return false, fmt.Errorf("TerminationProtectionIsEnabled: %v", *stackName)
 	}
 
-	if err := o.client.DeleteStack(ctx, stackName, []string{}); err != nil {
+	exportNames := []string{}
+	for _, output := range stack.Outputs {
+		if output.ExportName != nil {
+			exportNames = append(exportNames, *output.ExportName)
+		}
+	}
+	if err := o.checkStackImports(ctx, stackName, exportNames); err != nil {
 		return false, err
 	}
 
-	stacksAfterDelete, err := o.client.DescribeStacks(ctx, stackName)
+	if stack.StackStatus == types.StackStatusUpdateRollbackFailed {
+		if err := o.recoverUpdateRollbackFailedStack(ctx, &stack); err != nil {
+			return false, err
+		}
+	}
+
+	// the termination protection is disabled just before the deletion, so that it stays enabled if the checks
+	// above stop the deletion
+	if terminationProtectionEnabled {
+		if err := o.disableTerminationProtection(ctx, stackName); err != nil {
+			return false, err
+		}
+	}
+
+	result, err := o.deleteStack(ctx, stackName, []string{}, types.DeletionModeStandard)
 	if err != nil {
 		return false, err
 	}
-	if len(stacksAfterDelete) == 0 {
-		io.Logger.Info().Msg("No resources were DELETE_FAILED.")
+	if result.Status == client.StackDeletionStatusDeleted {
+		io.LoggerFromContext(ctx).Info().Msg("No resources were DELETE_FAILED.")
 		return true, nil
 	}
-	if stacksAfterDelete[0].StackStatus != "DELETE_FAILED" {
-		return false, fmt.Errorf("StackStatusError: StackStatus is expected to be DELETE_FAILED, but %v: %v", stacksAfterDelete[0].StackStatus, *stackName)
-	}
 
 	return false, nil
 }
 
+// PlanCloudFormationStack walks the stack and its nested child stacks and classifies each resource
+// in the same way as the OperatorCollection, without calling DeleteStack.
+func (o *CloudFormationStackOperator) PlanCloudFormationStack(ctx context.Context, stackName *string, isRootStack bool) ([]StackPlan, error) {
+	stackPlans := []StackPlan{}
+
+	stacks, err := o.client.DescribeStacks(ctx, stackName)
+	if err != nil {
+		return stackPlans, err
+	}
+	if len(stacks) == 0 && isRootStack {
+		errMsg := fmt.Sprintf("%s stack not found.", *stackName)
+		return stackPlans, fmt.Errorf("NotExistsError: %v", errMsg)
+	}
+	if len(stacks) == 0 {
+		return stackPlans, nil
+	}
+
+	stackResourceSummaries, err := o.client.ListStackResources(ctx, stackName)
+	if err != nil {
+		return stackPlans, err
+	}
+
+	stackPlan := StackPlan{
+		StackName:              aws.ToString(stackName),
+		StackStatus:            stacks[0].StackStatus,
+		TerminationProtection:  aws.ToBool(stacks[0].EnableTerminationProtection),
+		ForceDeletionResources: []types.StackResourceSummary{},
+		UnsupportedResources:   []types.StackResourceSummary{},
+	}
+	childStackPlans := []StackPlan{}
+
+	operatorCollection := NewOperatorCollection(o.config, NewOperatorFactory(o.config, o.stackDeletionTimeout, o.stackDeletionMode, o.cfnRoleArn, o.maxDeletionPasses), o.targetResourceTypes)
+
+	for _, stackResource := range stackResourceSummaries {
+		if stackResource.ResourceStatus == types.ResourceStatusDeleteComplete {
+			continue
+		}
+
+		// classified in the same way as the deletion, including the opt-in CloudControl
+		if operatorCollection.getOperatorName(aws.ToString(stackResource.ResourceType)) != "" {
+			stackPlan.ForceDeletionResources = append(stackPlan.ForceDeletionResources, stackResource)
+		} else {
+			stackPlan.UnsupportedResources = append(stackPlan.UnsupportedResources, stackResource)
+		}
+
+		if aws.ToString(stackResource.ResourceType) == resourcetype.CloudformationStack {
+			childStackName := StackNameRuleRegExp.ReplaceAllString(aws.ToString(stackResource.PhysicalResourceId), `$1`)
+			plans, err := o.PlanCloudFormationStack(ctx, aws.String(childStackName), false)
+			if err != nil {
+				return stackPlans, err
+			}
+			childStackPlans = append(childStackPlans, plans...)
+		}
+	}
+
+	stackPlans = append(stackPlans, stackPlan)
+	stackPlans = append(stackPlans, childStackPlans...)
+
+	return stackPlans, nil
+}
+
 func (o *CloudFormationStackOperator) ListStacksFilteredByKeyword(ctx context.Context, keyword *string) ([]string, error) {
 	filteredStacks := []string{}
 
-	// Except StackStatusDeleteComplete and xxInProgress
+	stackNames, err := o.listRootStackNames(ctx)
+	if err != nil {
+		return filteredStacks, err
+	}
+
+	for _, stackName := range stackNames {
+		// for case-insensitive
+		lowerStackName := strings.ToLower(stackName)
+		lowerKeyword := strings.ToLower(*keyword)
+		if strings.Contains(lowerStackName, lowerKeyword) {
+			filteredStacks = append(filteredStacks, stackName)
+		}
+	}
+
+	return filteredStacks, nil
+}
+
+// ListStacksFilteredByPattern returns root stacks matching the glob pattern, or the regular expression
+// if the pattern has the "re:" prefix.
+func (o *CloudFormationStackOperator) ListStacksFilteredByPattern(ctx context.Context, pattern string) ([]string, error) {
+	filteredStacks := []string{}
+
+	match, err := NewStackNameMatcher(pattern)
+	if err != nil {
+		return filteredStacks, err
+	}
+
+	stackNames, err := o.listRootStackNames(ctx)
+	if err != nil {
+		return filteredStacks, err
+	}
+
+	for _, stackName := range stackNames {
+		if match(stackName) {
+			filteredStacks = append(filteredStacks, stackName)
+		}
+	}
+
+	return filteredStacks, nil
+}
+
+// ListStacksFilteredByTags returns root stacks that have all the specified stack-level tags.
+func (o *CloudFormationStackOperator) ListStacksFilteredByTags(ctx context.Context, tags map[string]string) ([]string, error) {
+	filteredStacks := []string{}
+
+	// If a stackName is nil, then return all stacks
+	stacks, err := o.client.DescribeStacks(ctx, nil)
+	if err != nil {
+		return filteredStacks, err
+	}
+
+	for _, stack := range stacks {
+		// pass the nested child stacks
+		if stack.RootId != nil {
+			continue
+		}
+		if stack.StackStatus == types.StackStatusDeleteComplete {
+			continue
+		}
+
+		stackTags := map[string]string{}
+		for _, tag := range stack.Tags {
+			stackTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+
+		matched := true
+		for key, value := range tags {
+			if v, ok := stackTags[key]; !ok || v != value {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			filteredStacks = append(filteredStacks, aws.ToString(stack.StackName))
+		}
+	}
+
+	return filteredStacks, nil
+}
+
+// ListExistingStacks returns the stacks that exist among the stacks, in the order of the stacks. It is used to
+// skip the stacks specified for all the regions or accounts but missing in some of them.
+func (o *CloudFormationStackOperator) ListExistingStacks(ctx context.Context, stackNames []string) ([]string, error) {
+	existingStacks := []string{}
+
+	// If a stackName is nil, then return all stacks
+	stacks, err := o.client.DescribeStacks(ctx, nil)
+	if err != nil {
+		return existingStacks, err
+	}
+
+	exists := map[string]bool{}
+	for _, stack := range stacks {
+		if stack.StackStatus == types.StackStatusDeleteComplete {
+			continue
+		}
+		exists[aws.ToString(stack.StackName)] = true
+		exists[aws.ToString(stack.StackId)] = true
+	}
+
+	for _, stackName := range stackNames {
+		if exists[stackName] {
+			existingStacks = append(existingStacks, stackName)
+		}
+	}
+
+	return existingStacks, nil
+}
+
+// NewStackNameMatcher returns a function reporting whether a stack name matches a glob pattern, or a regular
+// expression if the pattern starts with RegexpPatternPrefix.
+func NewStackNameMatcher(pattern string) (func(string) bool, error) {
+	if strings.HasPrefix(pattern, RegexpPatternPrefix) {
+		re, err := regexp.Compile(strings.TrimPrefix(pattern, RegexpPatternPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("InvalidPatternError: %v", err)
+		}
+		return re.MatchString, nil
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("InvalidPatternError: %v: %v", err, pattern)
+	}
+	return func(stackName string) bool {
+		matched, _ := path.Match(pattern, stackName)
+		return matched
+	}, nil
+}
+
+// listRootStackNames returns stack names except the nested child stacks and DELETE_COMPLETE stacks.
+func (o *CloudFormationStackOperator) listRootStackNames(ctx context.Context) ([]string, error) {
+	stackNames := []string{}
+
+	// Except StackStatusDeleteComplete. XXX_IN_PROGRESS stacks are listed, because the deletion waits for them.
 	stackStatusFilter := []types.StackStatus{
+		types.StackStatusCreateInProgress,
 		types.StackStatusCreateFailed,
 		types.StackStatusCreateComplete,
+		types.StackStatusRollbackInProgress,
 		types.StackStatusRollbackFailed,
 		types.StackStatusRollbackComplete,
+		types.StackStatusDeleteInProgress,
 		types.StackStatusDeleteFailed,
+		types.StackStatusUpdateInProgress,
+		types.StackStatusUpdateCompleteCleanupInProgress,
 		types.StackStatusUpdateComplete,
 		types.StackStatusUpdateFailed,
+		types.StackStatusUpdateRollbackInProgress,
 		types.StackStatusUpdateRollbackFailed,
+		types.StackStatusUpdateRollbackCompleteCleanupInProgress,
 		types.StackStatusUpdateRollbackComplete,
+		types.StackStatusReviewInProgress,
+		types.StackStatusImportInProgress,
 		types.StackStatusImportComplete,
 		types.StackStatusImportRollbackInProgress,
 		types.StackStatusImportRollbackFailed,
@@ -159,7 +849,7 @@ func (o *CloudFormationStackOperator) ListStacksFilteredByKeyword(ctx context.Co
 
 	stackSummaries, err := o.client.ListStacks(ctx, stackStatusFilter)
 	if err != nil {
-		return filteredStacks, err
+		return stackNames, err
 	}
 
 	for _, stackSummary := range stackSummaries {
@@ -167,14 +857,8 @@ func (o *CloudFormationStackOperator) ListStacksFilteredByKeyword(ctx context.Co
 		if stackSummary.RootId != nil {
 			continue
 		}
-
-		// for case-insensitive
-		lowerStackName := strings.ToLower(*stackSummary.StackName)
-		lowerKeyword := strings.ToLower(*keyword)
-		if strings.Contains(lowerStackName, lowerKeyword) {
-			filteredStacks = append(filteredStacks, *stackSummary.StackName)
-		}
+		stackNames = append(stackNames, *stackSummary.StackName)
 	}
 
-	return filteredStacks, nil
+	return stackNames, nil
 }
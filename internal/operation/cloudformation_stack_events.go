@@ -0,0 +1,283 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/resourcetype"
+	"github.com/go-to-k/delstack/pkg/client"
+)
+
+const StackEventsStreamingInterval = 5 * time.Second
+
+// streamedStack is a stack whose events are streamed. The stack name is the stack ID for nested stacks,
+// because the events of deleted stacks can be described only by the stack ID.
+type streamedStack struct {
+	stackName   *string
+	lastEventId string
+	notBefore   *time.Time
+}
+
+// stackEventsStreamer prints the new events of a stack and the nested stacks found in the events.
+type stackEventsStreamer struct {
+	mu       sync.Mutex
+	client   client.ICloudFormation
+	stacks   []*streamedStack
+	stackIds map[string]struct{}
+}
+
+func newStackEventsStreamer(client client.ICloudFormation, stackName *string, lastEventId string) *stackEventsStreamer {
+	return &stackEventsStreamer{
+		client: client,
+		stacks: []*streamedStack{
+			{
+				stackName:   stackName,
+				lastEventId: lastEventId,
+			},
+		},
+		stackIds: map[string]struct{}{},
+	}
+}
+
+type stackEventsStreamingKey struct{}
+
+// stackEventsStreaming is the streaming of the events shared by a root stack and its nested stacks deleted by the
+// operators, so that the events of the nested stacks found by the streamer of the root stack are not printed twice.
+type stackEventsStreaming struct {
+	mu       sync.Mutex
+	streamer *stackEventsStreamer
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// contextWithStackEventsStreaming returns the context sharing a streaming of the events in the deletion of a root
+// stack, and the function stopping it.
+func contextWithStackEventsStreaming(ctx context.Context) (context.Context, func()) {
+	streaming := &stackEventsStreaming{}
+	return context.WithValue(ctx, stackEventsStreamingKey{}, streaming), streaming.stop
+}
+
+// start starts the streamer for the first stack, or adds the stack to the running streamer unless its events
+// are already streamed, and returns the streamer.
+func (s *stackEventsStreaming) start(
+	ctx context.Context,
+	client client.ICloudFormation,
+	stackName *string,
+	stackId string,
+	lastEventId string,
+) *stackEventsStreamer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.streamer != nil {
+		s.streamer.addStack(stackId, lastEventId)
+		return s.streamer
+	}
+
+	s.streamer = newStackEventsStreamer(client, stackName, lastEventId)
+	s.streamer.stackIds[stackId] = struct{}{}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		s.streamer.stream(streamCtx, StackEventsStreamingInterval)
+	}()
+
+	return s.streamer
+}
+
+func (s *stackEventsStreaming) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.streamer == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+}
+
+// deleteStack deletes the stack while streaming the events of the stack and its nested stacks.
+func (o *CloudFormationStackOperator) deleteStack(
+	ctx context.Context,
+	stackName *string,
+	retainResources []string,
+	deletionMode types.DeletionMode,
+) (*client.DeleteStackResult, error) {
+	var result *client.DeleteStackResult
+	err := o.streamStackEventsWhile(ctx, stackName, func() error {
+		var err error
+		result, err = o.deleteStackWithServiceRole(ctx, stackName, retainResources, deletionMode)
+		return err
+	})
+	return result, err
+}
+
+// waitStackOperationComplete waits for the in-flight operation of the stack while streaming the stack events.
+func (o *CloudFormationStackOperator) waitStackOperationComplete(ctx context.Context, stackName *string) (*types.Stack, error) {
+	var stack *types.Stack
+	err := o.streamStackEventsWhile(ctx, stackName, func() error {
+		var err error
+		stack, err = o.client.WaitStackOperationComplete(ctx, stackName)
+		return err
+	})
+	return stack, err
+}
+
+// streamStackEventsWhile streams the events of the stack and its nested stacks while running fn. In the deletion
+// of a root stack, the events are streamed by the streamer shared with the nested stacks until the end of it.
+func (o *CloudFormationStackOperator) streamStackEventsWhile(ctx context.Context, stackName *string, fn func() error) error {
+	// events before fn are not streamed
+	events, err := o.client.DescribeStackEvents(ctx, stackName, "")
+	if err != nil {
+		return err
+	}
+	var lastEventId, stackId string
+	if len(events) > 0 {
+		lastEventId = aws.ToString(events[0].EventId)
+		stackId = aws.ToString(events[0].StackId)
+	}
+
+	if streaming, ok := ctx.Value(stackEventsStreamingKey{}).(*stackEventsStreaming); ok {
+		streamer := streaming.start(ctx, o.client, stackName, stackId, lastEventId)
+
+		err = fn()
+
+		// the last events, such as the reasons of DELETE_FAILED, may arrive after the last poll of the stream
+		if err == nil {
+			streamer.poll(ctx)
+		}
+		return err
+	}
+
+	streamer := newStackEventsStreamer(o.client, stackName, lastEventId)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		streamer.stream(streamCtx, StackEventsStreamingInterval)
+	}()
+
+	err = fn()
+
+	cancel()
+	<-done
+
+	// the last events, such as the reasons of DELETE_FAILED, may arrive after the last poll of the stream
+	if err == nil {
+		streamer.poll(ctx)
+	}
+
+	return err
+}
+
+func (s *stackEventsStreamer) stream(ctx context.Context, interval time.Duration) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		s.poll(ctx)
+	}
+}
+
+// poll prints the events of each stack newer than the last printed event in the oldest order. The failure of
+// the poll is ignored, because streaming the events is not essential to the deletion.
+func (s *stackEventsStreamer) poll(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the stacks found in this poll are polled in the same loop
+	for i := 0; i < len(s.stacks); i++ {
+		stack := s.stacks[i]
+
+		events, err := s.client.DescribeStackEvents(ctx, stack.stackName, stack.lastEventId)
+		if err != nil {
+			io.LoggerFromContext(ctx).Debug().Msgf("Failed to describe stack events: %v", err)
+			continue
+		}
+		if len(events) == 0 {
+			continue
+		}
+		stack.lastEventId = aws.ToString(events[0].EventId)
+
+		for j := len(events) - 1; j >= 0; j-- {
+			event := events[j]
+			if stack.notBefore != nil && event.Timestamp != nil && event.Timestamp.Before(*stack.notBefore) {
+				continue
+			}
+
+			io.LoggerFromContext(ctx).Info().Msg(formatStackEvent(event))
+			s.addNestedStack(event)
+		}
+	}
+}
+
+func (s *stackEventsStreamer) addNestedStack(event types.StackEvent) {
+	if aws.ToString(event.ResourceType) != resourcetype.CloudformationStack {
+		return
+	}
+
+	stackId := aws.ToString(event.PhysicalResourceId)
+	if stackId == "" || stackId == aws.ToString(event.StackId) {
+		return
+	}
+	if _, ok := s.stackIds[stackId]; ok {
+		return
+	}
+
+	s.stackIds[stackId] = struct{}{}
+	s.stacks = append(s.stacks, &streamedStack{
+		stackName: aws.String(stackId),
+		notBefore: event.Timestamp,
+	})
+}
+
+// addStack streams the events of the stack after lastEventId, unless the stack has already been found.
+func (s *stackEventsStreamer) addStack(stackId string, lastEventId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stackId == "" {
+		return
+	}
+	if _, ok := s.stackIds[stackId]; ok {
+		return
+	}
+
+	s.stackIds[stackId] = struct{}{}
+	s.stacks = append(s.stacks, &streamedStack{
+		stackName:   aws.String(stackId),
+		lastEventId: lastEventId,
+	})
+}
+
+func formatStackEvent(event types.StackEvent) string {
+	var timestamp string
+	if event.Timestamp != nil {
+		timestamp = event.Timestamp.Local().Format(time.RFC3339)
+	}
+
+	message := fmt.Sprintf(
+		"%s | %s | %s | %s | %s",
+		timestamp,
+		aws.ToString(event.StackName),
+		aws.ToString(event.LogicalResourceId),
+		aws.ToString(event.ResourceType),
+		event.ResourceStatus,
+	)
+	if event.ResourceStatusReason != nil {
+		message += " | " + *event.ResourceStatusReason
+	}
+	return message
+}
@@ -0,0 +1,261 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestStackEventsStreamer_poll(t *testing.T) {
+	io.NewLogger(false)
+
+	deletionStartedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type want struct {
+		stacks []*streamedStack
+	}
+
+	cases := []struct {
+		name                        string
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+	}{
+		{
+			name: "poll events of the stack and the nested stack found in the events",
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEvents(gomock.Any(), aws.String("test"), "EventId0").Return(
+					[]types.StackEvent{
+						{
+							EventId:            aws.String("EventId2"),
+							StackId:            aws.String("StackId"),
+							StackName:          aws.String("test"),
+							LogicalResourceId:  aws.String("Child"),
+							PhysicalResourceId: aws.String("ChildStackId"),
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							ResourceStatus:     types.ResourceStatusDeleteInProgress,
+							Timestamp:          aws.Time(deletionStartedAt.Add(time.Second)),
+						},
+						{
+							EventId:            aws.String("EventId1"),
+							StackId:            aws.String("StackId"),
+							StackName:          aws.String("test"),
+							LogicalResourceId:  aws.String("test"),
+							PhysicalResourceId: aws.String("StackId"),
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							ResourceStatus:     types.ResourceStatusDeleteInProgress,
+							Timestamp:          aws.Time(deletionStartedAt),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DescribeStackEvents(gomock.Any(), aws.String("ChildStackId"), "").Return(
+					[]types.StackEvent{
+						{
+							EventId:              aws.String("ChildEventId2"),
+							StackId:              aws.String("ChildStackId"),
+							StackName:            aws.String("test-Child"),
+							LogicalResourceId:    aws.String("Bucket"),
+							PhysicalResourceId:   aws.String("BucketName"),
+							ResourceType:         aws.String("AWS::S3::Bucket"),
+							ResourceStatus:       types.ResourceStatusDeleteFailed,
+							ResourceStatusReason: aws.String("The bucket you tried to delete is not empty"),
+							Timestamp:            aws.Time(deletionStartedAt.Add(2 * time.Second)),
+						},
+						{
+							EventId:            aws.String("ChildEventId1"),
+							StackId:            aws.String("ChildStackId"),
+							StackName:          aws.String("test-Child"),
+							LogicalResourceId:  aws.String("test-Child"),
+							PhysicalResourceId: aws.String("ChildStackId"),
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							ResourceStatus:     types.ResourceStatusCreateComplete,
+							Timestamp:          aws.Time(deletionStartedAt.Add(-time.Hour)),
+						},
+					},
+					nil,
+				)
+			},
+			want: want{
+				stacks: []*streamedStack{
+					{
+						stackName:   aws.String("test"),
+						lastEventId: "EventId2",
+					},
+					{
+						stackName:   aws.String("ChildStackId"),
+						lastEventId: "ChildEventId2",
+						notBefore:   aws.Time(deletionStartedAt.Add(time.Second)),
+					},
+				},
+			},
+		},
+		{
+			name: "poll no new events",
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEvents(gomock.Any(), aws.String("test"), "EventId0").Return(
+					[]types.StackEvent{},
+					nil,
+				)
+			},
+			want: want{
+				stacks: []*streamedStack{
+					{
+						stackName:   aws.String("test"),
+						lastEventId: "EventId0",
+					},
+				},
+			},
+		},
+		{
+			name: "poll ignoring describe stack events errors",
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEvents(gomock.Any(), aws.String("test"), "EventId0").Return(
+					[]types.StackEvent{},
+					fmt.Errorf("DescribeStackEventsError"),
+				)
+			},
+			want: want{
+				stacks: []*streamedStack{
+					{
+						stackName:   aws.String("test"),
+						lastEventId: "EventId0",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			streamer := newStackEventsStreamer(cloudformationMock, aws.String("test"), "EventId0")
+			streamer.poll(context.Background())
+
+			if !reflect.DeepEqual(streamer.stacks, tt.want.stacks) {
+				t.Errorf("got = %#v, want %#v", streamer.stacks, tt.want.stacks)
+			}
+		})
+	}
+}
+
+func TestStackEventsStreamer_addStack(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		stackId     string
+		lastEventId string
+	}
+
+	cases := []struct {
+		name string
+		args args
+		want []*streamedStack
+	}{
+		{
+			name: "add a stack not streamed yet",
+			args: args{
+				stackId:     "OtherStackId",
+				lastEventId: "OtherEventId0",
+			},
+			want: []*streamedStack{
+				{
+					stackName:   aws.String("test"),
+					lastEventId: "EventId0",
+				},
+				{
+					stackName:   aws.String("ChildStackId"),
+					lastEventId: "ChildEventId0",
+				},
+				{
+					stackName:   aws.String("OtherStackId"),
+					lastEventId: "OtherEventId0",
+				},
+			},
+		},
+		{
+			name: "not add the nested stack already found in the events",
+			args: args{
+				stackId:     "ChildStackId",
+				lastEventId: "ChildEventId1",
+			},
+			want: []*streamedStack{
+				{
+					stackName:   aws.String("test"),
+					lastEventId: "EventId0",
+				},
+				{
+					stackName:   aws.String("ChildStackId"),
+					lastEventId: "ChildEventId0",
+				},
+			},
+		},
+		{
+			name: "not add the root stack already streamed",
+			args: args{
+				stackId:     "StackId",
+				lastEventId: "EventId1",
+			},
+			want: []*streamedStack{
+				{
+					stackName:   aws.String("test"),
+					lastEventId: "EventId0",
+				},
+				{
+					stackName:   aws.String("ChildStackId"),
+					lastEventId: "ChildEventId0",
+				},
+			},
+		},
+		{
+			name: "not add a stack without the stack id",
+			args: args{
+				stackId:     "",
+				lastEventId: "",
+			},
+			want: []*streamedStack{
+				{
+					stackName:   aws.String("test"),
+					lastEventId: "EventId0",
+				},
+				{
+					stackName:   aws.String("ChildStackId"),
+					lastEventId: "ChildEventId0",
+				},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			streamer := newStackEventsStreamer(nil, aws.String("test"), "EventId0")
+			streamer.stackIds["StackId"] = struct{}{}
+			streamer.stackIds["ChildStackId"] = struct{}{}
+			streamer.stacks = append(streamer.stacks, &streamedStack{
+				stackName:   aws.String("ChildStackId"),
+				lastEventId: "ChildEventId0",
+			})
+
+			streamer.addStack(tt.args.stackId, tt.args.lastEventId)
+
+			if !reflect.DeepEqual(streamer.stacks, tt.want) {
+				t.Errorf("got = %#v, want %#v", streamer.stacks, tt.want)
+			}
+		})
+	}
+}
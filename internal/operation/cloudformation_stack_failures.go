@@ -0,0 +1,123 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+)
+
+type stackPathKey struct{}
+
+// contextWithStackPath returns a context with the path of the stack from the root stack, e.g. Root/Child, so that
+// the nested child stacks deleted by the operators are displayed with their parents.
+func contextWithStackPath(ctx context.Context, stackName string) context.Context {
+	stackPath := stackName
+	if parentStackPath := stackPathFromContext(ctx); parentStackPath != "" {
+		stackPath = parentStackPath + "/" + stackName
+	}
+	return context.WithValue(ctx, stackPathKey{}, stackPath)
+}
+
+// stackPathFromContext returns the path of the stack set by contextWithStackPath, or an empty string.
+func stackPathFromContext(ctx context.Context) string {
+	if stackPath, ok := ctx.Value(stackPathKey{}).(string); ok {
+		return stackPath
+	}
+	return ""
+}
+
+// setDeleteFailedReasons sets ResourceStatusReason of the DELETE_FAILED resources to the reason of their latest
+// DELETE_FAILED event, which tells whether the blocker is a dependency, a permission or a protection setting.
+func (o *CloudFormationStackOperator) setDeleteFailedReasons(ctx context.Context, stackName *string, stackResourceSummaries []types.StackResourceSummary) error {
+	pending := map[string]struct{}{}
+	for _, resource := range stackResourceSummaries {
+		if resource.ResourceStatus == types.ResourceStatusDeleteFailed {
+			pending[aws.ToString(resource.LogicalResourceId)] = struct{}{}
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// the events are read across the pages until every DELETE_FAILED resource has a reason, or until the start of
+	// the latest deletion of the stack, because the older events are not the reasons of this deletion
+	events, err := o.client.DescribeStackEventsUntil(ctx, stackName, func(event types.StackEvent) bool {
+		if aws.ToString(event.PhysicalResourceId) == aws.ToString(event.StackId) {
+			return event.ResourceStatus == types.ResourceStatusDeleteInProgress
+		}
+		if event.ResourceStatus == types.ResourceStatusDeleteFailed && event.ResourceStatusReason != nil {
+			delete(pending, aws.ToString(event.LogicalResourceId))
+		}
+		return len(pending) == 0
+	})
+	if err != nil {
+		return err
+	}
+
+	reasons := map[string]string{}
+	// the events are in the newest order
+	for _, event := range events {
+		if event.ResourceStatus != types.ResourceStatusDeleteFailed || event.ResourceStatusReason == nil {
+			continue
+		}
+		logicalResourceId := aws.ToString(event.LogicalResourceId)
+		if _, ok := reasons[logicalResourceId]; !ok {
+			reasons[logicalResourceId] = aws.ToString(event.ResourceStatusReason)
+		}
+	}
+
+	for i, resource := range stackResourceSummaries {
+		if resource.ResourceStatus != types.ResourceStatusDeleteFailed {
+			continue
+		}
+		if reason, ok := reasons[aws.ToString(resource.LogicalResourceId)]; ok {
+			stackResourceSummaries[i].ResourceStatusReason = aws.String(reason)
+		}
+	}
+
+	return nil
+}
+
+// newForceDeletionError returns the error of the operators with the DELETE_FAILED resources of the stack and their
+// reasons. The error of a nested child stack is returned as is, because it already has its own resources.
+func newForceDeletionError(stackPath string, stackResourceSummaries []types.StackResourceSummary, err error) error {
+	if strings.HasPrefix(err.Error(), "ForceDeletionError: ") {
+		return err
+	}
+
+	deleteFailedResources := []types.StackResourceSummary{}
+	for _, resource := range stackResourceSummaries {
+		if resource.ResourceStatus == types.ResourceStatusDeleteFailed {
+			deleteFailedResources = append(deleteFailedResources, resource)
+		}
+	}
+
+	errMsg := fmt.Sprintf(
+		"%v: %v\nThese are the DELETE_FAILED resources and the reasons:\n%v",
+		stackPath,
+		err,
+		*DeleteFailedResourcesToTableFormat(stackPath, deleteFailedResources),
+	)
+	return fmt.Errorf("ForceDeletionError: %v", errMsg)
+}
+
+// DeleteFailedResourcesToTableFormat returns the DELETE_FAILED resources as a table of the stack path, resource
+// types, logical IDs, physical IDs and the reasons.
+func DeleteFailedResourcesToTableFormat(stackPath string, resources []types.StackResourceSummary) *string {
+	header := []string{"Stack", "ResourceType", "Resource", "PhysicalResourceId", "Reason"}
+	data := [][]string{}
+	for _, resource := range resources {
+		data = append(data, []string{
+			stackPath,
+			aws.ToString(resource.ResourceType),
+			aws.ToString(resource.LogicalResourceId),
+			aws.ToString(resource.PhysicalResourceId),
+			aws.ToString(resource.ResourceStatusReason),
+		})
+	}
+	return io.ToStringAsTableFormat(header, data)
+}
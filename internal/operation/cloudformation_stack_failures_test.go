@@ -0,0 +1,280 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestCloudFormationStackOperator_setDeleteFailedReasons(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx                    context.Context
+		stackName              *string
+		stackResourceSummaries []types.StackResourceSummary
+	}
+
+	type want struct {
+		stackResourceSummaries []types.StackResourceSummary
+		err                    error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "set the reasons of the latest DELETE_FAILED events successfully",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				stackResourceSummaries: []types.StackResourceSummary{
+					{
+						LogicalResourceId: aws.String("SecurityGroup"),
+						ResourceStatus:    "DELETE_FAILED",
+						ResourceType:      aws.String("AWS::EC2::SecurityGroup"),
+					},
+					{
+						LogicalResourceId: aws.String("Bucket"),
+						ResourceStatus:    "DELETE_FAILED",
+						ResourceType:      aws.String("AWS::S3::Bucket"),
+					},
+					{
+						LogicalResourceId: aws.String("Topic"),
+						ResourceStatus:    "DELETE_COMPLETE",
+						ResourceType:      aws.String("AWS::SNS::Topic"),
+					},
+				},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("test"), gomock.Any()).DoAndReturn(
+					describeStackEventsUntilFn([]types.StackEvent{
+						{
+							LogicalResourceId:    aws.String("SecurityGroup"),
+							ResourceStatus:       "DELETE_FAILED",
+							ResourceStatusReason: aws.String("resource sg-xxx has a dependent object"),
+						},
+						{
+							LogicalResourceId:    aws.String("Topic"),
+							ResourceStatus:       "DELETE_COMPLETE",
+							ResourceStatusReason: nil,
+						},
+						{
+							LogicalResourceId:    aws.String("SecurityGroup"),
+							ResourceStatus:       "DELETE_FAILED",
+							ResourceStatusReason: aws.String("the older reason"),
+						},
+						{
+							StackId:            aws.String("StackId"),
+							LogicalResourceId:  aws.String("test"),
+							PhysicalResourceId: aws.String("StackId"),
+							ResourceStatus:     "DELETE_IN_PROGRESS",
+						},
+						{
+							LogicalResourceId:    aws.String("Bucket"),
+							ResourceStatus:       "DELETE_FAILED",
+							ResourceStatusReason: aws.String("the reason of the previous deletion"),
+						},
+					}),
+				)
+			},
+			want: want{
+				stackResourceSummaries: []types.StackResourceSummary{
+					{
+						LogicalResourceId:    aws.String("SecurityGroup"),
+						ResourceStatus:       "DELETE_FAILED",
+						ResourceType:         aws.String("AWS::EC2::SecurityGroup"),
+						ResourceStatusReason: aws.String("resource sg-xxx has a dependent object"),
+					},
+					{
+						LogicalResourceId: aws.String("Bucket"),
+						ResourceStatus:    "DELETE_FAILED",
+						ResourceType:      aws.String("AWS::S3::Bucket"),
+					},
+					{
+						LogicalResourceId: aws.String("Topic"),
+						ResourceStatus:    "DELETE_COMPLETE",
+						ResourceType:      aws.String("AWS::SNS::Topic"),
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "set the reasons until every DELETE_FAILED resource has a reason successfully",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				stackResourceSummaries: []types.StackResourceSummary{
+					{
+						LogicalResourceId: aws.String("SecurityGroup"),
+						ResourceStatus:    "DELETE_FAILED",
+						ResourceType:      aws.String("AWS::EC2::SecurityGroup"),
+					},
+				},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("test"), gomock.Any()).DoAndReturn(
+					describeStackEventsUntilFn([]types.StackEvent{
+						{
+							LogicalResourceId:    aws.String("SecurityGroup"),
+							ResourceStatus:       "DELETE_FAILED",
+							ResourceStatusReason: aws.String("resource sg-xxx has a dependent object"),
+						},
+						{
+							LogicalResourceId:    aws.String("SecurityGroup"),
+							ResourceStatus:       "DELETE_FAILED",
+							ResourceStatusReason: aws.String("the older reason"),
+						},
+					}),
+				)
+			},
+			want: want{
+				stackResourceSummaries: []types.StackResourceSummary{
+					{
+						LogicalResourceId:    aws.String("SecurityGroup"),
+						ResourceStatus:       "DELETE_FAILED",
+						ResourceType:         aws.String("AWS::EC2::SecurityGroup"),
+						ResourceStatusReason: aws.String("resource sg-xxx has a dependent object"),
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "set no reasons without DELETE_FAILED resources successfully",
+			args: args{
+				ctx:                    context.Background(),
+				stackName:              aws.String("test"),
+				stackResourceSummaries: []types.StackResourceSummary{},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {},
+			want: want{
+				stackResourceSummaries: []types.StackResourceSummary{},
+				err:                    nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "set the reasons failure for describe stack events errors",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				stackResourceSummaries: []types.StackResourceSummary{
+					{
+						LogicalResourceId: aws.String("SecurityGroup"),
+						ResourceStatus:    "DELETE_FAILED",
+						ResourceType:      aws.String("AWS::EC2::SecurityGroup"),
+					},
+				},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("test"), gomock.Any()).Return(nil, fmt.Errorf("DescribeStackEventsError"))
+			},
+			want: want{
+				stackResourceSummaries: []types.StackResourceSummary{
+					{
+						LogicalResourceId: aws.String("SecurityGroup"),
+						ResourceStatus:    "DELETE_FAILED",
+						ResourceType:      aws.String("AWS::EC2::SecurityGroup"),
+					},
+				},
+				err: fmt.Errorf("DescribeStackEventsError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, []string{}, client.DefaultStackDeletionTimeout)
+
+			err := cloudformationStackOperator.setDeleteFailedReasons(tt.args.ctx, tt.args.stackName, tt.args.stackResourceSummaries)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(tt.args.stackResourceSummaries, tt.want.stackResourceSummaries) {
+				t.Errorf("output = %#v, want %#v", tt.args.stackResourceSummaries, tt.want.stackResourceSummaries)
+			}
+		})
+	}
+}
+
+func Test_newForceDeletionError(t *testing.T) {
+	stackResourceSummaries := []types.StackResourceSummary{
+		{
+			LogicalResourceId:    aws.String("Bucket"),
+			ResourceStatus:       "DELETE_FAILED",
+			ResourceType:         aws.String("AWS::S3::Bucket"),
+			PhysicalResourceId:   aws.String("bucket"),
+			ResourceStatusReason: aws.String("The bucket you tried to delete is not empty"),
+		},
+		{
+			LogicalResourceId:  aws.String("Topic"),
+			ResourceStatus:     "DELETE_COMPLETE",
+			ResourceType:       aws.String("AWS::SNS::Topic"),
+			PhysicalResourceId: aws.String("topic"),
+		},
+	}
+
+	ctx := contextWithStackPath(contextWithStackPath(context.Background(), "Root"), "Child")
+	stackPath := stackPathFromContext(ctx)
+	if stackPath != "Root/Child" {
+		t.Fatalf("stackPath = %#v, want %#v", stackPath, "Root/Child")
+	}
+
+	err := newForceDeletionError(stackPath, stackResourceSummaries, fmt.Errorf("DeleteBucketError"))
+	want := fmt.Sprintf(
+		"ForceDeletionError: Root/Child: DeleteBucketError\nThese are the DELETE_FAILED resources and the reasons:\n%v",
+		*DeleteFailedResourcesToTableFormat("Root/Child", stackResourceSummaries[:1]),
+	)
+	if err.Error() != want {
+		t.Errorf("err = %#v, want %#v", err.Error(), want)
+	}
+
+	// the error of the nested child stack is returned as is by the parent stack
+	parentErr := newForceDeletionError("Root", []types.StackResourceSummary{}, err)
+	if parentErr.Error() != want {
+		t.Errorf("err = %#v, want %#v", parentErr.Error(), want)
+	}
+}
+
+// describeStackEventsUntilFn returns the mock of DescribeStackEventsUntil returning the events up to the first event
+// for which isLast returns true, like the client reading the pages.
+func describeStackEventsUntilFn(events []types.StackEvent) func(context.Context, *string, func(types.StackEvent) bool) ([]types.StackEvent, error) {
+	return func(_ context.Context, _ *string, isLast func(types.StackEvent) bool) ([]types.StackEvent, error) {
+		for i, event := range events {
+			if isLast(event) {
+				return events[:i+1], nil
+			}
+		}
+		return events, nil
+	}
+}
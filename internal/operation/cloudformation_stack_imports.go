@@ -0,0 +1,188 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-to-k/delstack/internal/io"
+)
+
+// StackImport is an export of a stack imported by another stack, which blocks the deletion of the exporting stack.
+type StackImport struct {
+	ExportingStackName string
+	ExportName         string
+	ImportingStackName string
+}
+
+// ListStackImports returns the imports of the exports of the stacks and their nested stacks by stacks other than
+// them. The importing nested stacks are returned as their root stacks, because only the root stacks can be deleted.
+// If recursive is true, the imports of the exports of the importing stacks are also returned, so that all of them
+// can be deleted before the stacks.
+func (o *CloudFormationStackOperator) ListStackImports(ctx context.Context, stackNames []string, recursive bool) ([]StackImport, error) {
+	stackImports := []StackImport{}
+
+	exports, err := o.client.ListExports(ctx)
+	if err != nil {
+		return stackImports, err
+	}
+	if len(exports) == 0 {
+		return stackImports, nil
+	}
+
+	rootStackNames, err := o.listRootStackNamesByNestedStack(ctx)
+	if err != nil {
+		return stackImports, err
+	}
+	rootStackName := func(stackName string) string {
+		if rootName, ok := rootStackNames[stackName]; ok {
+			return rootName
+		}
+		return stackName
+	}
+
+	// the exports of the nested stacks are grouped into their root stacks
+	exportNames := map[string][]string{}
+	exportingStackNames := map[string]string{}
+	for _, export := range exports {
+		exportingStackName := StackNameRuleRegExp.ReplaceAllString(aws.ToString(export.ExportingStackId), `$1`)
+		rootName := rootStackName(exportingStackName)
+		exportNames[rootName] = append(exportNames[rootName], aws.ToString(export.Name))
+		exportingStackNames[aws.ToString(export.Name)] = exportingStackName
+	}
+
+	targetStacks := map[string]bool{}
+	for _, stackName := range stackNames {
+		targetStacks[stackName] = true
+	}
+
+	queue := append([]string{}, stackNames...)
+	for len(queue) > 0 {
+		stackName := queue[0]
+		queue = queue[1:]
+
+		imports, err := o.listImportsOfExports(ctx, stackName, exportNames[stackName], map[string]bool{})
+		if err != nil {
+			return stackImports, err
+		}
+
+		importingStackNames := []string{}
+		for _, stackImport := range imports {
+			stackImport.ExportingStackName = exportingStackNames[stackImport.ExportName]
+			stackImport.ImportingStackName = rootStackName(stackImport.ImportingStackName)
+			if targetStacks[stackImport.ImportingStackName] || stackImport.ImportingStackName == stackName {
+				continue
+			}
+			if containsStackImport(stackImports, stackImport) {
+				continue
+			}
+			stackImports = append(stackImports, stackImport)
+			importingStackNames = append(importingStackNames, stackImport.ImportingStackName)
+		}
+
+		if !recursive {
+			continue
+		}
+		for _, importingStackName := range importingStackNames {
+			if !targetStacks[importingStackName] {
+				targetStacks[importingStackName] = true
+				queue = append(queue, importingStackName)
+			}
+		}
+	}
+
+	return stackImports, nil
+}
+
+// listRootStackNamesByNestedStack returns the names of the root stacks by the names of their nested stacks.
+func (o *CloudFormationStackOperator) listRootStackNamesByNestedStack(ctx context.Context) (map[string]string, error) {
+	rootStackNames := map[string]string{}
+
+	stacks, err := o.client.DescribeStacks(ctx, nil)
+	if err != nil {
+		return rootStackNames, err
+	}
+
+	stackNamesById := map[string]string{}
+	for _, stack := range stacks {
+		stackNamesById[aws.ToString(stack.StackId)] = aws.ToString(stack.StackName)
+	}
+	for _, stack := range stacks {
+		if stack.RootId == nil {
+			continue
+		}
+		if rootName, ok := stackNamesById[*stack.RootId]; ok {
+			rootStackNames[aws.ToString(stack.StackName)] = rootName
+		}
+	}
+
+	return rootStackNames, nil
+}
+
+func containsStackImport(stackImports []StackImport, stackImport StackImport) bool {
+	for _, s := range stackImports {
+		if s == stackImport {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStackImports returns an error with the importing stacks if the exports in the outputs of the stack are
+// imported by other stacks, because DeleteStack fails immediately for the stack.
+func (o *CloudFormationStackOperator) checkStackImports(ctx context.Context, stackName *string, exportNames []string) error {
+	stackImports, err := o.listImportsOfExports(ctx, aws.ToString(stackName), exportNames, map[string]bool{})
+	if err != nil {
+		return err
+	}
+	if len(stackImports) == 0 {
+		return nil
+	}
+
+	errMsg := fmt.Sprintf(
+		"%v: the exports are imported by other stacks, so delete them first or use --cascade option.\n%v",
+		*stackName,
+		*StackImportsToTableFormat(stackImports),
+	)
+	return fmt.Errorf("ExportImportedError: %v", errMsg)
+}
+
+// listImportsOfExports returns the imports of the exports of the stack by stacks other than excludedStacks.
+func (o *CloudFormationStackOperator) listImportsOfExports(
+	ctx context.Context,
+	stackName string,
+	exportNames []string,
+	excludedStacks map[string]bool,
+) ([]StackImport, error) {
+	stackImports := []StackImport{}
+
+	for _, exportName := range exportNames {
+		importingStackNames, err := o.client.ListImports(ctx, aws.String(exportName))
+		if err != nil {
+			return stackImports, err
+		}
+
+		for _, importingStackName := range importingStackNames {
+			if excludedStacks[importingStackName] || importingStackName == stackName {
+				continue
+			}
+			stackImports = append(stackImports, StackImport{
+				ExportingStackName: stackName,
+				ExportName:         exportName,
+				ImportingStackName: importingStackName,
+			})
+		}
+	}
+
+	return stackImports, nil
+}
+
+// StackImportsToTableFormat returns the imports as a table of the exporting stacks, export names and importing stacks.
+func StackImportsToTableFormat(stackImports []StackImport) *string {
+	header := []string{"ExportingStack", "ExportName", "ImportingStack"}
+	data := [][]string{}
+	for _, stackImport := range stackImports {
+		data = append(data, []string{stackImport.ExportingStackName, stackImport.ExportName, stackImport.ImportingStackName})
+	}
+	return io.ToStringAsTableFormat(header, data)
+}
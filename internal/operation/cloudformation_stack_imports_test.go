@@ -0,0 +1,279 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestCloudFormationStackOperator_ListStackImports(t *testing.T) {
+	io.NewLogger(false)
+
+	exports := []types.Export{
+		{
+			ExportingStackId: aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Stack1/ID1"),
+			Name:             aws.String("Export1"),
+		},
+		{
+			ExportingStackId: aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Stack2/ID2"),
+			Name:             aws.String("Export2"),
+		},
+		{
+			ExportingStackId: aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Other/ID3"),
+			Name:             aws.String("OtherExport"),
+		},
+	}
+	stacks := []types.Stack{
+		{
+			StackId:   aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Stack1/ID1"),
+			StackName: aws.String("Stack1"),
+		},
+		{
+			StackId:   aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Stack1-Nested/ID4"),
+			StackName: aws.String("Stack1-Nested"),
+			RootId:    aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Stack1/ID1"),
+		},
+		{
+			StackId:   aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Stack2/ID2"),
+			StackName: aws.String("Stack2"),
+		},
+		{
+			StackId:   aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Stack2-Nested/ID5"),
+			StackName: aws.String("Stack2-Nested"),
+			RootId:    aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Stack2/ID2"),
+		},
+	}
+
+	type args struct {
+		ctx        context.Context
+		stackNames []string
+		recursive  bool
+	}
+
+	type want struct {
+		stackImports []StackImport
+		err          error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "list stack imports by stacks other than the target stacks successfully",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack1", "Importer1"},
+				recursive:  false,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListExports(gomock.Any()).Return(exports, nil)
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export1")).Return([]string{"Importer1", "Stack2"}, nil)
+			},
+			want: want{
+				stackImports: []StackImport{
+					{
+						ExportingStackName: "Stack1",
+						ExportName:         "Export1",
+						ImportingStackName: "Stack2",
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stack imports recursively successfully",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack1"},
+				recursive:  true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListExports(gomock.Any()).Return(exports, nil)
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export1")).Return([]string{"Stack2"}, nil)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export2")).Return([]string{"Stack3"}, nil)
+			},
+			want: want{
+				stackImports: []StackImport{
+					{
+						ExportingStackName: "Stack1",
+						ExportName:         "Export1",
+						ImportingStackName: "Stack2",
+					},
+					{
+						ExportingStackName: "Stack2",
+						ExportName:         "Export2",
+						ImportingStackName: "Stack3",
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stack imports by the nested stacks as their root stacks successfully",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack1"},
+				recursive:  false,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListExports(gomock.Any()).Return(exports, nil)
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export1")).Return([]string{"Stack2-Nested", "Stack2", "Stack1-Nested"}, nil)
+			},
+			want: want{
+				stackImports: []StackImport{
+					{
+						ExportingStackName: "Stack1",
+						ExportName:         "Export1",
+						ImportingStackName: "Stack2",
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stack imports of the exports of the nested stacks successfully",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack1"},
+				recursive:  false,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListExports(gomock.Any()).Return(
+					[]types.Export{
+						{
+							ExportingStackId: aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Stack1-Nested/ID4"),
+							Name:             aws.String("NestedExport"),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("NestedExport")).Return([]string{"Stack2"}, nil)
+			},
+			want: want{
+				stackImports: []StackImport{
+					{
+						ExportingStackName: "Stack1-Nested",
+						ExportName:         "NestedExport",
+						ImportingStackName: "Stack2",
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list no stack imports for no exports",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack3"},
+				recursive:  true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListExports(gomock.Any()).Return(exports, nil)
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+			},
+			want: want{
+				stackImports: []StackImport{},
+				err:          nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stack imports failure for list exports errors",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack1"},
+				recursive:  false,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListExports(gomock.Any()).Return(nil, fmt.Errorf("ListExportsError"))
+			},
+			want: want{
+				stackImports: []StackImport{},
+				err:          fmt.Errorf("ListExportsError"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "list stack imports failure for describe stacks errors",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack1"},
+				recursive:  false,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListExports(gomock.Any()).Return(exports, nil)
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(nil, fmt.Errorf("DescribeStacksError"))
+			},
+			want: want{
+				stackImports: []StackImport{},
+				err:          fmt.Errorf("DescribeStacksError"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "list stack imports failure for list imports errors",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack1"},
+				recursive:  false,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListExports(gomock.Any()).Return(exports, nil)
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export1")).Return(nil, fmt.Errorf("ListImportsError"))
+			},
+			want: want{
+				stackImports: []StackImport{},
+				err:          fmt.Errorf("ListImportsError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, []string{}, client.DefaultStackDeletionTimeout)
+
+			got, err := cloudformationStackOperator.ListStackImports(tt.args.ctx, tt.args.stackNames, tt.args.recursive)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want.stackImports) {
+				t.Errorf("output = %#v, want %#v", got, tt.want.stackImports)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/resourcetype"
+)
+
+// StackTree is a stack and its nested child stacks, with the resources which may become DELETE_FAILED.
+type StackTree struct {
+	StackName         string
+	LogicalResourceId string // the logical ID in the parent stack, empty for the root stack
+	StackStatus       types.StackStatus
+	ResourceCount     int
+	Resources         []StackTreeResource
+	Children          []*StackTree
+}
+
+// StackTreeResource is a resource with the name of the operator which force deletes it if it becomes DELETE_FAILED,
+// or an empty name if it is unsupported.
+type StackTreeResource struct {
+	Resource     types.StackResourceSummary
+	OperatorName string
+}
+
+// InspectCloudFormationStack resolves the nested child stacks of the stack recursively and returns the tree of
+// them with the resources handled by the operators and the DELETE_FAILED resources unsupported, without deleting
+// anything. It returns nil for a nested child stack that no longer exists.
+func (o *CloudFormationStackOperator) InspectCloudFormationStack(ctx context.Context, stackName *string, isRootStack bool) (*StackTree, error) {
+	stacks, err := o.client.DescribeStacks(ctx, stackName)
+	if err != nil {
+		return nil, err
+	}
+	if len(stacks) == 0 && isRootStack {
+		errMsg := fmt.Sprintf("%s stack not found.", *stackName)
+		return nil, fmt.Errorf("NotExistsError: %v", errMsg)
+	}
+	if len(stacks) == 0 {
+		return nil, nil
+	}
+
+	stackResourceSummaries, err := o.client.ListStackResources(ctx, stackName)
+	if err != nil {
+		return nil, err
+	}
+
+	stackTree := &StackTree{
+		StackName:   aws.ToString(stackName),
+		StackStatus: stacks[0].StackStatus,
+		Resources:   []StackTreeResource{},
+		Children:    []*StackTree{},
+	}
+
+	operatorCollection := NewOperatorCollection(o.config, NewOperatorFactory(o.config, o.stackDeletionTimeout, o.stackDeletionMode, o.cfnRoleArn, o.maxDeletionPasses), o.targetResourceTypes)
+
+	for _, stackResource := range stackResourceSummaries {
+		if stackResource.ResourceStatus == types.ResourceStatusDeleteComplete {
+			continue
+		}
+		stackTree.ResourceCount++
+
+		if aws.ToString(stackResource.ResourceType) == resourcetype.CloudformationStack && stackResource.PhysicalResourceId != nil {
+			childStackName := StackNameRuleRegExp.ReplaceAllString(aws.ToString(stackResource.PhysicalResourceId), `$1`)
+			childStackTree, err := o.InspectCloudFormationStack(ctx, aws.String(childStackName), false)
+			if err != nil {
+				return nil, err
+			}
+			if childStackTree != nil {
+				childStackTree.LogicalResourceId = aws.ToString(stackResource.LogicalResourceId)
+				stackTree.Children = append(stackTree.Children, childStackTree)
+				continue
+			}
+		}
+
+		operatorName := operatorCollection.getOperatorName(aws.ToString(stackResource.ResourceType))
+		if operatorName == "" && stackResource.ResourceStatus != types.ResourceStatusDeleteFailed {
+			continue
+		}
+		stackTree.Resources = append(stackTree.Resources, StackTreeResource{
+			Resource:     stackResource,
+			OperatorName: operatorName,
+		})
+	}
+
+	return stackTree, nil
+}
+
+// StackTreeToTreeFormat returns the stack and its nested child stacks as a tree, e.g.
+//
+//	Root [UPDATE_COMPLETE] 3 resources
+//	├── Bucket (AWS::S3::Bucket) [CREATE_COMPLETE] => S3BucketOperator
+//	└── Child: Root-Child-XXXX [DELETE_FAILED] 1 resources
+//	    └── SecurityGroup (AWS::EC2::SecurityGroup) [DELETE_FAILED] => unsupported
+func StackTreeToTreeFormat(stackTree *StackTree) *string {
+	builder := &strings.Builder{}
+	writeStackTree(builder, stackTree, "", "")
+	tree := builder.String()
+	return &tree
+}
+
+func writeStackTree(builder *strings.Builder, stackTree *StackTree, prefix string, childPrefix string) {
+	name := stackTree.StackName
+	if stackTree.LogicalResourceId != "" {
+		name = stackTree.LogicalResourceId + ": " + stackTree.StackName
+	}
+	fmt.Fprintf(builder, "%s%s [%s] %d resources\n", prefix, name, stackTree.StackStatus, stackTree.ResourceCount)
+
+	count := len(stackTree.Resources) + len(stackTree.Children)
+	index := 0
+	for _, resource := range stackTree.Resources {
+		index++
+		branch, _ := treeBranches(index == count)
+
+		operatorName := resource.OperatorName
+		if operatorName == "" {
+			operatorName = "unsupported"
+		}
+		fmt.Fprintf(
+			builder,
+			"%s%s%s (%s) [%s] => %s\n",
+			childPrefix,
+			branch,
+			aws.ToString(resource.Resource.LogicalResourceId),
+			aws.ToString(resource.Resource.ResourceType),
+			resource.Resource.ResourceStatus,
+			operatorName,
+		)
+	}
+	for _, child := range stackTree.Children {
+		index++
+		branch, indent := treeBranches(index == count)
+		writeStackTree(builder, child, childPrefix+branch, childPrefix+indent)
+	}
+}
+
+func treeBranches(isLast bool) (string, string) {
+	if isLast {
+		return "└── ", "    "
+	}
+	return "├── ", "│   "
+}
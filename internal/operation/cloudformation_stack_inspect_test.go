@@ -0,0 +1,293 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestCloudFormationStackOperator_InspectCloudFormationStack(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx         context.Context
+		stackName   *string
+		isRootStack bool
+	}
+
+	type want struct {
+		stackTree *StackTree
+		err       error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "inspect stack with nested child stacks successfully",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("Root"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("Root")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("Root"),
+							StackStatus: "UPDATE_COMPLETE",
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("Root")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("Bucket"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::S3::Bucket"),
+							PhysicalResourceId: aws.String("bucket"),
+						},
+						{
+							LogicalResourceId:  aws.String("Topic"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::SNS::Topic"),
+							PhysicalResourceId: aws.String("topic"),
+						},
+						{
+							LogicalResourceId:  aws.String("Deleted"),
+							ResourceStatus:     "DELETE_COMPLETE",
+							ResourceType:       aws.String("AWS::IAM::Role"),
+							PhysicalResourceId: aws.String("role"),
+						},
+						{
+							LogicalResourceId:  aws.String("Child"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							PhysicalResourceId: aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/Root-Child-XXXX/ID"),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("Root-Child-XXXX")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("Root-Child-XXXX"),
+							StackStatus: "DELETE_FAILED",
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("Root-Child-XXXX")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("SecurityGroup"),
+							ResourceStatus:     "DELETE_FAILED",
+							ResourceType:       aws.String("AWS::EC2::SecurityGroup"),
+							PhysicalResourceId: aws.String("sg-xxx"),
+						},
+					},
+					nil,
+				)
+			},
+			want: want{
+				stackTree: &StackTree{
+					StackName:     "Root",
+					StackStatus:   "UPDATE_COMPLETE",
+					ResourceCount: 3,
+					Resources: []StackTreeResource{
+						{
+							Resource: types.StackResourceSummary{
+								LogicalResourceId:  aws.String("Bucket"),
+								ResourceStatus:     "CREATE_COMPLETE",
+								ResourceType:       aws.String("AWS::S3::Bucket"),
+								PhysicalResourceId: aws.String("bucket"),
+							},
+							OperatorName: "S3BucketOperator",
+						},
+					},
+					Children: []*StackTree{
+						{
+							StackName:         "Root-Child-XXXX",
+							LogicalResourceId: "Child",
+							StackStatus:       "DELETE_FAILED",
+							ResourceCount:     1,
+							Resources: []StackTreeResource{
+								{
+									Resource: types.StackResourceSummary{
+										LogicalResourceId:  aws.String("SecurityGroup"),
+										ResourceStatus:     "DELETE_FAILED",
+										ResourceType:       aws.String("AWS::EC2::SecurityGroup"),
+										PhysicalResourceId: aws.String("sg-xxx"),
+									},
+									OperatorName: "Ec2SecurityGroupOperator",
+								},
+							},
+							Children: []*StackTree{},
+						},
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "inspect stack failure for not existing root stack",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("Root"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("Root")).Return([]types.Stack{}, nil)
+			},
+			want: want{
+				stackTree: nil,
+				err:       fmt.Errorf("NotExistsError: Root stack not found."),
+			},
+			wantErr: true,
+		},
+		{
+			name: "inspect no stack for not existing child stack",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("Child"),
+				isRootStack: false,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("Child")).Return([]types.Stack{}, nil)
+			},
+			want: want{
+				stackTree: nil,
+				err:       nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "inspect stack failure for list stack resources errors",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("Root"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("Root")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("Root"),
+							StackStatus: "UPDATE_COMPLETE",
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("Root")).Return(nil, fmt.Errorf("ListStackResourcesError"))
+			},
+			want: want{
+				stackTree: nil,
+				err:       fmt.Errorf("ListStackResourcesError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypesForAllServices, client.DefaultStackDeletionTimeout)
+
+			got, err := cloudformationStackOperator.InspectCloudFormationStack(tt.args.ctx, tt.args.stackName, tt.args.isRootStack)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want.stackTree) {
+				t.Errorf("output = %#v, want %#v", got, tt.want.stackTree)
+			}
+		})
+	}
+}
+
+func Test_StackTreeToTreeFormat(t *testing.T) {
+	stackTree := &StackTree{
+		StackName:     "Root",
+		StackStatus:   "UPDATE_COMPLETE",
+		ResourceCount: 3,
+		Resources: []StackTreeResource{
+			{
+				Resource: types.StackResourceSummary{
+					LogicalResourceId: aws.String("Bucket"),
+					ResourceStatus:    "CREATE_COMPLETE",
+					ResourceType:      aws.String("AWS::S3::Bucket"),
+				},
+				OperatorName: "S3BucketOperator",
+			},
+		},
+		Children: []*StackTree{
+			{
+				StackName:         "Root-Child-XXXX",
+				LogicalResourceId: "Child",
+				StackStatus:       "DELETE_FAILED",
+				ResourceCount:     2,
+				Resources: []StackTreeResource{
+					{
+						Resource: types.StackResourceSummary{
+							LogicalResourceId: aws.String("SecurityGroup"),
+							ResourceStatus:    "DELETE_FAILED",
+							ResourceType:      aws.String("AWS::EC2::SecurityGroup"),
+						},
+						OperatorName: "",
+					},
+				},
+				Children: []*StackTree{
+					{
+						StackName:         "Root-Child-XXXX-GrandChild-YYYY",
+						LogicalResourceId: "GrandChild",
+						StackStatus:       "CREATE_COMPLETE",
+						ResourceCount:     0,
+					},
+				},
+			},
+			{
+				StackName:         "Root-Child2-ZZZZ",
+				LogicalResourceId: "Child2",
+				StackStatus:       "CREATE_COMPLETE",
+				ResourceCount:     0,
+			},
+		},
+	}
+
+	want := "Root [UPDATE_COMPLETE] 3 resources\n" +
+		"├── Bucket (AWS::S3::Bucket) [CREATE_COMPLETE] => S3BucketOperator\n" +
+		"├── Child: Root-Child-XXXX [DELETE_FAILED] 2 resources\n" +
+		"│   ├── SecurityGroup (AWS::EC2::SecurityGroup) [DELETE_FAILED] => unsupported\n" +
+		"│   └── GrandChild: Root-Child-XXXX-GrandChild-YYYY [CREATE_COMPLETE] 0 resources\n" +
+		"└── Child2: Root-Child2-ZZZZ [CREATE_COMPLETE] 0 resources\n"
+
+	got := StackTreeToTreeFormat(stackTree)
+	if *got != want {
+		t.Errorf("output = %#v, want %#v", *got, want)
+	}
+}
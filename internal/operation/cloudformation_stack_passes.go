@@ -0,0 +1,34 @@
+package operation
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+)
+
+// listDeleteFailedLogicalResourceIds returns the logical IDs of the DELETE_FAILED resources, which are compared
+// between the passes of the deletion to find whether a pass made progress.
+func listDeleteFailedLogicalResourceIds(stackResourceSummaries []types.StackResourceSummary) []string {
+	logicalResourceIds := []string{}
+	for _, resource := range stackResourceSummaries {
+		if resource.ResourceStatus == types.ResourceStatusDeleteFailed {
+			logicalResourceIds = append(logicalResourceIds, aws.ToString(resource.LogicalResourceId))
+		}
+	}
+	return logicalResourceIds
+}
+
+// subtractStrings returns the elements of a not contained in b in the order of a.
+func subtractStrings(a []string, b []string) []string {
+	contained := map[string]bool{}
+	for _, v := range b {
+		contained[v] = true
+	}
+
+	result := []string{}
+	for _, v := range a {
+		if !contained[v] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
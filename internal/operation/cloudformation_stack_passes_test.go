@@ -0,0 +1,420 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/journal"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestCloudFormationStackOperator_DeleteCloudFormationStack_Passes(t *testing.T) {
+	io.NewLogger(false)
+
+	deleteFailedStacks := []types.Stack{
+		{
+			StackName:   aws.String("test"),
+			StackStatus: "DELETE_FAILED",
+		},
+	}
+	bucketFailed := []types.StackResourceSummary{
+		{
+			LogicalResourceId:  aws.String("Bucket"),
+			ResourceStatus:     "DELETE_FAILED",
+			ResourceType:       aws.String("AWS::S3::Bucket"),
+			PhysicalResourceId: aws.String("PhysicalBucket"),
+		},
+	}
+	roleFailed := []types.StackResourceSummary{
+		{
+			LogicalResourceId:  aws.String("Bucket"),
+			ResourceStatus:     "DELETE_SKIPPED",
+			ResourceType:       aws.String("AWS::S3::Bucket"),
+			PhysicalResourceId: aws.String("PhysicalBucket"),
+		},
+		{
+			LogicalResourceId:  aws.String("Role"),
+			ResourceStatus:     "DELETE_FAILED",
+			ResourceType:       aws.String("AWS::IAM::Role"),
+			PhysicalResourceId: aws.String("PhysicalRole"),
+		},
+	}
+
+	type args struct {
+		ctx               context.Context
+		stackName         *string
+		maxDeletionPasses int
+	}
+
+	cases := []struct {
+		name                         string
+		args                         args
+		prepareMockCloudFormationFn  func(m *client.MockICloudFormation)
+		prepareMockOperatorManagerFn func(m *MockIOperatorManager)
+		want                         error
+		wantErr                      bool
+	}{
+		{
+			name: "delete stack successfully in the second pass for new DELETE_FAILED resources",
+			args: args{
+				ctx:               newJournalContext(t, journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"}),
+				stackName:         aws.String("test"),
+				maxDeletionPasses: 3,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(deleteFailedStacks, nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(bucketFailed, nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"Bucket"}, types.DeletionModeStandard, nil).Return(
+					&client.DeleteStackResult{Status: client.StackDeletionStatusFailed, StatusReason: "Role failed"}, nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(roleFailed, nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"Role"}, types.DeletionModeStandard, nil).Return(
+					&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil,
+				)
+			},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
+				m.EXPECT().SetOperatorCollection(aws.String("test"), bucketFailed)
+				m.EXPECT().CheckResourceCounts().Return(nil)
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
+				m.EXPECT().GetLogicalResourceIds().Return([]string{"Bucket"})
+				m.EXPECT().SetOperatorCollection(aws.String("test"), roleFailed)
+				m.EXPECT().CheckResourceCounts().Return(nil)
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
+				m.EXPECT().GetLogicalResourceIds().Return([]string{"Role"})
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete stack successfully in the second pass for the operators failure",
+			args: args{
+				ctx:               newJournalContext(t, journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"}),
+				stackName:         aws.String("test"),
+				maxDeletionPasses: 3,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(deleteFailedStacks, nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(bucketFailed, nil).Times(2)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"Bucket"}, types.DeletionModeStandard, nil).Return(
+					&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil,
+				)
+			},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
+				m.EXPECT().SetOperatorCollection(aws.String("test"), bucketFailed).Times(2)
+				m.EXPECT().CheckResourceCounts().Return(nil).Times(2)
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(fmt.Errorf("BucketNotEmpty"))
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
+				m.EXPECT().GetLogicalResourceIds().Return([]string{"Bucket"})
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete stack failure for no progress between the passes",
+			args: args{
+				ctx:               newJournalContext(t, journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"}),
+				stackName:         aws.String("test"),
+				maxDeletionPasses: 3,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(deleteFailedStacks, nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(bucketFailed, nil).Times(2)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"Bucket"}, types.DeletionModeStandard, nil).Return(
+					&client.DeleteStackResult{Status: client.StackDeletionStatusFailed, StatusReason: "Bucket failed"}, nil,
+				)
+			},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
+				m.EXPECT().SetOperatorCollection(aws.String("test"), bucketFailed)
+				m.EXPECT().CheckResourceCounts().Return(nil)
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
+				m.EXPECT().GetLogicalResourceIds().Return([]string{"Bucket"})
+			},
+			want:    fmt.Errorf("StackDeletionFailedError: test: Bucket failed"),
+			wantErr: true,
+		},
+		{
+			name: "delete stack failure for the operators failure in all the passes",
+			args: args{
+				ctx:               newJournalContext(t, journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"}),
+				stackName:         aws.String("test"),
+				maxDeletionPasses: 2,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(deleteFailedStacks, nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(bucketFailed, nil).Times(2)
+			},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
+				m.EXPECT().SetOperatorCollection(aws.String("test"), bucketFailed).Times(2)
+				m.EXPECT().CheckResourceCounts().Return(nil).Times(2)
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(fmt.Errorf("BucketNotEmpty")).Times(2)
+			},
+			want:    newForceDeletionError("test", bucketFailed, fmt.Errorf("BucketNotEmpty")),
+			wantErr: true,
+		},
+		{
+			name: "delete stack failure in the only pass if max passes are not set",
+			args: args{
+				ctx:       newJournalContext(t, journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"}),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(deleteFailedStacks, nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(bucketFailed, nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"Bucket"}, types.DeletionModeStandard, nil).Return(
+					&client.DeleteStackResult{Status: client.StackDeletionStatusFailed, StatusReason: "Role failed"}, nil,
+				)
+			},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
+				m.EXPECT().SetOperatorCollection(aws.String("test"), bucketFailed)
+				m.EXPECT().CheckResourceCounts().Return(nil)
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
+				m.EXPECT().GetLogicalResourceIds().Return([]string{"Bucket"})
+			},
+			want:    fmt.Errorf("StackDeletionFailedError: test: Role failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+			operatorManagerMock := NewMockIOperatorManager(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+			cloudformationMock.EXPECT().DescribeStackEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
+			cloudformationMock.EXPECT().DescribeStackEventsUntil(gomock.Any(), gomock.Any(), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
+			tt.prepareMockOperatorManagerFn(operatorManagerMock)
+
+			targetResourceTypes := []string{
+				"AWS::S3::Bucket",
+				"AWS::IAM::Role",
+			}
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypes, client.DefaultStackDeletionTimeout)
+			cloudformationStackOperator.SetMaxDeletionPasses(tt.args.maxDeletionPasses)
+
+			err := cloudformationStackOperator.DeleteCloudFormationStack(tt.args.ctx, tt.args.stackName, true, operatorManagerMock)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
+				return
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackOperator_DeleteResources_Passes(t *testing.T) {
+	io.NewLogger(false)
+
+	deleteFailedStacks := []types.Stack{
+		{
+			StackName:   aws.String("Child"),
+			StackStatus: "DELETE_FAILED",
+		},
+	}
+	firstFailed := []types.StackResourceSummary{
+		{
+			LogicalResourceId:  aws.String("Custom1"),
+			ResourceStatus:     "DELETE_FAILED",
+			ResourceType:       aws.String("Custom::First"),
+			PhysicalResourceId: aws.String("PhysicalCustom1"),
+		},
+	}
+	secondFailed := []types.StackResourceSummary{
+		{
+			LogicalResourceId:  aws.String("Custom1"),
+			ResourceStatus:     "DELETE_SKIPPED",
+			ResourceType:       aws.String("Custom::First"),
+			PhysicalResourceId: aws.String("PhysicalCustom1"),
+		},
+		{
+			LogicalResourceId:  aws.String("Custom2"),
+			ResourceStatus:     "DELETE_FAILED",
+			ResourceType:       aws.String("Custom::Second"),
+			PhysicalResourceId: aws.String("PhysicalCustom2"),
+		},
+	}
+
+	type args struct {
+		ctx               context.Context
+		maxDeletionPasses int
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        error
+		wantErr                     bool
+	}{
+		{
+			name: "delete nested stack successfully in the second pass with the max passes of the factory",
+			args: args{
+				ctx:               context.Background(),
+				maxDeletionPasses: 2,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("Child")).Return(deleteFailedStacks, nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("Child"), []string{}, types.DeletionModeStandard, nil).Return(
+					&client.DeleteStackResult{Status: client.StackDeletionStatusFailed, StatusReason: "Custom1 failed"}, nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("Child")).Return(firstFailed, nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("Child"), []string{"Custom1"}, types.DeletionModeStandard, nil).Return(
+					&client.DeleteStackResult{Status: client.StackDeletionStatusFailed, StatusReason: "Custom2 failed"}, nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("Child")).Return(secondFailed, nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("Child"), []string{"Custom2"}, types.DeletionModeStandard, nil).Return(
+					&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil,
+				)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete nested stack failure in the only pass if max passes are not set in the factory",
+			args: args{
+				ctx: context.Background(),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("Child")).Return(deleteFailedStacks, nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("Child"), []string{}, types.DeletionModeStandard, nil).Return(
+					&client.DeleteStackResult{Status: client.StackDeletionStatusFailed, StatusReason: "Custom1 failed"}, nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("Child")).Return(firstFailed, nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("Child"), []string{"Custom1"}, types.DeletionModeStandard, nil).Return(
+					&client.DeleteStackResult{Status: client.StackDeletionStatusFailed, StatusReason: "Custom2 failed"}, nil,
+				)
+			},
+			want:    fmt.Errorf("StackDeletionFailedError: Child: Custom2 failed"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+			cloudformationMock.EXPECT().DescribeStackEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
+			cloudformationMock.EXPECT().DescribeStackEventsUntil(gomock.Any(), gomock.Any(), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
+
+			targetResourceTypes := []string{
+				"AWS::CloudFormation::Stack",
+				"Custom::",
+			}
+
+			// the operator of the nested stacks is created by the factory as in the operator collection
+			operatorFactory := NewOperatorFactory(aws.Config{}, client.DefaultStackDeletionTimeout, StackDeletionModeRetain, nil, tt.args.maxDeletionPasses)
+			cloudformationStackOperator := operatorFactory.CreateCloudFormationStackOperator(targetResourceTypes)
+			cloudformationStackOperator.client = cloudformationMock
+			cloudformationStackOperator.AddResource(&types.StackResourceSummary{
+				LogicalResourceId:  aws.String("Child"),
+				ResourceStatus:     "DELETE_FAILED",
+				ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+				PhysicalResourceId: aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/Child/ID"),
+			})
+
+			err := cloudformationStackOperator.DeleteResources(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
+				return
+			}
+		})
+	}
+}
+
+func Test_subtractStrings(t *testing.T) {
+	cases := []struct {
+		name string
+		a    []string
+		b    []string
+		want []string
+	}{
+		{
+			name: "subtract strings successfully",
+			a:    []string{"Bucket", "Role", "Repository"},
+			b:    []string{"Role", "Vault"},
+			want: []string{"Bucket", "Repository"},
+		},
+		{
+			name: "subtract all strings",
+			a:    []string{"Bucket"},
+			b:    []string{"Bucket"},
+			want: []string{},
+		},
+		{
+			name: "subtract strings from empty strings",
+			a:    []string{},
+			b:    []string{"Bucket"},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := subtractStrings(tt.a, tt.b)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("output = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_appendUniqueResources(t *testing.T) {
+	bucket := types.StackResourceSummary{LogicalResourceId: aws.String("Bucket")}
+	securityGroup := types.StackResourceSummary{LogicalResourceId: aws.String("SecurityGroup")}
+
+	cases := []struct {
+		name         string
+		resources    []types.StackResourceSummary
+		newResources []types.StackResourceSummary
+		want         []types.StackResourceSummary
+	}{
+		{
+			name:         "append resources not collected in the previous passes",
+			resources:    []types.StackResourceSummary{bucket},
+			newResources: []types.StackResourceSummary{bucket, securityGroup},
+			want:         []types.StackResourceSummary{bucket, securityGroup},
+		},
+		{
+			name:         "append no resources for all collected in the previous passes",
+			resources:    []types.StackResourceSummary{bucket, securityGroup},
+			newResources: []types.StackResourceSummary{securityGroup},
+			want:         []types.StackResourceSummary{bucket, securityGroup},
+		},
+		{
+			name:         "append resources to empty resources",
+			resources:    []types.StackResourceSummary{},
+			newResources: []types.StackResourceSummary{bucket},
+			want:         []types.StackResourceSummary{bucket},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := appendUniqueResources(tt.resources, tt.newResources)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("output = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
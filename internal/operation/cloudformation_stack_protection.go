@@ -0,0 +1,38 @@
+package operation
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-to-k/delstack/internal/io"
+)
+
+// ListTerminationProtectedStacks returns the stacks with the termination protection among the stacks.
+func (o *CloudFormationStackOperator) ListTerminationProtectedStacks(ctx context.Context, stackNames []string) ([]string, error) {
+	protectedStacks := []string{}
+
+	targetStacks := map[string]bool{}
+	for _, stackName := range stackNames {
+		targetStacks[stackName] = true
+	}
+
+	// If a stackName is nil, then return all stacks
+	stacks, err := o.client.DescribeStacks(ctx, nil)
+	if err != nil {
+		return protectedStacks, err
+	}
+
+	for _, stack := range stacks {
+		if targetStacks[aws.ToString(stack.StackName)] && aws.ToBool(stack.EnableTerminationProtection) {
+			protectedStacks = append(protectedStacks, aws.ToString(stack.StackName))
+		}
+	}
+
+	return protectedStacks, nil
+}
+
+func (o *CloudFormationStackOperator) disableTerminationProtection(ctx context.Context, stackName *string) error {
+	io.LoggerFromContext(ctx).Warn().Msgf("Disable the termination protection, %v", *stackName)
+
+	return o.client.UpdateTerminationProtection(ctx, stackName, false)
+}
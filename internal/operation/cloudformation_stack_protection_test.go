@@ -0,0 +1,133 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestCloudFormationStackOperator_ListTerminationProtectedStacks(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx        context.Context
+		stackNames []string
+	}
+
+	type want struct {
+		protectedStacks []string
+		err             error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "list termination protected stacks successfully",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack1", "Stack2"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("Stack1"),
+							EnableTerminationProtection: aws.Bool(true),
+						},
+						{
+							StackName:                   aws.String("Stack2"),
+							EnableTerminationProtection: aws.Bool(false),
+						},
+						{
+							StackName:                   aws.String("Other"),
+							EnableTerminationProtection: aws.Bool(true),
+						},
+					},
+					nil,
+				)
+			},
+			want: want{
+				protectedStacks: []string{"Stack1"},
+				err:             nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list no termination protected stacks for no protection",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack1"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("Stack1"),
+						},
+					},
+					nil,
+				)
+			},
+			want: want{
+				protectedStacks: []string{},
+				err:             nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list termination protected stacks failure for describe stacks errors",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"Stack1"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(nil, fmt.Errorf("DescribeStacksError"))
+			},
+			want: want{
+				protectedStacks: []string{},
+				err:             fmt.Errorf("DescribeStacksError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, []string{}, client.DefaultStackDeletionTimeout)
+
+			got, err := cloudformationStackOperator.ListTerminationProtectedStacks(tt.args.ctx, tt.args.stackNames)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want.protectedStacks) {
+				t.Errorf("output = %#v, want %#v", got, tt.want.protectedStacks)
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/resourcetype"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DeletionPolicyRetain               = "Retain"
+	DeletionPolicyRetainExceptOnCreate = "RetainExceptOnCreate"
+)
+
+// RetainedResource is a resource which remains after the deletion of its stack.
+type RetainedResource struct {
+	StackName string
+	Reason    string
+	Resource  types.StackResourceSummary
+}
+
+// ListRetainedResources returns the resources of the stack and its nested child stacks whose DeletionPolicy is
+// Retain or RetainExceptOnCreate. It must be called before the deletion, because the template and the resources
+// cannot be got by the stack name after it.
+func (o *CloudFormationStackOperator) ListRetainedResources(ctx context.Context, stackName *string) ([]RetainedResource, error) {
+	retainedResources := []RetainedResource{}
+
+	template, err := o.client.GetTemplate(ctx, stackName)
+	if err != nil {
+		return retainedResources, err
+	}
+	// the stack does not exist
+	if template == nil {
+		return retainedResources, nil
+	}
+
+	deletionPolicies, err := parseDeletionPolicies(*template)
+	if err != nil {
+		return retainedResources, fmt.Errorf("TemplateParseError: %v: %v", *stackName, err)
+	}
+
+	stackResourceSummaries, err := o.client.ListStackResources(ctx, stackName)
+	if err != nil {
+		return retainedResources, err
+	}
+
+	for _, resource := range stackResourceSummaries {
+		if resource.PhysicalResourceId == nil || resource.ResourceStatus == types.ResourceStatusDeleteComplete {
+			continue
+		}
+
+		deletionPolicy := deletionPolicies[aws.ToString(resource.LogicalResourceId)]
+		if deletionPolicy == DeletionPolicyRetain || deletionPolicy == DeletionPolicyRetainExceptOnCreate {
+			retainedResources = append(retainedResources, RetainedResource{
+				StackName: *stackName,
+				Reason:    "DeletionPolicy: " + deletionPolicy,
+				Resource:  resource,
+			})
+			continue
+		}
+
+		// the resources of a retained nested stack remain with it, so only the deleted nested stacks are walked
+		if aws.ToString(resource.ResourceType) == resourcetype.CloudformationStack {
+			childStackName := StackNameRuleRegExp.ReplaceAllString(aws.ToString(resource.PhysicalResourceId), `$1`)
+			childRetainedResources, err := o.ListRetainedResources(ctx, aws.String(childStackName))
+			if err != nil {
+				return retainedResources, err
+			}
+			retainedResources = append(retainedResources, childRetainedResources...)
+		}
+	}
+
+	return retainedResources, nil
+}
+
+// parseDeletionPolicies returns the DeletionPolicy of each logical ID in the JSON or YAML template. The policies
+// given by intrinsic functions are ignored.
+func parseDeletionPolicies(template string) (map[string]string, error) {
+	deletionPolicies := map[string]string{}
+
+	var jsonTemplate struct {
+		Resources map[string]struct {
+			DeletionPolicy interface{} `json:"DeletionPolicy"`
+		} `json:"Resources"`
+	}
+	if err := json.Unmarshal([]byte(template), &jsonTemplate); err == nil {
+		for logicalId, resource := range jsonTemplate.Resources {
+			if deletionPolicy, ok := resource.DeletionPolicy.(string); ok {
+				deletionPolicies[logicalId] = deletionPolicy
+			}
+		}
+		return deletionPolicies, nil
+	}
+
+	// YAML templates are parsed as nodes, because the short forms of intrinsic functions (e.g. !Ref) are custom tags
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(template), &document); err != nil {
+		return deletionPolicies, err
+	}
+	if len(document.Content) == 0 {
+		return deletionPolicies, nil
+	}
+
+	resources := getMappingValue(document.Content[0], "Resources")
+	if resources == nil || resources.Kind != yaml.MappingNode {
+		return deletionPolicies, nil
+	}
+	for i := 0; i+1 < len(resources.Content); i += 2 {
+		deletionPolicy := getMappingValue(resources.Content[i+1], "DeletionPolicy")
+		if deletionPolicy != nil && deletionPolicy.Kind == yaml.ScalarNode && deletionPolicy.Tag == "!!str" {
+			deletionPolicies[resources.Content[i].Value] = deletionPolicy.Value
+		}
+	}
+
+	return deletionPolicies, nil
+}
+
+func getMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// listRetainedCustomResources returns the DELETE_FAILED custom resources, which are retained or abandoned by the
+// deletion after the force deletion without being deleted by any operator.
+func (o *CloudFormationStackOperator) listRetainedCustomResources(stackName *string, stackResourceSummaries []types.StackResourceSummary) []RetainedResource {
+	retainedResources := []RetainedResource{}
+
+	reason := "RetainResources"
+	if o.stackDeletionMode != StackDeletionModeRetain {
+		reason = string(types.DeletionModeForceDeleteStack)
+	}
+
+	for _, resource := range stackResourceSummaries {
+		if resource.ResourceStatus != types.ResourceStatusDeleteFailed {
+			continue
+		}
+		if !strings.Contains(aws.ToString(resource.ResourceType), resourcetype.CustomResource) {
+			continue
+		}
+		retainedResources = append(retainedResources, RetainedResource{
+			StackName: *stackName,
+			Reason:    reason,
+			Resource:  resource,
+		})
+	}
+
+	return retainedResources
+}
+
+// reportRetainedResources displays the resources remaining after the deletion of the stack, and deletes the ones of
+// the supported types by the operators with --purge-retained.
+func (o *CloudFormationStackOperator) reportRetainedResources(ctx context.Context, stackName *string, retainedResources []RetainedResource) error {
+	if len(retainedResources) == 0 {
+		return nil
+	}
+
+	io.LoggerFromContext(ctx).Warn().Msgf(
+		"%v was deleted, but these resources were retained and remain:\n%v",
+		*stackName,
+		*RetainedResourcesToTableFormat(retainedResources),
+	)
+
+	if !o.purgeRetained {
+		return nil
+	}
+
+	return o.purgeRetainedResources(ctx, stackName, retainedResources)
+}
+
+// purgeRetainedResources deletes the retained resources of the supported types by the operators in the same way as
+// the DELETE_FAILED resources. The custom resources are not purged, because the operator of them deletes nothing
+// but only lets the stack deletion skip them.
+func (o *CloudFormationStackOperator) purgeRetainedResources(ctx context.Context, stackName *string, retainedResources []RetainedResource) error {
+	stackResourceSummaries := []types.StackResourceSummary{}
+	customResources := []types.StackResourceSummary{}
+	for _, retainedResource := range retainedResources {
+		resource := retainedResource.Resource
+		if strings.Contains(aws.ToString(resource.ResourceType), resourcetype.CustomResource) {
+			customResources = append(customResources, resource)
+			continue
+		}
+		// the operators are given only the DELETE_FAILED resources
+		resource.ResourceStatus = types.ResourceStatusDeleteFailed
+		stackResourceSummaries = append(stackResourceSummaries, resource)
+	}
+
+	if len(stackResourceSummaries) == 0 {
+		io.LoggerFromContext(ctx).Warn().Msgf("No retained resources of %v were purged, because they are unsupported (or you did not selected in the interactive prompt).", *stackName)
+		return nil
+	}
+
+	operatorFactory := NewOperatorFactory(o.config, o.stackDeletionTimeout, o.stackDeletionMode, o.cfnRoleArn, o.maxDeletionPasses)
+	operatorCollection := NewOperatorCollection(o.config, operatorFactory, o.targetResourceTypes)
+	operatorManager := NewOperatorManager(operatorCollection)
+
+	operatorManager.SetOperatorCollection(stackName, stackResourceSummaries)
+
+	if err := operatorManager.DeleteResourceCollection(ctx); err != nil {
+		return err
+	}
+
+	unsupportedResources := append(customResources, operatorManager.GetUnsupportedResources()...)
+	if len(unsupportedResources) == len(retainedResources) {
+		io.LoggerFromContext(ctx).Warn().Msgf("No retained resources of %v were purged, because they are unsupported (or you did not selected in the interactive prompt).", *stackName)
+		return nil
+	}
+	if len(unsupportedResources) > 0 {
+		io.LoggerFromContext(ctx).Warn().Msgf(
+			"The retained resources of %v were purged, except for these resources unsupported (or you did not selected in the interactive prompt):\n%v",
+			*stackName,
+			*abandonedResourcesToTableFormat(unsupportedResources),
+		)
+		return nil
+	}
+	io.LoggerFromContext(ctx).Info().Msgf("The retained resources of %v were purged.", *stackName)
+
+	return nil
+}
+
+// RetainedResourcesToTableFormat returns the retained resources as a table of the stacks, resource types, logical
+// IDs, physical IDs and the reasons.
+func RetainedResourcesToTableFormat(retainedResources []RetainedResource) *string {
+	header := []string{"Stack", "ResourceType", "Resource", "PhysicalResourceId", "Reason"}
+	data := [][]string{}
+	for _, retainedResource := range retainedResources {
+		data = append(data, []string{
+			retainedResource.StackName,
+			aws.ToString(retainedResource.Resource.ResourceType),
+			aws.ToString(retainedResource.Resource.LogicalResourceId),
+			aws.ToString(retainedResource.Resource.PhysicalResourceId),
+			retainedResource.Reason,
+		})
+	}
+	return io.ToStringAsTableFormat(header, data)
+}
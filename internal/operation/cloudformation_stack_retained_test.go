@@ -0,0 +1,308 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestCloudFormationStackOperator_ListRetainedResources(t *testing.T) {
+	io.NewLogger(false)
+
+	yamlTemplate := `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  Bucket:
+    Type: AWS::S3::Bucket
+    DeletionPolicy: Retain
+    Properties:
+      BucketName: !Sub "${AWS::StackName}-bucket"
+  Repository:
+    Type: AWS::ECR::Repository
+    DeletionPolicy: RetainExceptOnCreate
+  Role:
+    Type: AWS::IAM::Role
+    DeletionPolicy: !If [IsProd, Retain, Delete]
+  Child:
+    Type: AWS::CloudFormation::Stack
+    Properties:
+      TemplateURL: !Ref TemplateURL
+`
+	childTemplate := `{"Resources":{"Vault":{"Type":"AWS::Backup::BackupVault","DeletionPolicy":"Retain"},"Queue":{"Type":"AWS::SQS::Queue","DeletionPolicy":"Delete"}}}`
+
+	type args struct {
+		ctx       context.Context
+		stackName *string
+	}
+
+	type want struct {
+		retainedResources []RetainedResource
+		err               error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "list retained resources of the stack and its nested child stacks successfully",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().GetTemplate(gomock.Any(), aws.String("test")).Return(aws.String(yamlTemplate), nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("Bucket"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::S3::Bucket"),
+							PhysicalResourceId: aws.String("test-bucket"),
+						},
+						{
+							LogicalResourceId:  aws.String("Repository"),
+							ResourceStatus:     "UPDATE_COMPLETE",
+							ResourceType:       aws.String("AWS::ECR::Repository"),
+							PhysicalResourceId: aws.String("repository"),
+						},
+						{
+							LogicalResourceId:  aws.String("Role"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::IAM::Role"),
+							PhysicalResourceId: aws.String("role"),
+						},
+						{
+							LogicalResourceId:  aws.String("Child"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							PhysicalResourceId: aws.String("arn:aws:cloudformation:ap-northeast-1:123456789012:stack/test-Child-XXX/ID"),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().GetTemplate(gomock.Any(), aws.String("test-Child-XXX")).Return(aws.String(childTemplate), nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test-Child-XXX")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("Vault"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::Backup::BackupVault"),
+							PhysicalResourceId: aws.String("vault"),
+						},
+						{
+							LogicalResourceId:  aws.String("Queue"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::SQS::Queue"),
+							PhysicalResourceId: aws.String("queue"),
+						},
+					},
+					nil,
+				)
+			},
+			want: want{
+				retainedResources: []RetainedResource{
+					{
+						StackName: "test",
+						Reason:    "DeletionPolicy: Retain",
+						Resource: types.StackResourceSummary{
+							LogicalResourceId:  aws.String("Bucket"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::S3::Bucket"),
+							PhysicalResourceId: aws.String("test-bucket"),
+						},
+					},
+					{
+						StackName: "test",
+						Reason:    "DeletionPolicy: RetainExceptOnCreate",
+						Resource: types.StackResourceSummary{
+							LogicalResourceId:  aws.String("Repository"),
+							ResourceStatus:     "UPDATE_COMPLETE",
+							ResourceType:       aws.String("AWS::ECR::Repository"),
+							PhysicalResourceId: aws.String("repository"),
+						},
+					},
+					{
+						StackName: "test-Child-XXX",
+						Reason:    "DeletionPolicy: Retain",
+						Resource: types.StackResourceSummary{
+							LogicalResourceId:  aws.String("Vault"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::Backup::BackupVault"),
+							PhysicalResourceId: aws.String("vault"),
+						},
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list no retained resources for resources not created or already deleted",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().GetTemplate(gomock.Any(), aws.String("test")).Return(aws.String(yamlTemplate), nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId: aws.String("Bucket"),
+							ResourceStatus:    "CREATE_FAILED",
+							ResourceType:      aws.String("AWS::S3::Bucket"),
+						},
+						{
+							LogicalResourceId:  aws.String("Repository"),
+							ResourceStatus:     "DELETE_COMPLETE",
+							ResourceType:       aws.String("AWS::ECR::Repository"),
+							PhysicalResourceId: aws.String("repository"),
+						},
+					},
+					nil,
+				)
+			},
+			want: want{
+				retainedResources: []RetainedResource{},
+				err:               nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list no retained resources for not existing stack",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().GetTemplate(gomock.Any(), aws.String("test")).Return(nil, nil)
+			},
+			want: want{
+				retainedResources: []RetainedResource{},
+				err:               nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list retained resources failure for invalid template",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().GetTemplate(gomock.Any(), aws.String("test")).Return(aws.String("Resources: ["), nil)
+			},
+			want: want{
+				retainedResources: []RetainedResource{},
+				err:               fmt.Errorf("TemplateParseError: test: yaml: line 1: did not find expected node content"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "list retained resources failure for list stack resources errors",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().GetTemplate(gomock.Any(), aws.String("test")).Return(aws.String(childTemplate), nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(nil, fmt.Errorf("ListStackResourcesError"))
+			},
+			want: want{
+				retainedResources: []RetainedResource{},
+				err:               fmt.Errorf("ListStackResourcesError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, []string{}, client.DefaultStackDeletionTimeout)
+
+			got, err := cloudformationStackOperator.ListRetainedResources(tt.args.ctx, tt.args.stackName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want.retainedResources) {
+				t.Errorf("output = %#v, want %#v", got, tt.want.retainedResources)
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackOperator_purgeRetainedResources(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx               context.Context
+		stackName         *string
+		retainedResources []RetainedResource
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "purge no custom resources without the operators successfully",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				retainedResources: []RetainedResource{
+					{
+						StackName: "test",
+						Resource: types.StackResourceSummary{
+							LogicalResourceId:  aws.String("CustomResource"),
+							PhysicalResourceId: aws.String("CustomResourcePhysicalId"),
+							ResourceType:       aws.String("Custom::Resource"),
+							ResourceStatus:     types.ResourceStatusDeleteSkipped,
+						},
+						Reason: DeletionPolicyRetain,
+					},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, []string{"Custom::"}, client.DefaultStackDeletionTimeout)
+			cloudformationStackOperator.SetPurgeRetained(true)
+
+			err := cloudformationStackOperator.purgeRetainedResources(tt.args.ctx, tt.args.stackName, tt.args.retainedResources)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
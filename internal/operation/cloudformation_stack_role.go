@@ -0,0 +1,199 @@
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+)
+
+const CloudFormationServicePrincipal = "cloudformation.amazonaws.com"
+
+// deleteStackWithServiceRole deletes the stack with the service role of --cfn-role-arn. Without it, if the deletion
+// fails because the service role recorded in the stack no longer exists, the deletion is retried with the role of
+// the caller, because CloudFormation keeps using the recorded role unless another role is specified. The retry is
+// done only if the trust policy of the caller's role allows CloudFormation to assume it.
+func (o *CloudFormationStackOperator) deleteStackWithServiceRole(
+	ctx context.Context,
+	stackName *string,
+	retainResources []string,
+	deletionMode types.DeletionMode,
+) (*client.DeleteStackResult, error) {
+	result, err := o.client.DeleteStack(ctx, stackName, retainResources, deletionMode, o.cfnRoleArn)
+	if err == nil || o.cfnRoleArn != nil || o.iamClient == nil || o.stsClient == nil {
+		return result, err
+	}
+	if !strings.Contains(err.Error(), "is invalid or cannot be assumed") {
+		return result, err
+	}
+
+	recordedRoleArn, exists, checkErr := o.checkRecordedServiceRoleExists(ctx, stackName)
+	if checkErr != nil {
+		return nil, checkErr
+	}
+	if exists {
+		return result, err
+	}
+
+	callerRoleArn, callerErr := o.getCallerRoleArn(ctx)
+	if callerErr != nil {
+		errMsg := fmt.Sprintf("%v: the service role %v no longer exists, so specify --cfn-role-arn option: %v", *stackName, recordedRoleArn, callerErr)
+		return nil, fmt.Errorf("ServiceRoleNotFoundError: %v", errMsg)
+	}
+
+	io.LoggerFromContext(ctx).Warn().Msgf(
+		"The service role %v of %v no longer exists, so retry the deletion with the role of the caller, %v",
+		recordedRoleArn,
+		*stackName,
+		*callerRoleArn,
+	)
+
+	return o.client.DeleteStack(ctx, stackName, retainResources, deletionMode, callerRoleArn)
+}
+
+// checkRecordedServiceRoleExists returns the service role recorded in the stack and whether it still exists.
+// It is regarded as existing if no role is recorded.
+func (o *CloudFormationStackOperator) checkRecordedServiceRoleExists(ctx context.Context, stackName *string) (string, bool, error) {
+	stacks, err := o.client.DescribeStacks(ctx, stackName)
+	if err != nil {
+		return "", false, err
+	}
+	if len(stacks) == 0 || stacks[0].RoleARN == nil {
+		return "", true, nil
+	}
+
+	roleArn := aws.ToString(stacks[0].RoleARN)
+	// the role name is the last element of the path, e.g. arn:aws:iam::123456789012:role/path/RoleName
+	roleName := roleArn[strings.LastIndex(roleArn, "/")+1:]
+
+	exists, err := o.iamClient.CheckRoleExists(ctx, aws.String(roleName))
+	if err != nil {
+		return "", false, err
+	}
+
+	return roleArn, exists, nil
+}
+
+// getCallerRoleArn returns the ARN of the IAM role the caller assumes, if CloudFormation can assume it.
+func (o *CloudFormationStackOperator) getCallerRoleArn(ctx context.Context) (*string, error) {
+	callerArn, err := o.stsClient.GetCallerIdentityArn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// e.g. arn:aws:sts::123456789012:assumed-role/RoleName/SessionName
+	parsedArn, err := arn.Parse(aws.ToString(callerArn))
+	if err != nil {
+		return nil, err
+	}
+	resource := strings.Split(parsedArn.Resource, "/")
+	if parsedArn.Service != "sts" || len(resource) < 2 || resource[0] != "assumed-role" {
+		return nil, fmt.Errorf("the caller %v is not an IAM role", aws.ToString(callerArn))
+	}
+
+	// the ARN of the assumed role does not include the path of the role
+	role, err := o.iamClient.GetRole(ctx, aws.String(resource[1]))
+	if err != nil {
+		return nil, err
+	}
+
+	trusted, err := trustsCloudFormation(aws.ToString(role.AssumeRolePolicyDocument))
+	if err != nil {
+		return nil, err
+	}
+	if !trusted {
+		return nil, fmt.Errorf("the role of the caller %v cannot be assumed by %v", aws.ToString(role.Arn), CloudFormationServicePrincipal)
+	}
+
+	return role.Arn, nil
+}
+
+// trustsCloudFormation returns whether the trust policy of a role allows CloudFormation to assume the role.
+// The policy document returned by IAM is URL-encoded. The conditions of the statements are not evaluated.
+func trustsCloudFormation(policyDocument string) (bool, error) {
+	decoded, err := url.QueryUnescape(policyDocument)
+	if err != nil {
+		return false, err
+	}
+
+	var document struct {
+		Statement interface{} `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(decoded), &document); err != nil {
+		return false, err
+	}
+
+	// Statement is either a statement or a list of statements
+	statements, ok := document.Statement.([]interface{})
+	if !ok {
+		statements = []interface{}{document.Statement}
+	}
+
+	trusted := false
+	for _, s := range statements {
+		statement, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if !allowsAssumeRole(statement["Action"]) || !includesCloudFormation(statement["Principal"]) {
+			continue
+		}
+		switch statement["Effect"] {
+		case "Deny":
+			return false, nil
+		case "Allow":
+			trusted = true
+		}
+	}
+
+	return trusted, nil
+}
+
+func allowsAssumeRole(action interface{}) bool {
+	for _, a := range toStrings(action) {
+		if a == "*" || strings.EqualFold(a, "sts:*") || strings.EqualFold(a, "sts:AssumeRole") {
+			return true
+		}
+	}
+	return false
+}
+
+func includesCloudFormation(principal interface{}) bool {
+	if principal == "*" {
+		return true
+	}
+	principals, ok := principal.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, service := range toStrings(principals["Service"]) {
+		if service == CloudFormationServicePrincipal {
+			return true
+		}
+	}
+	return false
+}
+
+// toStrings returns the values of a policy element, which is either a string or a list of strings.
+func toStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := []string{}
+		for _, element := range v {
+			if str, ok := element.(string); ok {
+				values = append(values, str)
+			}
+		}
+		return values
+	}
+	return []string{}
+}
@@ -0,0 +1,326 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+const cloudFormationTrustPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"cloudformation.amazonaws.com"},"Action":"sts:AssumeRole"}]}`
+
+func TestCloudFormationStackOperator_deleteStackWithServiceRole(t *testing.T) {
+	io.NewLogger(false)
+
+	invalidRoleErr := fmt.Errorf("Role arn:aws:iam::123456789012:role/path/DeletedRole is invalid or cannot be assumed")
+
+	type args struct {
+		ctx        context.Context
+		stackName  *string
+		cfnRoleArn *string
+	}
+
+	type want struct {
+		result *client.DeleteStackResult
+		err    error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		prepareMockIamFn            func(m *client.MockIIam)
+		prepareMockStsFn            func(m *client.MockISts)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "delete stack with the service role of the option successfully",
+			args: args{
+				ctx:        context.Background(),
+				stackName:  aws.String("test"),
+				cfnRoleArn: aws.String("arn:aws:iam::123456789012:role/CfnRole"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, aws.String("arn:aws:iam::123456789012:role/CfnRole")).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
+			},
+			prepareMockIamFn: func(m *client.MockIIam) {},
+			prepareMockStsFn: func(m *client.MockISts) {},
+			want: want{
+				result: &client.DeleteStackResult{Status: client.StackDeletionStatusDeleted},
+				err:    nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete stack failure with the service role of the option without fallback",
+			args: args{
+				ctx:        context.Background(),
+				stackName:  aws.String("test"),
+				cfnRoleArn: aws.String("arn:aws:iam::123456789012:role/CfnRole"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, aws.String("arn:aws:iam::123456789012:role/CfnRole")).Return(nil, invalidRoleErr)
+			},
+			prepareMockIamFn: func(m *client.MockIIam) {},
+			prepareMockStsFn: func(m *client.MockISts) {},
+			want: want{
+				result: nil,
+				err:    invalidRoleErr,
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete stack successfully with the role of the caller for the deleted service role",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(nil, invalidRoleErr)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("test"),
+							RoleARN:   aws.String("arn:aws:iam::123456789012:role/path/DeletedRole"),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, aws.String("arn:aws:iam::123456789012:role/admin/CallerRole")).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
+			},
+			prepareMockIamFn: func(m *client.MockIIam) {
+				m.EXPECT().CheckRoleExists(gomock.Any(), aws.String("DeletedRole")).Return(false, nil)
+				m.EXPECT().GetRole(gomock.Any(), aws.String("CallerRole")).Return(
+					&iamTypes.Role{
+						RoleName:                 aws.String("CallerRole"),
+						Arn:                      aws.String("arn:aws:iam::123456789012:role/admin/CallerRole"),
+						AssumeRolePolicyDocument: aws.String(url.QueryEscape(cloudFormationTrustPolicy)),
+					},
+					nil,
+				)
+			},
+			prepareMockStsFn: func(m *client.MockISts) {
+				m.EXPECT().GetCallerIdentityArn(gomock.Any()).Return(aws.String("arn:aws:sts::123456789012:assumed-role/CallerRole/session"), nil)
+			},
+			want: want{
+				result: &client.DeleteStackResult{Status: client.StackDeletionStatusDeleted},
+				err:    nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete stack failure for the role of the caller not assumable by CloudFormation",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(nil, invalidRoleErr)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("test"),
+							RoleARN:   aws.String("arn:aws:iam::123456789012:role/path/DeletedRole"),
+						},
+					},
+					nil,
+				)
+			},
+			prepareMockIamFn: func(m *client.MockIIam) {
+				m.EXPECT().CheckRoleExists(gomock.Any(), aws.String("DeletedRole")).Return(false, nil)
+				m.EXPECT().GetRole(gomock.Any(), aws.String("CallerRole")).Return(
+					&iamTypes.Role{
+						RoleName:                 aws.String("CallerRole"),
+						Arn:                      aws.String("arn:aws:iam::123456789012:role/admin/CallerRole"),
+						AssumeRolePolicyDocument: aws.String(url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"sts:AssumeRole"}]}`)),
+					},
+					nil,
+				)
+			},
+			prepareMockStsFn: func(m *client.MockISts) {
+				m.EXPECT().GetCallerIdentityArn(gomock.Any()).Return(aws.String("arn:aws:sts::123456789012:assumed-role/CallerRole/session"), nil)
+			},
+			want: want{
+				result: nil,
+				err:    fmt.Errorf("ServiceRoleNotFoundError: test: the service role arn:aws:iam::123456789012:role/path/DeletedRole no longer exists, so specify --cfn-role-arn option: the role of the caller arn:aws:iam::123456789012:role/admin/CallerRole cannot be assumed by cloudformation.amazonaws.com"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete stack failure for the existing service role",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(nil, invalidRoleErr)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("test"),
+							RoleARN:   aws.String("arn:aws:iam::123456789012:role/path/DeletedRole"),
+						},
+					},
+					nil,
+				)
+			},
+			prepareMockIamFn: func(m *client.MockIIam) {
+				m.EXPECT().CheckRoleExists(gomock.Any(), aws.String("DeletedRole")).Return(true, nil)
+			},
+			prepareMockStsFn: func(m *client.MockISts) {},
+			want: want{
+				result: nil,
+				err:    invalidRoleErr,
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete stack failure for the caller is not a role",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(nil, invalidRoleErr)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("test"),
+							RoleARN:   aws.String("arn:aws:iam::123456789012:role/path/DeletedRole"),
+						},
+					},
+					nil,
+				)
+			},
+			prepareMockIamFn: func(m *client.MockIIam) {
+				m.EXPECT().CheckRoleExists(gomock.Any(), aws.String("DeletedRole")).Return(false, nil)
+			},
+			prepareMockStsFn: func(m *client.MockISts) {
+				m.EXPECT().GetCallerIdentityArn(gomock.Any()).Return(aws.String("arn:aws:iam::123456789012:user/test-user"), nil)
+			},
+			want: want{
+				result: nil,
+				err:    fmt.Errorf("ServiceRoleNotFoundError: test: the service role arn:aws:iam::123456789012:role/path/DeletedRole no longer exists, so specify --cfn-role-arn option: the caller arn:aws:iam::123456789012:user/test-user is not an IAM role"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete stack failure for other errors without fallback",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(nil, fmt.Errorf("DeleteStackError"))
+			},
+			prepareMockIamFn: func(m *client.MockIIam) {},
+			prepareMockStsFn: func(m *client.MockISts) {},
+			want: want{
+				result: nil,
+				err:    fmt.Errorf("DeleteStackError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+			iamMock := client.NewMockIIam(ctrl)
+			stsMock := client.NewMockISts(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+			tt.prepareMockIamFn(iamMock)
+			tt.prepareMockStsFn(stsMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, []string{}, client.DefaultStackDeletionTimeout)
+			cloudformationStackOperator.SetServiceRole(tt.args.cfnRoleArn, iamMock, stsMock)
+
+			got, err := cloudformationStackOperator.deleteStackWithServiceRole(tt.args.ctx, tt.args.stackName, []string{}, types.DeletionModeStandard)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want.result) {
+				t.Errorf("output = %#v, want %#v", got, tt.want.result)
+			}
+		})
+	}
+}
+
+func Test_trustsCloudFormation(t *testing.T) {
+	cases := []struct {
+		name           string
+		policyDocument string
+		want           bool
+		wantErr        bool
+	}{
+		{
+			name:           "trusted by the statement list",
+			policyDocument: url.QueryEscape(cloudFormationTrustPolicy),
+			want:           true,
+			wantErr:        false,
+		},
+		{
+			name:           "trusted by the single statement with the service list",
+			policyDocument: url.QueryEscape(`{"Version":"2012-10-17","Statement":{"Effect":"Allow","Principal":{"Service":["ec2.amazonaws.com","cloudformation.amazonaws.com"]},"Action":["sts:AssumeRole"]}}`),
+			want:           true,
+			wantErr:        false,
+		},
+		{
+			name:           "not trusted for another service",
+			policyDocument: url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"ec2.amazonaws.com"},"Action":"sts:AssumeRole"}]}`),
+			want:           false,
+			wantErr:        false,
+		},
+		{
+			name:           "not trusted for another action",
+			policyDocument: url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"cloudformation.amazonaws.com"},"Action":"sts:TagSession"}]}`),
+			want:           false,
+			wantErr:        false,
+		},
+		{
+			name:           "not trusted for the deny statement",
+			policyDocument: url.QueryEscape(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"Service":"cloudformation.amazonaws.com"},"Action":"sts:AssumeRole"},{"Effect":"Deny","Principal":"*","Action":"*"}]}`),
+			want:           false,
+			wantErr:        false,
+		},
+		{
+			name:           "failure for the invalid document",
+			policyDocument: url.QueryEscape(`{"Statement":`),
+			want:           false,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := trustsCloudFormation(tt.policyDocument)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
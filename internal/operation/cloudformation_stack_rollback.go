@@ -0,0 +1,113 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/resourcetype"
+)
+
+// recoverUpdateRollbackFailedStack continues the rollback of the UPDATE_ROLLBACK_FAILED stack, skipping the
+// resources that failed to roll back, and waits for UPDATE_ROLLBACK_COMPLETE so that the stack can be deleted.
+func (o *CloudFormationStackOperator) recoverUpdateRollbackFailedStack(ctx context.Context, stack *types.Stack) error {
+	// the rollback of a nested stack can be continued only from the root stack
+	rollbackStackName := stack.StackName
+	if stack.RootId != nil {
+		rollbackStackName = stack.RootId
+	}
+
+	resourcesToSkip, err := o.listResourcesToSkip(ctx, rollbackStackName, "")
+	if err != nil {
+		return err
+	}
+
+	io.LoggerFromContext(ctx).Info().Msgf(
+		"%v is UPDATE_ROLLBACK_FAILED, continuing the rollback of %v with ResourcesToSkip %v.",
+		aws.ToString(stack.StackName),
+		aws.ToString(rollbackStackName),
+		resourcesToSkip,
+	)
+
+	var settledStack *types.Stack
+	err = o.streamStackEventsWhile(ctx, rollbackStackName, func() error {
+		if err := o.client.ContinueUpdateRollback(ctx, rollbackStackName, resourcesToSkip); err != nil {
+			return err
+		}
+		var err error
+		settledStack, err = o.client.WaitStackOperationComplete(ctx, rollbackStackName)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if settledStack == nil {
+		return fmt.Errorf("UpdateRollbackFailedError: %v: the stack no longer exists", aws.ToString(rollbackStackName))
+	}
+	if settledStack.StackStatus != types.StackStatusUpdateRollbackComplete {
+		return fmt.Errorf(
+			"UpdateRollbackFailedError: %v: the rollback ended in %v: %v",
+			aws.ToString(rollbackStackName),
+			settledStack.StackStatus,
+			aws.ToString(settledStack.StackStatusReason),
+		)
+	}
+
+	return nil
+}
+
+// listResourcesToSkip returns the logical IDs of the resources that failed in the latest rollback of the stack.
+// The resources in nested stacks are returned in the NestedStackName.ResourceLogicalID format, because
+// ContinueUpdateRollback for the root stack accepts only that format to skip them.
+func (o *CloudFormationStackOperator) listResourcesToSkip(ctx context.Context, stackName *string, prefix string) ([]string, error) {
+	resourcesToSkip := []string{}
+
+	// the events are read across the pages until the start of the latest rollback
+	events, err := o.client.DescribeStackEventsUntil(ctx, stackName, isUpdateRollbackStartEvent)
+	if err != nil {
+		return resourcesToSkip, err
+	}
+
+	found := map[string]struct{}{}
+	for _, event := range events {
+		// events of the stack itself, the latest UPDATE_ROLLBACK_IN_PROGRESS of which is the start of the rollback
+		if aws.ToString(event.PhysicalResourceId) == aws.ToString(event.StackId) {
+			if isUpdateRollbackStartEvent(event) {
+				break
+			}
+			continue
+		}
+		if event.ResourceStatus != types.ResourceStatusUpdateFailed {
+			continue
+		}
+
+		logicalId := aws.ToString(event.LogicalResourceId)
+		if _, ok := found[logicalId]; ok {
+			continue
+		}
+		found[logicalId] = struct{}{}
+
+		// a nested stack itself cannot be skipped unless it is deleted, so the failed resources in it are skipped
+		if aws.ToString(event.ResourceType) == resourcetype.CloudformationStack && event.PhysicalResourceId != nil {
+			nestedResourcesToSkip, err := o.listResourcesToSkip(ctx, event.PhysicalResourceId, prefix+logicalId+".")
+			if err != nil {
+				return resourcesToSkip, err
+			}
+			resourcesToSkip = append(resourcesToSkip, nestedResourcesToSkip...)
+			continue
+		}
+
+		resourcesToSkip = append(resourcesToSkip, prefix+logicalId)
+	}
+
+	return resourcesToSkip, nil
+}
+
+// isUpdateRollbackStartEvent returns whether the event is UPDATE_ROLLBACK_IN_PROGRESS of the stack itself.
+func isUpdateRollbackStartEvent(event types.StackEvent) bool {
+	return aws.ToString(event.PhysicalResourceId) == aws.ToString(event.StackId) &&
+		event.ResourceStatus == types.ResourceStatusUpdateRollbackInProgress
+}
@@ -0,0 +1,261 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestCloudFormationStackOperator_listResourcesToSkip(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx       context.Context
+		stackName *string
+	}
+
+	type want struct {
+		resourcesToSkip []string
+		err             error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "list resources failed in the latest rollback including nested stacks",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("test"), gomock.Any()).Return(
+					[]types.StackEvent{
+						{
+							StackId:            aws.String("StackId"),
+							LogicalResourceId:  aws.String("test"),
+							PhysicalResourceId: aws.String("StackId"),
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							ResourceStatus:     types.ResourceStatusUpdateRollbackFailed,
+						},
+						{
+							StackId:            aws.String("StackId"),
+							LogicalResourceId:  aws.String("Child"),
+							PhysicalResourceId: aws.String("ChildStackId"),
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							ResourceStatus:     types.ResourceStatusUpdateFailed,
+						},
+						{
+							StackId:            aws.String("StackId"),
+							LogicalResourceId:  aws.String("Function"),
+							PhysicalResourceId: aws.String("FunctionId"),
+							ResourceType:       aws.String("AWS::Lambda::Function"),
+							ResourceStatus:     types.ResourceStatusUpdateFailed,
+						},
+						{
+							StackId:            aws.String("StackId"),
+							LogicalResourceId:  aws.String("Topic"),
+							PhysicalResourceId: aws.String("TopicId"),
+							ResourceType:       aws.String("AWS::SNS::Topic"),
+							ResourceStatus:     types.ResourceStatusUpdateComplete,
+						},
+						{
+							StackId:            aws.String("StackId"),
+							LogicalResourceId:  aws.String("test"),
+							PhysicalResourceId: aws.String("StackId"),
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							ResourceStatus:     types.ResourceStatusUpdateRollbackInProgress,
+						},
+						{
+							StackId:            aws.String("StackId"),
+							LogicalResourceId:  aws.String("Queue"),
+							PhysicalResourceId: aws.String("QueueId"),
+							ResourceType:       aws.String("AWS::SQS::Queue"),
+							ResourceStatus:     types.ResourceStatusUpdateFailed,
+						},
+					},
+					nil,
+				)
+
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("ChildStackId"), gomock.Any()).Return(
+					[]types.StackEvent{
+						{
+							StackId:            aws.String("ChildStackId"),
+							LogicalResourceId:  aws.String("test-Child"),
+							PhysicalResourceId: aws.String("ChildStackId"),
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							ResourceStatus:     types.ResourceStatusUpdateRollbackFailed,
+						},
+						{
+							StackId:            aws.String("ChildStackId"),
+							LogicalResourceId:  aws.String("Role"),
+							PhysicalResourceId: aws.String("RoleId"),
+							ResourceType:       aws.String("AWS::IAM::Role"),
+							ResourceStatus:     types.ResourceStatusUpdateFailed,
+						},
+						{
+							StackId:            aws.String("ChildStackId"),
+							LogicalResourceId:  aws.String("test-Child"),
+							PhysicalResourceId: aws.String("ChildStackId"),
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							ResourceStatus:     types.ResourceStatusUpdateRollbackInProgress,
+						},
+					},
+					nil,
+				)
+			},
+			want: want{
+				resourcesToSkip: []string{"Child.Role", "Function"},
+				err:             nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list no resources for no failed resources",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("test"), gomock.Any()).Return([]types.StackEvent{}, nil)
+			},
+			want: want{
+				resourcesToSkip: []string{},
+				err:             nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list resources failure",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("test"), gomock.Any()).Return(nil, fmt.Errorf("DescribeStackEventsError"))
+			},
+			want: want{
+				resourcesToSkip: []string{},
+				err:             fmt.Errorf("DescribeStackEventsError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, []string{}, client.DefaultStackDeletionTimeout)
+
+			got, err := cloudformationStackOperator.listResourcesToSkip(tt.args.ctx, tt.args.stackName, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want.resourcesToSkip) {
+				t.Errorf("output = %#v, want %#v", got, tt.want.resourcesToSkip)
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackOperator_recoverUpdateRollbackFailedStack(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx   context.Context
+		stack *types.Stack
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		wantErr                     error
+	}{
+		{
+			name: "recover nested stack by continuing the rollback of the root stack",
+			args: args{
+				ctx: context.Background(),
+				stack: &types.Stack{
+					StackName:   aws.String("test-Child"),
+					StackStatus: types.StackStatusUpdateRollbackFailed,
+					RootId:      aws.String("RootStackId"),
+				},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEvents(gomock.Any(), aws.String("RootStackId"), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("RootStackId"), gomock.Any()).Return([]types.StackEvent{}, nil)
+				m.EXPECT().ContinueUpdateRollback(gomock.Any(), aws.String("RootStackId"), []string{}).Return(nil)
+				m.EXPECT().WaitStackOperationComplete(gomock.Any(), aws.String("RootStackId")).Return(
+					&types.Stack{
+						StackName:   aws.String("test"),
+						StackStatus: types.StackStatusUpdateRollbackComplete,
+					},
+					nil,
+				)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "recover failure for stack no longer exists",
+			args: args{
+				ctx: context.Background(),
+				stack: &types.Stack{
+					StackName:   aws.String("test"),
+					StackStatus: types.StackStatusUpdateRollbackFailed,
+				},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackEvents(gomock.Any(), aws.String("test"), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("test"), gomock.Any()).Return([]types.StackEvent{}, nil)
+				m.EXPECT().ContinueUpdateRollback(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().WaitStackOperationComplete(gomock.Any(), aws.String("test")).Return(nil, nil)
+			},
+			wantErr: fmt.Errorf("UpdateRollbackFailedError: test: the stack no longer exists"),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, []string{}, client.DefaultStackDeletionTimeout)
+
+			err := cloudformationStackOperator.recoverUpdateRollbackFailedStack(tt.args.ctx, tt.args.stack)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+var _ IOperator = (*CloudFormationStackSetOperator)(nil)
+
+const (
+	DefaultMaxConcurrentPercentage    = 100
+	DefaultFailureTolerancePercentage = 0
+)
+
+type CloudFormationStackSetOperator struct {
+	client               client.ICloudFormation
+	resources            []*types.StackResourceSummary
+	callAs               types.CallAs
+	operationPreferences *types.StackSetOperationPreferences
+}
+
+func NewCloudFormationStackSetOperator(client client.ICloudFormation) *CloudFormationStackSetOperator {
+	return &CloudFormationStackSetOperator{
+		client:    client,
+		resources: []*types.StackResourceSummary{},
+		callAs:    types.CallAsSelf,
+	}
+}
+
+// SetCallAs sets whether the StackSets are called as the management account (SELF) or as a delegated
+// administrator (DELEGATED_ADMIN) for service-managed StackSets.
+func (o *CloudFormationStackSetOperator) SetCallAs(callAs types.CallAs) {
+	o.callAs = callAs
+}
+
+// SetOperationPreferences sets the concurrency and the failure tolerance of the deletion of the stack instances,
+// or nil to use the defaults of CloudFormation.
+func (o *CloudFormationStackSetOperator) SetOperationPreferences(operationPreferences *types.StackSetOperationPreferences) {
+	o.operationPreferences = operationPreferences
+}
+
+func (o *CloudFormationStackSetOperator) AddResource(resource *types.StackResourceSummary) {
+	o.resources = append(o.resources, resource)
+}
+
+func (o *CloudFormationStackSetOperator) GetResourcesLength() int {
+	return len(o.resources)
+}
+
+func (o *CloudFormationStackSetOperator) DeleteResources(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	sem := semaphore.NewWeighted(int64(runtime.NumCPU()))
+
+	for _, stackSet := range o.resources {
+		stackSet := stackSet
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return err
+		}
+		eg.Go(func() error {
+			defer sem.Release(1)
+
+			// the physical ID is the StackSet ID (name:UUID), which the APIs accept as the name
+			return deleteResourceWithJournal(ctx, stackSet, func() error {
+				return o.DeleteStackSet(ctx, stackSet.PhysicalResourceId)
+			})
+		})
+	}
+
+	return eg.Wait()
+}
+
+// ListStackSetInstances returns the stack instances of the StackSet and whether the StackSet exists.
+func (o *CloudFormationStackSetOperator) ListStackSetInstances(ctx context.Context, stackSetName *string) ([]types.StackInstanceSummary, bool, error) {
+	stackSet, err := o.client.DescribeStackSet(ctx, stackSetName, o.callAs)
+	if err != nil {
+		return nil, false, err
+	}
+	if stackSet == nil {
+		return []types.StackInstanceSummary{}, false, nil
+	}
+
+	stackInstances, err := o.client.ListStackInstances(ctx, stackSetName, o.callAs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return stackInstances, true, nil
+}
+
+// DeleteStackSet deletes all the stack instances of the StackSet across the accounts and the regions, and then
+// deletes the StackSet itself. It does nothing if the StackSet does not exist.
+func (o *CloudFormationStackSetOperator) DeleteStackSet(ctx context.Context, stackSetName *string) error {
+	stackSet, err := o.client.DescribeStackSet(ctx, stackSetName, o.callAs)
+	if err != nil {
+		return err
+	}
+	if stackSet == nil {
+		io.LoggerFromContext(ctx).Info().Msgf("%v does not exist", *stackSetName)
+		return nil
+	}
+
+	stackInstances, err := o.client.ListStackInstances(ctx, stackSetName, o.callAs)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range groupStackInstances(stackInstances, stackSet.PermissionModel) {
+		members := target.Accounts
+		if stackSet.PermissionModel == types.PermissionModelsServiceManaged {
+			members = target.OrganizationalUnitIds
+		}
+		io.LoggerFromContext(ctx).Info().Msgf(
+			"Delete the stack instances of %v in %v and %v",
+			*stackSetName,
+			strings.Join(members, ", "),
+			strings.Join(target.Regions, ", "),
+		)
+
+		result, err := o.client.DeleteStackInstances(ctx, stackSetName, target, o.operationPreferences, o.callAs)
+		if err != nil {
+			return err
+		}
+		if result.Status != types.StackSetOperationStatusSucceeded {
+			return o.newStackInstancesDeletionError(ctx, stackSetName, result)
+		}
+	}
+
+	return o.client.DeleteStackSet(ctx, stackSetName, o.callAs)
+}
+
+// newStackInstancesDeletionError returns the error of the failed operation with the stack instances remaining.
+func (o *CloudFormationStackSetOperator) newStackInstancesDeletionError(
+	ctx context.Context,
+	stackSetName *string,
+	result *client.StackSetOperationResult,
+) error {
+	stackInstances, err := o.client.ListStackInstances(ctx, stackSetName, o.callAs)
+	if err != nil {
+		return err
+	}
+
+	errMsg := fmt.Sprintf(
+		"%v: the operation is %v: %v\nThese are the stack instances remaining:\n%v",
+		*stackSetName,
+		result.Status,
+		result.StatusReason,
+		*StackInstancesToTableFormat(stackInstances),
+	)
+	return fmt.Errorf("StackInstancesDeletionError: %v", errMsg)
+}
+
+// groupStackInstances returns the targets of DeleteStackInstances, which are the regions grouped by the accounts
+// (self-managed) or the organizational units (service-managed) of their stack instances. Since an operation
+// deletes the instances in all the combinations of the accounts and the regions, and operations on a StackSet
+// cannot run at the same time, this keeps the operations few without deleting instances that do not exist.
+func groupStackInstances(stackInstances []types.StackInstanceSummary, permissionModel types.PermissionModels) []client.StackInstancesTarget {
+	membersByRegion := map[string]map[string]bool{}
+	for _, stackInstance := range stackInstances {
+		region := aws.ToString(stackInstance.Region)
+		member := aws.ToString(stackInstance.Account)
+		if permissionModel == types.PermissionModelsServiceManaged {
+			member = aws.ToString(stackInstance.OrganizationalUnitId)
+		}
+		if _, ok := membersByRegion[region]; !ok {
+			membersByRegion[region] = map[string]bool{}
+		}
+		membersByRegion[region][member] = true
+	}
+
+	regions := []string{}
+	for region := range membersByRegion {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	targets := []client.StackInstancesTarget{}
+	targetIndexes := map[string]int{}
+	for _, region := range regions {
+		members := []string{}
+		for member := range membersByRegion[region] {
+			members = append(members, member)
+		}
+		sort.Strings(members)
+
+		key := strings.Join(members, ",")
+		if index, ok := targetIndexes[key]; ok {
+			targets[index].Regions = append(targets[index].Regions, region)
+			continue
+		}
+
+		target := client.StackInstancesTarget{
+			Regions: []string{region},
+		}
+		if permissionModel == types.PermissionModelsServiceManaged {
+			target.OrganizationalUnitIds = members
+		} else {
+			target.Accounts = members
+		}
+		targetIndexes[key] = len(targets)
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+// ParseCallAs returns the CallAs of the value, or SELF if it is empty.
+func ParseCallAs(value string) (types.CallAs, error) {
+	switch callAs := types.CallAs(strings.ToUpper(value)); callAs {
+	case "":
+		return types.CallAsSelf, nil
+	case types.CallAsSelf, types.CallAsDelegatedAdmin:
+		return callAs, nil
+	default:
+		errMsg := fmt.Sprintf("--call-as must be one of %v and %v: %v", types.CallAsSelf, types.CallAsDelegatedAdmin, value)
+		return "", fmt.Errorf("InvalidOptionError: %v", errMsg)
+	}
+}
+
+// NewStackSetOperationPreferences returns the operation preferences of the deletion of the stack instances,
+// validating the percentages and the region concurrency type (PARALLEL or SEQUENTIAL).
+func NewStackSetOperationPreferences(
+	maxConcurrentPercentage int,
+	failureTolerancePercentage int,
+	regionConcurrencyType string,
+) (*types.StackSetOperationPreferences, error) {
+	if maxConcurrentPercentage < 1 || maxConcurrentPercentage > 100 {
+		errMsg := fmt.Sprintf("--max-concurrent-percentage must be between 1 and 100: %d", maxConcurrentPercentage)
+		return nil, fmt.Errorf("InvalidOptionError: %v", errMsg)
+	}
+	if failureTolerancePercentage < 0 || failureTolerancePercentage > 100 {
+		errMsg := fmt.Sprintf("--failure-tolerance-percentage must be between 0 and 100: %d", failureTolerancePercentage)
+		return nil, fmt.Errorf("InvalidOptionError: %v", errMsg)
+	}
+
+	concurrencyType := types.RegionConcurrencyType(strings.ToUpper(regionConcurrencyType))
+	switch concurrencyType {
+	case "":
+		concurrencyType = types.RegionConcurrencyTypeParallel
+	case types.RegionConcurrencyTypeParallel, types.RegionConcurrencyTypeSequential:
+	default:
+		errMsg := fmt.Sprintf(
+			"--region-concurrency-type must be one of %v and %v: %v",
+			types.RegionConcurrencyTypeParallel,
+			types.RegionConcurrencyTypeSequential,
+			regionConcurrencyType,
+		)
+		return nil, fmt.Errorf("InvalidOptionError: %v", errMsg)
+	}
+
+	return &types.StackSetOperationPreferences{
+		MaxConcurrentPercentage:    aws.Int32(int32(maxConcurrentPercentage)),
+		FailureTolerancePercentage: aws.Int32(int32(failureTolerancePercentage)),
+		RegionConcurrencyType:      concurrencyType,
+	}, nil
+}
+
+// StackInstancesToTableFormat returns the stack instances as a table of the accounts, regions, statuses and reasons.
+func StackInstancesToTableFormat(stackInstances []types.StackInstanceSummary) *string {
+	header := []string{"Account", "Region", "Status", "Reason"}
+	data := [][]string{}
+	for _, stackInstance := range stackInstances {
+		data = append(data, []string{
+			aws.ToString(stackInstance.Account),
+			aws.ToString(stackInstance.Region),
+			string(stackInstance.Status),
+			aws.ToString(stackInstance.StatusReason),
+		})
+	}
+	return io.ToStringAsTableFormat(header, data)
+}
@@ -0,0 +1,564 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestCloudFormationStackSetOperator_DeleteStackSet(t *testing.T) {
+	io.NewLogger(false)
+
+	selfManagedInstances := []types.StackInstanceSummary{
+		{Account: aws.String("111111111111"), Region: aws.String("us-east-1")},
+		{Account: aws.String("222222222222"), Region: aws.String("us-east-1")},
+		{Account: aws.String("111111111111"), Region: aws.String("us-west-2")},
+	}
+	failedInstances := []types.StackInstanceSummary{
+		{
+			Account:      aws.String("222222222222"),
+			Region:       aws.String("us-east-1"),
+			Status:       types.StackInstanceStatusInoperable,
+			StatusReason: aws.String("Bucket is not empty"),
+		},
+	}
+
+	type args struct {
+		ctx          context.Context
+		stackSetName *string
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        error
+		wantErr                     bool
+	}{
+		{
+			name: "delete self-managed stack set successfully",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(
+					&types.StackSet{StackSetName: aws.String("test"), PermissionModel: types.PermissionModelsSelfManaged}, nil,
+				)
+				m.EXPECT().ListStackInstances(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(selfManagedInstances, nil)
+				m.EXPECT().DeleteStackInstances(
+					gomock.Any(),
+					aws.String("test"),
+					client.StackInstancesTarget{Accounts: []string{"111111111111", "222222222222"}, Regions: []string{"us-east-1"}},
+					gomock.Any(),
+					types.CallAsSelf,
+				).Return(&client.StackSetOperationResult{Status: types.StackSetOperationStatusSucceeded}, nil)
+				m.EXPECT().DeleteStackInstances(
+					gomock.Any(),
+					aws.String("test"),
+					client.StackInstancesTarget{Accounts: []string{"111111111111"}, Regions: []string{"us-west-2"}},
+					gomock.Any(),
+					types.CallAsSelf,
+				).Return(&client.StackSetOperationResult{Status: types.StackSetOperationStatusSucceeded}, nil)
+				m.EXPECT().DeleteStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete service-managed stack set successfully",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(
+					&types.StackSet{StackSetName: aws.String("test"), PermissionModel: types.PermissionModelsServiceManaged}, nil,
+				)
+				m.EXPECT().ListStackInstances(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(
+					[]types.StackInstanceSummary{
+						{Account: aws.String("111111111111"), OrganizationalUnitId: aws.String("ou-xxxx-11111111"), Region: aws.String("us-east-1")},
+						{Account: aws.String("222222222222"), OrganizationalUnitId: aws.String("ou-xxxx-11111111"), Region: aws.String("us-east-1")},
+						{Account: aws.String("111111111111"), OrganizationalUnitId: aws.String("ou-xxxx-11111111"), Region: aws.String("us-west-2")},
+					},
+					nil,
+				)
+				m.EXPECT().DeleteStackInstances(
+					gomock.Any(),
+					aws.String("test"),
+					client.StackInstancesTarget{OrganizationalUnitIds: []string{"ou-xxxx-11111111"}, Regions: []string{"us-east-1", "us-west-2"}},
+					gomock.Any(),
+					types.CallAsSelf,
+				).Return(&client.StackSetOperationResult{Status: types.StackSetOperationStatusSucceeded}, nil)
+				m.EXPECT().DeleteStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete stack set without stack instances successfully",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(
+					&types.StackSet{StackSetName: aws.String("test"), PermissionModel: types.PermissionModelsSelfManaged}, nil,
+				)
+				m.EXPECT().ListStackInstances(gomock.Any(), aws.String("test"), types.CallAsSelf).Return([]types.StackInstanceSummary{}, nil)
+				m.EXPECT().DeleteStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete stack set successfully for not existing stack set",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(nil, nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete stack set failure for failed operation",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(
+					&types.StackSet{StackSetName: aws.String("test"), PermissionModel: types.PermissionModelsSelfManaged}, nil,
+				)
+				m.EXPECT().ListStackInstances(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(selfManagedInstances, nil)
+				m.EXPECT().DeleteStackInstances(gomock.Any(), aws.String("test"), gomock.Any(), gomock.Any(), types.CallAsSelf).Return(
+					&client.StackSetOperationResult{Status: types.StackSetOperationStatusFailed, StatusReason: "Reason"}, nil,
+				)
+				m.EXPECT().ListStackInstances(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(failedInstances, nil)
+			},
+			want: fmt.Errorf(
+				"StackInstancesDeletionError: test: the operation is FAILED: Reason\nThese are the stack instances remaining:\n%v",
+				*StackInstancesToTableFormat(failedInstances),
+			),
+			wantErr: true,
+		},
+		{
+			name: "delete stack set failure for delete stack instances errors",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(
+					&types.StackSet{StackSetName: aws.String("test"), PermissionModel: types.PermissionModelsSelfManaged}, nil,
+				)
+				m.EXPECT().ListStackInstances(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(selfManagedInstances, nil)
+				m.EXPECT().DeleteStackInstances(gomock.Any(), aws.String("test"), gomock.Any(), gomock.Any(), types.CallAsSelf).Return(
+					nil, fmt.Errorf("DeleteStackInstancesError"),
+				)
+			},
+			want:    fmt.Errorf("DeleteStackInstancesError"),
+			wantErr: true,
+		},
+		{
+			name: "delete stack set failure for describe stack set errors",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(nil, fmt.Errorf("DescribeStackSetError"))
+			},
+			want:    fmt.Errorf("DescribeStackSetError"),
+			wantErr: true,
+		},
+		{
+			name: "delete stack set failure for delete stack set errors",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(
+					&types.StackSet{StackSetName: aws.String("test"), PermissionModel: types.PermissionModelsSelfManaged}, nil,
+				)
+				m.EXPECT().ListStackInstances(gomock.Any(), aws.String("test"), types.CallAsSelf).Return([]types.StackInstanceSummary{}, nil)
+				m.EXPECT().DeleteStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(fmt.Errorf("DeleteStackSetError"))
+			},
+			want:    fmt.Errorf("DeleteStackSetError"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackSetOperator := NewCloudFormationStackSetOperator(cloudformationMock)
+
+			err := cloudformationStackSetOperator.DeleteStackSet(tt.args.ctx, tt.args.stackSetName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackSetOperator_ListStackSetInstances(t *testing.T) {
+	io.NewLogger(false)
+
+	stackInstances := []types.StackInstanceSummary{
+		{Account: aws.String("111111111111"), Region: aws.String("us-east-1")},
+	}
+
+	type args struct {
+		ctx          context.Context
+		stackSetName *string
+	}
+
+	type want struct {
+		stackInstances []types.StackInstanceSummary
+		exists         bool
+		err            error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "list stack instances successfully",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(
+					&types.StackSet{StackSetName: aws.String("test"), PermissionModel: types.PermissionModelsSelfManaged}, nil,
+				)
+				m.EXPECT().ListStackInstances(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(stackInstances, nil)
+			},
+			want: want{
+				stackInstances: stackInstances,
+				exists:         true,
+				err:            nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list no stack instances for the stack set not existing",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(nil, nil)
+			},
+			want: want{
+				stackInstances: []types.StackInstanceSummary{},
+				exists:         false,
+				err:            nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stack instances failure",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(
+					&types.StackSet{StackSetName: aws.String("test"), PermissionModel: types.PermissionModelsSelfManaged}, nil,
+				)
+				m.EXPECT().ListStackInstances(gomock.Any(), aws.String("test"), types.CallAsSelf).Return(nil, fmt.Errorf("ListStackInstancesError"))
+			},
+			want: want{
+				stackInstances: nil,
+				exists:         false,
+				err:            fmt.Errorf("ListStackInstancesError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			stackSetOperator := NewCloudFormationStackSetOperator(cloudformationMock)
+
+			stackInstances, exists, err := stackSetOperator.ListStackSetInstances(tt.args.ctx, tt.args.stackSetName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(stackInstances, tt.want.stackInstances) {
+				t.Errorf("stackInstances = %#v, want %#v", stackInstances, tt.want.stackInstances)
+			}
+			if exists != tt.want.exists {
+				t.Errorf("exists = %#v, want %#v", exists, tt.want.exists)
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackSetOperator_DeleteResourcesForCloudFormationStackSet(t *testing.T) {
+	io.NewLogger(false)
+
+	cases := []struct {
+		name                        string
+		ctx                         context.Context
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		wantErr                     bool
+	}{
+		{
+			name: "delete resources successfully",
+			ctx:  context.Background(),
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test:12345678-abcd"), types.CallAsSelf).Return(nil, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete resources failure",
+			ctx:  context.Background(),
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStackSet(gomock.Any(), aws.String("test:12345678-abcd"), types.CallAsSelf).Return(nil, fmt.Errorf("DescribeStackSetError"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackSetOperator := NewCloudFormationStackSetOperator(cloudformationMock)
+			cloudformationStackSetOperator.AddResource(&types.StackResourceSummary{
+				LogicalResourceId:  aws.String("StackSet"),
+				ResourceStatus:     "DELETE_FAILED",
+				ResourceType:       aws.String("AWS::CloudFormation::StackSet"),
+				PhysicalResourceId: aws.String("test:12345678-abcd"),
+			})
+
+			err := cloudformationStackSetOperator.DeleteResources(tt.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_groupStackInstances(t *testing.T) {
+	cases := []struct {
+		name            string
+		stackInstances  []types.StackInstanceSummary
+		permissionModel types.PermissionModels
+		want            []client.StackInstancesTarget
+	}{
+		{
+			name: "group regions with the same accounts",
+			stackInstances: []types.StackInstanceSummary{
+				{Account: aws.String("222222222222"), Region: aws.String("us-west-2")},
+				{Account: aws.String("111111111111"), Region: aws.String("us-east-1")},
+				{Account: aws.String("111111111111"), Region: aws.String("us-west-2")},
+				{Account: aws.String("222222222222"), Region: aws.String("us-east-1")},
+				{Account: aws.String("111111111111"), Region: aws.String("ap-northeast-1")},
+			},
+			permissionModel: types.PermissionModelsSelfManaged,
+			want: []client.StackInstancesTarget{
+				{Accounts: []string{"111111111111"}, Regions: []string{"ap-northeast-1"}},
+				{Accounts: []string{"111111111111", "222222222222"}, Regions: []string{"us-east-1", "us-west-2"}},
+			},
+		},
+		{
+			name: "group regions by organizational units for service-managed stack set",
+			stackInstances: []types.StackInstanceSummary{
+				{Account: aws.String("111111111111"), OrganizationalUnitId: aws.String("ou-xxxx-11111111"), Region: aws.String("us-east-1")},
+				{Account: aws.String("222222222222"), OrganizationalUnitId: aws.String("ou-xxxx-22222222"), Region: aws.String("us-west-2")},
+			},
+			permissionModel: types.PermissionModelsServiceManaged,
+			want: []client.StackInstancesTarget{
+				{OrganizationalUnitIds: []string{"ou-xxxx-11111111"}, Regions: []string{"us-east-1"}},
+				{OrganizationalUnitIds: []string{"ou-xxxx-22222222"}, Regions: []string{"us-west-2"}},
+			},
+		},
+		{
+			name:            "no targets for no stack instances",
+			stackInstances:  []types.StackInstanceSummary{},
+			permissionModel: types.PermissionModelsSelfManaged,
+			want:            []client.StackInstancesTarget{},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := groupStackInstances(tt.stackInstances, tt.permissionModel)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_NewStackSetOperationPreferences(t *testing.T) {
+	type args struct {
+		maxConcurrentPercentage    int
+		failureTolerancePercentage int
+		regionConcurrencyType      string
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    *types.StackSetOperationPreferences
+		wantErr bool
+	}{
+		{
+			name: "parallel preferences for empty region concurrency type",
+			args: args{
+				maxConcurrentPercentage:    100,
+				failureTolerancePercentage: 0,
+				regionConcurrencyType:      "",
+			},
+			want: &types.StackSetOperationPreferences{
+				MaxConcurrentPercentage:    aws.Int32(100),
+				FailureTolerancePercentage: aws.Int32(0),
+				RegionConcurrencyType:      types.RegionConcurrencyTypeParallel,
+			},
+			wantErr: false,
+		},
+		{
+			name: "sequential preferences",
+			args: args{
+				maxConcurrentPercentage:    50,
+				failureTolerancePercentage: 10,
+				regionConcurrencyType:      "sequential",
+			},
+			want: &types.StackSetOperationPreferences{
+				MaxConcurrentPercentage:    aws.Int32(50),
+				FailureTolerancePercentage: aws.Int32(10),
+				RegionConcurrencyType:      types.RegionConcurrencyTypeSequential,
+			},
+			wantErr: false,
+		},
+		{
+			name: "preferences failure for zero max concurrent percentage",
+			args: args{
+				maxConcurrentPercentage:    0,
+				failureTolerancePercentage: 0,
+				regionConcurrencyType:      "",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "preferences failure for too large failure tolerance percentage",
+			args: args{
+				maxConcurrentPercentage:    100,
+				failureTolerancePercentage: 101,
+				regionConcurrencyType:      "",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "preferences failure for unknown region concurrency type",
+			args: args{
+				maxConcurrentPercentage:    100,
+				failureTolerancePercentage: 0,
+				regionConcurrencyType:      "RANDOM",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewStackSetOperationPreferences(tt.args.maxConcurrentPercentage, tt.args.failureTolerancePercentage, tt.args.regionConcurrencyType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ParseCallAs(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   string
+		want    types.CallAs
+		wantErr bool
+	}{
+		{
+			name:    "parse empty value as SELF",
+			value:   "",
+			want:    types.CallAsSelf,
+			wantErr: false,
+		},
+		{
+			name:    "parse DELEGATED_ADMIN",
+			value:   "delegated_admin",
+			want:    types.CallAsDelegatedAdmin,
+			wantErr: false,
+		},
+		{
+			name:    "parse failure for unknown value",
+			value:   "ADMIN",
+			want:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCallAs(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
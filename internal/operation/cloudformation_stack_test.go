@@ -3,12 +3,15 @@ package operation
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/journal"
+	"github.com/go-to-k/delstack/internal/resourcetype"
 	"github.com/go-to-k/delstack/pkg/client"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -21,9 +24,10 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 	io.NewLogger(false)
 
 	type args struct {
-		ctx         context.Context
-		stackName   *string
-		isRootStack bool
+		ctx               context.Context
+		stackName         *string
+		isRootStack       bool
+		stackDeletionMode StackDeletionMode
 	}
 
 	cases := []struct {
@@ -53,12 +57,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
-
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{},
-					nil,
-				)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
 			want:                         nil,
@@ -83,21 +82,49 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
-
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{},
-					nil,
-				)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
 			want:                         nil,
 			wantErr:                      false,
 		},
 		{
-			name: "delete stack failure for root stack for TerminationProtection is enabled stack",
+			name: "skip stack already deleted in the previous run",
 			args: args{
-				ctx:         context.Background(),
+				ctx: newJournalContext(t,
+					journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"},
+					journal.Entry{Step: journal.StepStackDeleted, StackName: "test"},
+				),
+				stackName:   aws.String("test"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn:  func(m *client.MockICloudFormation) {},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
+			want:                         nil,
+			wantErr:                      false,
+		},
+		{
+			name: "skip stack deleted in the background since the previous run",
+			args: args{
+				ctx: newJournalContext(t,
+					journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"},
+				),
+				stackName:   aws.String("test"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return([]types.Stack{}, nil)
+			},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
+			want:                         nil,
+			wantErr:                      false,
+		},
+		{
+			name: "skip stack deleted after waiting for the in-flight deletion since the previous run",
+			args: args{
+				ctx: newJournalContext(t,
+					journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"},
+				),
 				stackName:   aws.String("test"),
 				isRootStack: true,
 			},
@@ -105,43 +132,64 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
 					[]types.Stack{
 						{
-							StackName:                   aws.String("test"),
-							StackStatus:                 "CREATE_COMPLETE",
-							EnableTerminationProtection: aws.Bool(true),
+							StackName:   aws.String("test"),
+							StackStatus: "DELETE_IN_PROGRESS",
 						},
 					},
 					nil,
 				)
+				m.EXPECT().WaitStackOperationComplete(gomock.Any(), aws.String("test")).Return(nil, nil)
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
-			want:                         fmt.Errorf("TerminationProtectionIsEnabled: test"),
-			wantErr:                      true,
+			want:                         nil,
+			wantErr:                      false,
 		},
 		{
-			name: "delete stack failure for child stack for TerminationProtection is enabled stack",
+			name: "skip normal deletion attempted in the previous run",
 			args: args{
-				ctx:         context.Background(),
+				ctx: newJournalContext(t,
+					journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"},
+				),
 				stackName:   aws.String("test"),
-				isRootStack: false,
+				isRootStack: true,
 			},
 			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
 					[]types.Stack{
 						{
-							StackName:                   aws.String("test"),
-							StackStatus:                 "CREATE_COMPLETE",
-							EnableTerminationProtection: aws.Bool(true),
+							StackName:   aws.String("test"),
+							StackStatus: "DELETE_FAILED",
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("LogicalResourceId1"),
+							ResourceStatus:     "DELETE_FAILED",
+							ResourceType:       aws.String("AWS::S3::Bucket"),
+							PhysicalResourceId: aws.String("PhysicalResourceId1"),
 						},
 					},
 					nil,
 				)
+
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"LogicalResourceId1"}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
 			},
-			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
-			want:                         fmt.Errorf("TerminationProtectionIsEnabled: test"),
-			wantErr:                      true,
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
+				m.EXPECT().SetOperatorCollection(aws.String("test"), gomock.Any()).Do(
+					func(stackName *string, stackResourceSummaries []types.StackResourceSummary) {},
+				)
+				m.EXPECT().CheckResourceCounts().Return(nil)
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
+				m.EXPECT().GetLogicalResourceIds().Return([]string{"LogicalResourceId1"})
+			},
+			want:    nil,
+			wantErr: false,
 		},
 		{
-			name: "delete stack failure for root stack for not DELETE_FAILED stack",
+			name: "delete stack failure for root stack for TerminationProtection is enabled stack",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
@@ -152,21 +200,19 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					[]types.Stack{
 						{
 							StackName:                   aws.String("test"),
-							StackStatus:                 "UPDATE_ROLLBACK_COMPLETE",
-							EnableTerminationProtection: aws.Bool(false),
+							StackStatus:                 "CREATE_COMPLETE",
+							EnableTerminationProtection: aws.Bool(true),
 						},
 					},
 					nil,
-				).AnyTimes()
-
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				)
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
-			want:                         fmt.Errorf("StackStatusError: StackStatus is expected to be DELETE_FAILED, but UPDATE_ROLLBACK_COMPLETE: test"),
+			want:                         fmt.Errorf("TerminationProtectionIsEnabled: test"),
 			wantErr:                      true,
 		},
 		{
-			name: "delete stack failure for child stack for not DELETE_FAILED stack",
+			name: "delete stack failure for child stack for TerminationProtection is enabled stack",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
@@ -177,17 +223,15 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					[]types.Stack{
 						{
 							StackName:                   aws.String("test"),
-							StackStatus:                 "UPDATE_ROLLBACK_COMPLETE",
-							EnableTerminationProtection: aws.Bool(false),
+							StackStatus:                 "CREATE_COMPLETE",
+							EnableTerminationProtection: aws.Bool(true),
 						},
 					},
 					nil,
-				).AnyTimes()
-
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				)
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
-			want:                         fmt.Errorf("StackStatusError: StackStatus is expected to be DELETE_FAILED, but UPDATE_ROLLBACK_COMPLETE: test"),
+			want:                         fmt.Errorf("TerminationProtectionIsEnabled: test"),
 			wantErr:                      true,
 		},
 		{
@@ -209,7 +253,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(fmt.Errorf("DeleteStackError"))
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(nil, fmt.Errorf("DeleteStackError"))
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
 			want:                         fmt.Errorf("DeleteStackError"),
@@ -234,7 +278,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(fmt.Errorf("DeleteStackError"))
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(nil, fmt.Errorf("DeleteStackError"))
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
 			want:                         fmt.Errorf("DeleteStackError"),
@@ -308,66 +352,6 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 			want:                         nil,
 			wantErr:                      false,
 		},
-		{
-			name: "delete stack failure for root stack for describe stacks error after delete stack",
-			args: args{
-				ctx:         context.Background(),
-				stackName:   aws.String("test"),
-				isRootStack: true,
-			},
-			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{
-						{
-							StackName:                   aws.String("test"),
-							StackStatus:                 "DELETE_FAILED",
-							EnableTerminationProtection: aws.Bool(false),
-						},
-					},
-					nil,
-				)
-
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
-
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{},
-					fmt.Errorf("DescribeStacksError"),
-				)
-			},
-			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
-			want:                         fmt.Errorf("DescribeStacksError"),
-			wantErr:                      true,
-		},
-		{
-			name: "delete stack failure for child stack for describe stacks error after delete stack",
-			args: args{
-				ctx:         context.Background(),
-				stackName:   aws.String("test"),
-				isRootStack: false,
-			},
-			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{
-						{
-							StackName:                   aws.String("test"),
-							StackStatus:                 "DELETE_FAILED",
-							EnableTerminationProtection: aws.Bool(false),
-						},
-					},
-					nil,
-				)
-
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
-
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{},
-					fmt.Errorf("DescribeStacksError"),
-				)
-			},
-			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
-			want:                         fmt.Errorf("DescribeStacksError"),
-			wantErr:                      true,
-		},
 		{
 			name: "delete stack success for root stack for no resources after delete stack",
 			args: args{
@@ -387,12 +371,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
-
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{},
-					nil,
-				)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
 			want:                         nil,
@@ -417,12 +396,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
-
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{},
-					nil,
-				)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {},
 			want:                         nil,
@@ -447,7 +421,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
 				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
 					[]types.StackResourceSummary{},
@@ -477,7 +451,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
 				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
 					[]types.StackResourceSummary{},
@@ -507,7 +481,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
 				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
 					[]types.StackResourceSummary{
@@ -555,7 +529,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
 				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
 					[]types.StackResourceSummary{
@@ -603,7 +577,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
 				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
 					[]types.StackResourceSummary{
@@ -630,7 +604,23 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 				m.EXPECT().CheckResourceCounts().Return(nil)
 				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(fmt.Errorf("DeleteResourceCollectionError"))
 			},
-			want:    fmt.Errorf("DeleteResourceCollectionError"),
+			want: fmt.Errorf(
+				"ForceDeletionError: test: DeleteResourceCollectionError\nThese are the DELETE_FAILED resources and the reasons:\n%v",
+				*DeleteFailedResourcesToTableFormat("test", []types.StackResourceSummary{
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId1"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+						PhysicalResourceId: aws.String("PhysicalResourceId1"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId2"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::S3::Bucket"),
+						PhysicalResourceId: aws.String("PhysicalResourceId2"),
+					},
+				}),
+			),
 			wantErr: true,
 		},
 		{
@@ -652,7 +642,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
 				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
 					[]types.StackResourceSummary{
@@ -679,7 +669,23 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 				m.EXPECT().CheckResourceCounts().Return(nil)
 				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(fmt.Errorf("DeleteResourceCollectionError"))
 			},
-			want:    fmt.Errorf("DeleteResourceCollectionError"),
+			want: fmt.Errorf(
+				"ForceDeletionError: test: DeleteResourceCollectionError\nThese are the DELETE_FAILED resources and the reasons:\n%v",
+				*DeleteFailedResourcesToTableFormat("test", []types.StackResourceSummary{
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId1"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+						PhysicalResourceId: aws.String("PhysicalResourceId1"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId2"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::S3::Bucket"),
+						PhysicalResourceId: aws.String("PhysicalResourceId2"),
+					},
+				}),
+			),
 			wantErr: true,
 		},
 		{
@@ -701,7 +707,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
 				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
 					[]types.StackResourceSummary{
@@ -721,7 +727,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"LogicalResourceId1", "LogicalResourceId2"}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"LogicalResourceId1", "LogicalResourceId2"}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
 				m.EXPECT().SetOperatorCollection(aws.String("test"), gomock.Any()).Do(
@@ -735,11 +741,11 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "delete stack success for child stack for delete stack at last",
+			name: "delete stack failure for root stack for DELETE_FAILED at last",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
-				isRootStack: false,
+				isRootStack: true,
 			},
 			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
@@ -753,7 +759,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
 				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
 					[]types.StackResourceSummary{
@@ -773,7 +779,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"LogicalResourceId1", "LogicalResourceId2"}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"LogicalResourceId1", "LogicalResourceId2"}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed, StatusReason: "The following resource(s) failed to delete: [LogicalResourceId1]."}, nil)
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
 				m.EXPECT().SetOperatorCollection(aws.String("test"), gomock.Any()).Do(
@@ -783,15 +789,15 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
 				m.EXPECT().GetLogicalResourceIds().Return([]string{"LogicalResourceId1", "LogicalResourceId2"})
 			},
-			want:    nil,
-			wantErr: false,
+			want:    fmt.Errorf("StackDeletionFailedError: test: The following resource(s) failed to delete: [LogicalResourceId1]."),
+			wantErr: true,
 		},
 		{
-			name: "delete stack failure for root stack for delete stack error at last",
+			name: "delete stack success for child stack for delete stack at last",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
-				isRootStack: true,
+				isRootStack: false,
 			},
 			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
@@ -805,7 +811,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
 				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
 					[]types.StackResourceSummary{
@@ -825,7 +831,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"LogicalResourceId1", "LogicalResourceId2"}).Return(fmt.Errorf("DeleteStackError"))
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"LogicalResourceId1", "LogicalResourceId2"}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
 				m.EXPECT().SetOperatorCollection(aws.String("test"), gomock.Any()).Do(
@@ -835,15 +841,15 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
 				m.EXPECT().GetLogicalResourceIds().Return([]string{"LogicalResourceId1", "LogicalResourceId2"})
 			},
-			want:    fmt.Errorf("DeleteStackError"),
-			wantErr: true,
+			want:    nil,
+			wantErr: false,
 		},
 		{
-			name: "delete stack failure for child stack for delete stack error at last",
+			name: "delete stack failure for root stack for delete stack error at last",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
-				isRootStack: false,
+				isRootStack: true,
 			},
 			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
@@ -857,7 +863,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
 				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
 					[]types.StackResourceSummary{
@@ -877,7 +883,7 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"LogicalResourceId1", "LogicalResourceId2"}).Return(fmt.Errorf("DeleteStackError"))
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"LogicalResourceId1", "LogicalResourceId2"}, types.DeletionModeStandard, nil).Return(nil, fmt.Errorf("DeleteStackError"))
 			},
 			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
 				m.EXPECT().SetOperatorCollection(aws.String("test"), gomock.Any()).Do(
@@ -890,68 +896,12 @@ func TestCloudFormationStackOperator_DeleteCloudFormationStack(t *testing.T) {
 			want:    fmt.Errorf("DeleteStackError"),
 			wantErr: true,
 		},
-	}
-
-	for _, tt := range cases {
-		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			cloudformationMock := client.NewMockICloudFormation(ctrl)
-			operatorManagerMock := NewMockIOperatorManager(ctrl)
-
-			tt.prepareMockCloudFormationFn(cloudformationMock)
-			tt.prepareMockOperatorManagerFn(operatorManagerMock)
-
-			targetResourceTypes := []string{
-				"AWS::S3::Bucket",
-				"AWS::IAM::Role",
-				"AWS::ECR::Repository",
-				"AWS::Backup::BackupVault",
-				"AWS::CloudFormation::Stack",
-				"Custom::",
-			}
-
-			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypes)
-
-			err := cloudformationStackOperator.DeleteCloudFormationStack(tt.args.ctx, tt.args.stackName, tt.args.isRootStack, operatorManagerMock)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("error = %#v, wantErr %#v", err.Error(), tt.wantErr)
-				return
-			}
-			if tt.wantErr && err.Error() != tt.want.Error() {
-				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
-				return
-			}
-		})
-	}
-}
-
-func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
-	io.NewLogger(false)
-
-	type args struct {
-		ctx         context.Context
-		stackName   *string
-		isRootStack bool
-	}
-
-	type want struct {
-		got bool
-		err error
-	}
-
-	cases := []struct {
-		name                        string
-		args                        args
-		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
-		want                        want
-		wantErr                     bool
-	}{
 		{
-			name: "delete stack successfully for root stack",
+			name: "delete stack failure for child stack for delete stack error at last",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
-				isRootStack: true,
+				isRootStack: false,
 			},
 			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
@@ -963,33 +913,245 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 						},
 					},
 					nil,
-				)
+				).AnyTimes()
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
+					[]types.StackResourceSummary{
 						{
-							StackName:                   aws.String("test"),
-							StackStatus:                 "DELETE_FAILED",
-							EnableTerminationProtection: aws.Bool(false),
+							LogicalResourceId:  aws.String("LogicalResourceId1"),
+							ResourceStatus:     "DELETE_FAILED",
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							PhysicalResourceId: aws.String("PhysicalResourceId1"),
+						},
+						{
+							LogicalResourceId:  aws.String("LogicalResourceId2"),
+							ResourceStatus:     "DELETE_FAILED",
+							ResourceType:       aws.String("AWS::S3::Bucket"),
+							PhysicalResourceId: aws.String("PhysicalResourceId2"),
 						},
 					},
 					nil,
 				)
+
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{"LogicalResourceId1", "LogicalResourceId2"}, types.DeletionModeStandard, nil).Return(nil, fmt.Errorf("DeleteStackError"))
 			},
-			want: want{
-				got: false,
-				err: nil,
-			},
-			wantErr: false,
-		},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
+				m.EXPECT().SetOperatorCollection(aws.String("test"), gomock.Any()).Do(
+					func(stackName *string, stackResourceSummaries []types.StackResourceSummary) {},
+				)
+				m.EXPECT().CheckResourceCounts().Return(nil)
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
+				m.EXPECT().GetLogicalResourceIds().Return([]string{"LogicalResourceId1", "LogicalResourceId2"})
+			},
+			want:    fmt.Errorf("DeleteStackError"),
+			wantErr: true,
+		},
 		{
-			name: "delete stack successfully for child stack",
+			name: "delete stack successfully by FORCE_DELETE_STACK for force mode",
+			args: args{
+				ctx: newJournalContext(t,
+					journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"},
+				),
+				stackName:         aws.String("test"),
+				isRootStack:       true,
+				stackDeletionMode: StackDeletionModeForce,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("test"),
+							StackStatus: "DELETE_FAILED",
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("LogicalResourceId1"),
+							ResourceStatus:     "DELETE_FAILED",
+							ResourceType:       aws.String("AWS::S3::Bucket"),
+							PhysicalResourceId: aws.String("PhysicalResourceId1"),
+						},
+					},
+					nil,
+				)
+
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeForceDeleteStack, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
+			},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
+				m.EXPECT().SetOperatorCollection(aws.String("test"), gomock.Any()).Do(
+					func(stackName *string, stackResourceSummaries []types.StackResourceSummary) {},
+				)
+				m.EXPECT().CheckResourceCounts().Return(nil)
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
+				m.EXPECT().GetLogicalResourceIds().Return([]string{"LogicalResourceId1"})
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete stack failure for unsupported resources for force mode",
+			args: args{
+				ctx: newJournalContext(t,
+					journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"},
+				),
+				stackName:         aws.String("test"),
+				isRootStack:       true,
+				stackDeletionMode: StackDeletionModeForce,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("test"),
+							StackStatus: "DELETE_FAILED",
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return([]types.StackResourceSummary{}, nil)
+			},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
+				m.EXPECT().SetOperatorCollection(aws.String("test"), gomock.Any()).Do(
+					func(stackName *string, stackResourceSummaries []types.StackResourceSummary) {},
+				)
+				m.EXPECT().CheckResourceCounts().Return(fmt.Errorf("UnsupportedResourceError"))
+			},
+			want:    fmt.Errorf("UnsupportedResourceError"),
+			wantErr: true,
+		},
+		{
+			name: "delete stack successfully abandoning unsupported resources for abandon-unsupported mode",
+			args: args{
+				ctx: newJournalContext(t,
+					journal.Entry{Step: journal.StepNormalDeleteAttempted, StackName: "test"},
+				),
+				stackName:         aws.String("test"),
+				isRootStack:       true,
+				stackDeletionMode: StackDeletionModeAbandonUnsupported,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("test"),
+							StackStatus: "DELETE_FAILED",
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("LogicalResourceId1"),
+							ResourceStatus:     "DELETE_FAILED",
+							ResourceType:       aws.String("AWS::S3::Bucket"),
+							PhysicalResourceId: aws.String("PhysicalResourceId1"),
+						},
+						{
+							LogicalResourceId:  aws.String("LogicalResourceId2"),
+							ResourceStatus:     "DELETE_FAILED",
+							ResourceType:       aws.String("AWS::EC2::SecurityGroup"),
+							PhysicalResourceId: aws.String("PhysicalResourceId2"),
+						},
+					},
+					nil,
+				)
+
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeForceDeleteStack, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
+			},
+			prepareMockOperatorManagerFn: func(m *MockIOperatorManager) {
+				m.EXPECT().SetOperatorCollection(aws.String("test"), gomock.Any()).Do(
+					func(stackName *string, stackResourceSummaries []types.StackResourceSummary) {},
+				)
+				m.EXPECT().GetUnsupportedResources().Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("LogicalResourceId2"),
+							ResourceStatus:     "DELETE_FAILED",
+							ResourceType:       aws.String("AWS::EC2::SecurityGroup"),
+							PhysicalResourceId: aws.String("PhysicalResourceId2"),
+						},
+					},
+				)
+				m.EXPECT().DeleteResourceCollection(gomock.Any()).Return(nil)
+				m.EXPECT().GetLogicalResourceIds().Return([]string{"LogicalResourceId1", "LogicalResourceId2"})
+			},
+			want:    nil,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+			operatorManagerMock := NewMockIOperatorManager(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+			cloudformationMock.EXPECT().DescribeStackEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
+			cloudformationMock.EXPECT().DescribeStackEventsUntil(gomock.Any(), gomock.Any(), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
+			tt.prepareMockOperatorManagerFn(operatorManagerMock)
+
+			targetResourceTypes := []string{
+				"AWS::S3::Bucket",
+				"AWS::IAM::Role",
+				"AWS::ECR::Repository",
+				"AWS::Backup::BackupVault",
+				"AWS::CloudFormation::Stack",
+				"Custom::",
+			}
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypes, client.DefaultStackDeletionTimeout)
+			if tt.args.stackDeletionMode != "" {
+				cloudformationStackOperator.SetStackDeletionMode(tt.args.stackDeletionMode)
+			}
+
+			err := cloudformationStackOperator.DeleteCloudFormationStack(tt.args.ctx, tt.args.stackName, tt.args.isRootStack, operatorManagerMock)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err.Error(), tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
+				return
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx                                 context.Context
+		stackName                           *string
+		isRootStack                         bool
+		terminationProtectionDisabledStacks []string
+	}
+
+	type want struct {
+		got bool
+		err error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "delete stack successfully for root stack",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
-				isRootStack: false,
+				isRootStack: true,
 			},
 			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
@@ -1003,8 +1165,22 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
-
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
+			},
+			want: want{
+				got: false,
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete stack successfully for child stack",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("test"),
+				isRootStack: false,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
 					[]types.Stack{
 						{
@@ -1015,6 +1191,8 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 					},
 					nil,
 				)
+
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusFailed}, nil)
 			},
 			want: want{
 				got: false,
@@ -1048,11 +1226,40 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "delete stack failure for child stack for TerminationProtection is enabled stack",
+			name: "delete stack successfully for root stack for TerminationProtection is disabled by the option",
 			args: args{
-				ctx:         context.Background(),
-				stackName:   aws.String("test"),
-				isRootStack: false,
+				ctx:                                 context.Background(),
+				stackName:                           aws.String("test"),
+				isRootStack:                         true,
+				terminationProtectionDisabledStacks: []string{"test"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("test"),
+							StackStatus:                 "CREATE_COMPLETE",
+							EnableTerminationProtection: aws.Bool(true),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().UpdateTerminationProtection(gomock.Any(), aws.String("test"), false).Return(nil)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
+			},
+			want: want{
+				got: true,
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete stack failure for root stack for TerminationProtection is enabled stack not disabled by the option",
+			args: args{
+				ctx:                                 context.Background(),
+				stackName:                           aws.String("test"),
+				isRootStack:                         true,
+				terminationProtectionDisabledStacks: []string{"other"},
 			},
 			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
@@ -1073,34 +1280,34 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "delete stack failure for root stack for not DELETE_FAILED stack",
+			name: "delete stack failure for root stack for update termination protection errors",
 			args: args{
-				ctx:         context.Background(),
-				stackName:   aws.String("test"),
-				isRootStack: true,
+				ctx:                                 context.Background(),
+				stackName:                           aws.String("test"),
+				isRootStack:                         true,
+				terminationProtectionDisabledStacks: []string{"test"},
 			},
 			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
 					[]types.Stack{
 						{
 							StackName:                   aws.String("test"),
-							StackStatus:                 "UPDATE_ROLLBACK_COMPLETE",
-							EnableTerminationProtection: aws.Bool(false),
+							StackStatus:                 "CREATE_COMPLETE",
+							EnableTerminationProtection: aws.Bool(true),
 						},
 					},
 					nil,
-				).AnyTimes()
-
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				)
+				m.EXPECT().UpdateTerminationProtection(gomock.Any(), aws.String("test"), false).Return(fmt.Errorf("UpdateTerminationProtectionError"))
 			},
 			want: want{
 				got: false,
-				err: fmt.Errorf("StackStatusError: StackStatus is expected to be DELETE_FAILED, but UPDATE_ROLLBACK_COMPLETE: test"),
+				err: fmt.Errorf("UpdateTerminationProtectionError"),
 			},
 			wantErr: true,
 		},
 		{
-			name: "delete stack failure for child stack for not DELETE_FAILED stack",
+			name: "delete stack failure for child stack for TerminationProtection is enabled stack",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
@@ -1111,18 +1318,16 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 					[]types.Stack{
 						{
 							StackName:                   aws.String("test"),
-							StackStatus:                 "UPDATE_ROLLBACK_COMPLETE",
-							EnableTerminationProtection: aws.Bool(false),
+							StackStatus:                 "CREATE_COMPLETE",
+							EnableTerminationProtection: aws.Bool(true),
 						},
 					},
 					nil,
-				).AnyTimes()
-
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				)
 			},
 			want: want{
 				got: false,
-				err: fmt.Errorf("StackStatusError: StackStatus is expected to be DELETE_FAILED, but UPDATE_ROLLBACK_COMPLETE: test"),
+				err: fmt.Errorf("TerminationProtectionIsEnabled: test"),
 			},
 			wantErr: true,
 		},
@@ -1145,7 +1350,7 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(fmt.Errorf("DeleteStackError"))
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(nil, fmt.Errorf("DeleteStackError"))
 			},
 			want: want{
 				got: false,
@@ -1172,7 +1377,7 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(fmt.Errorf("DeleteStackError"))
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(nil, fmt.Errorf("DeleteStackError"))
 			},
 			want: want{
 				got: false,
@@ -1257,7 +1462,7 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "delete stack failure for root stack for describe stacks error after delete stack",
+			name: "delete stack success for root stack for no resources after delete stack",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
@@ -1275,21 +1480,16 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
-
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{},
-					fmt.Errorf("DescribeStacksError"),
-				)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
 			},
 			want: want{
-				got: false,
-				err: fmt.Errorf("DescribeStacksError"),
+				got: true,
+				err: nil,
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "delete stack failure for child stack for describe stacks error after delete stack",
+			name: "delete stack success for child stack for no resources after delete stack",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
@@ -1307,21 +1507,16 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
-
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{},
-					fmt.Errorf("DescribeStacksError"),
-				)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
 			},
 			want: want{
-				got: false,
-				err: fmt.Errorf("DescribeStacksError"),
+				got: true,
+				err: nil,
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "delete stack success for root stack for no resources after delete stack",
+			name: "delete stack success for DELETE_IN_PROGRESS stack after waiting",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
@@ -1332,51 +1527,123 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 					[]types.Stack{
 						{
 							StackName:                   aws.String("test"),
-							StackStatus:                 "DELETE_FAILED",
+							StackStatus:                 "DELETE_IN_PROGRESS",
 							EnableTerminationProtection: aws.Bool(false),
 						},
 					},
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
-
-				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{},
+				m.EXPECT().WaitStackOperationComplete(gomock.Any(), aws.String("test")).Return(nil, nil)
+			},
+			want: want{
+				got: true,
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete stack failure for DELETE_IN_PROGRESS stack that ends in DELETE_FAILED",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("test"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("test"),
+							StackStatus:                 "DELETE_IN_PROGRESS",
+							EnableTerminationProtection: aws.Bool(false),
+						},
+					},
+					nil,
+				)
+
+				m.EXPECT().WaitStackOperationComplete(gomock.Any(), aws.String("test")).Return(
+					&types.Stack{
+						StackName:                   aws.String("test"),
+						StackStatus:                 "DELETE_FAILED",
+						EnableTerminationProtection: aws.Bool(false),
+					},
 					nil,
 				)
 			},
 			want: want{
-				got: true,
+				got: false,
 				err: nil,
 			},
 			wantErr: false,
 		},
 		{
-			name: "delete stack success for child stack for no resources after delete stack",
+			name: "delete stack success for UPDATE_ROLLBACK_IN_PROGRESS stack after waiting",
 			args: args{
 				ctx:         context.Background(),
 				stackName:   aws.String("test"),
-				isRootStack: false,
+				isRootStack: true,
 			},
 			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
 					[]types.Stack{
 						{
 							StackName:                   aws.String("test"),
-							StackStatus:                 "DELETE_FAILED",
+							StackStatus:                 "UPDATE_ROLLBACK_IN_PROGRESS",
 							EnableTerminationProtection: aws.Bool(false),
 						},
 					},
 					nil,
 				)
 
-				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+				m.EXPECT().WaitStackOperationComplete(gomock.Any(), aws.String("test")).Return(
+					&types.Stack{
+						StackName:                   aws.String("test"),
+						StackStatus:                 "UPDATE_ROLLBACK_COMPLETE",
+						EnableTerminationProtection: aws.Bool(false),
+					},
+					nil,
+				)
 
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
+			},
+			want: want{
+				got: true,
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete stack success for UPDATE_ROLLBACK_FAILED stack after continuing the rollback",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("test"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
 				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
-					[]types.Stack{},
+					[]types.Stack{
+						{
+							StackName:                   aws.String("test"),
+							StackStatus:                 "UPDATE_ROLLBACK_FAILED",
+							EnableTerminationProtection: aws.Bool(false),
+						},
+					},
+					nil,
+				)
+
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("test"), gomock.Any()).Return([]types.StackEvent{}, nil)
+				m.EXPECT().ContinueUpdateRollback(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+
+				m.EXPECT().WaitStackOperationComplete(gomock.Any(), aws.String("test")).Return(
+					&types.Stack{
+						StackName:                   aws.String("test"),
+						StackStatus:                 "UPDATE_ROLLBACK_COMPLETE",
+						EnableTerminationProtection: aws.Bool(false),
+					},
 					nil,
 				)
+
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("test"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil)
 			},
 			want: want{
 				got: true,
@@ -1384,6 +1651,188 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "delete stack failure for UPDATE_ROLLBACK_FAILED stack for the rollback failed again",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("test"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("test"),
+							StackStatus:                 "UPDATE_ROLLBACK_FAILED",
+							EnableTerminationProtection: aws.Bool(false),
+						},
+					},
+					nil,
+				)
+
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("test"), gomock.Any()).Return([]types.StackEvent{}, nil)
+				m.EXPECT().ContinueUpdateRollback(gomock.Any(), aws.String("test"), []string{}).Return(nil)
+
+				m.EXPECT().WaitStackOperationComplete(gomock.Any(), aws.String("test")).Return(
+					&types.Stack{
+						StackName:                   aws.String("test"),
+						StackStatus:                 "UPDATE_ROLLBACK_FAILED",
+						StackStatusReason:           aws.String("Reason"),
+						EnableTerminationProtection: aws.Bool(false),
+					},
+					nil,
+				)
+			},
+			want: want{
+				got: false,
+				err: fmt.Errorf("UpdateRollbackFailedError: test: the rollback ended in UPDATE_ROLLBACK_FAILED: Reason"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete stack failure for UPDATE_ROLLBACK_FAILED stack for ContinueUpdateRollback errors",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("test"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("test"),
+							StackStatus:                 "UPDATE_ROLLBACK_FAILED",
+							EnableTerminationProtection: aws.Bool(false),
+						},
+					},
+					nil,
+				)
+
+				m.EXPECT().DescribeStackEventsUntil(gomock.Any(), aws.String("test"), gomock.Any()).Return([]types.StackEvent{}, nil)
+				m.EXPECT().ContinueUpdateRollback(gomock.Any(), aws.String("test"), []string{}).Return(fmt.Errorf("ContinueUpdateRollbackError"))
+			},
+			want: want{
+				got: false,
+				err: fmt.Errorf("ContinueUpdateRollbackError"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete stack failure for exports imported by other stacks",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("test"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("test"),
+							StackStatus:                 "CREATE_COMPLETE",
+							EnableTerminationProtection: aws.Bool(false),
+							Outputs: []types.Output{
+								{
+									OutputKey:  aws.String("Output1"),
+									ExportName: aws.String("Export1"),
+								},
+								{
+									OutputKey: aws.String("Output2"),
+								},
+							},
+						},
+					},
+					nil,
+				)
+
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export1")).Return([]string{"Importer"}, nil)
+			},
+			want: want{
+				got: false,
+				err: fmt.Errorf(
+					"ExportImportedError: test: the exports are imported by other stacks, so delete them first or use --cascade option.\n%v",
+					*StackImportsToTableFormat([]StackImport{
+						{
+							ExportingStackName: "test",
+							ExportName:         "Export1",
+							ImportingStackName: "Importer",
+						},
+					}),
+				),
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete stack failure for exports imported by other stacks without disabling TerminationProtection",
+			args: args{
+				ctx:                                 context.Background(),
+				stackName:                           aws.String("test"),
+				isRootStack:                         true,
+				terminationProtectionDisabledStacks: []string{"test"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("test"),
+							StackStatus:                 "CREATE_COMPLETE",
+							EnableTerminationProtection: aws.Bool(true),
+							Outputs: []types.Output{
+								{
+									OutputKey:  aws.String("Output1"),
+									ExportName: aws.String("Export1"),
+								},
+							},
+						},
+					},
+					nil,
+				)
+
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export1")).Return([]string{"Importer"}, nil)
+				m.EXPECT().UpdateTerminationProtection(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: want{
+				got: false,
+				err: fmt.Errorf(
+					"ExportImportedError: test: the exports are imported by other stacks, so delete them first or use --cascade option.\n%v",
+					*StackImportsToTableFormat([]StackImport{
+						{
+							ExportingStackName: "test",
+							ExportName:         "Export1",
+							ImportingStackName: "Importer",
+						},
+					}),
+				),
+			},
+			wantErr: true,
+		},
+		{
+			name: "delete stack failure for in progress stack for wait errors",
+			args: args{
+				ctx:         context.Background(),
+				stackName:   aws.String("test"),
+				isRootStack: true,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("test"),
+							StackStatus:                 "UPDATE_IN_PROGRESS",
+							EnableTerminationProtection: aws.Bool(false),
+						},
+					},
+					nil,
+				)
+
+				m.EXPECT().WaitStackOperationComplete(gomock.Any(), aws.String("test")).Return(nil, fmt.Errorf("WaitStackOperationCompleteError"))
+			},
+			want: want{
+				got: false,
+				err: fmt.Errorf("WaitStackOperationCompleteError"),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range cases {
@@ -1392,6 +1841,7 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 			cloudformationMock := client.NewMockICloudFormation(ctrl)
 
 			tt.prepareMockCloudFormationFn(cloudformationMock)
+			cloudformationMock.EXPECT().DescribeStackEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
 
 			targetResourceTypes := []string{
 				"AWS::S3::Bucket",
@@ -1401,7 +1851,8 @@ func TestCloudFormationStackOperator_deleteStackNormally(t *testing.T) {
 				"AWS::CloudFormation::Stack",
 				"Custom::",
 			}
-			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypes)
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypes, client.DefaultStackDeletionTimeout)
+			cloudformationStackOperator.SetTerminationProtectionDisabledStacks(tt.args.terminationProtectionDisabledStacks)
 
 			got, err := cloudformationStackOperator.deleteStackNormally(tt.args.ctx, tt.args.stackName, tt.args.isRootStack)
 			if (err != nil) != tt.wantErr {
@@ -1433,17 +1884,26 @@ func TestCloudFormationStackOperator_ListStacksFilteredByKeyword(t *testing.T) {
 		err            error
 	}
 
-	// Except StackStatusDeleteComplete and xxInProgress
+	// Except StackStatusDeleteComplete
 	stackStatusFilter := []types.StackStatus{
+		types.StackStatusCreateInProgress,
 		types.StackStatusCreateFailed,
 		types.StackStatusCreateComplete,
+		types.StackStatusRollbackInProgress,
 		types.StackStatusRollbackFailed,
 		types.StackStatusRollbackComplete,
+		types.StackStatusDeleteInProgress,
 		types.StackStatusDeleteFailed,
+		types.StackStatusUpdateInProgress,
+		types.StackStatusUpdateCompleteCleanupInProgress,
 		types.StackStatusUpdateComplete,
 		types.StackStatusUpdateFailed,
+		types.StackStatusUpdateRollbackInProgress,
 		types.StackStatusUpdateRollbackFailed,
+		types.StackStatusUpdateRollbackCompleteCleanupInProgress,
 		types.StackStatusUpdateRollbackComplete,
+		types.StackStatusReviewInProgress,
+		types.StackStatusImportInProgress,
 		types.StackStatusImportComplete,
 		types.StackStatusImportRollbackInProgress,
 		types.StackStatusImportRollbackFailed,
@@ -1714,7 +2174,7 @@ func TestCloudFormationStackOperator_ListStacksFilteredByKeyword(t *testing.T) {
 				"Custom::",
 			}
 
-			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypes)
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypes, client.DefaultStackDeletionTimeout)
 
 			output, err := cloudformationStackOperator.ListStacksFilteredByKeyword(tt.args.ctx, &tt.args.keyword)
 			if (err != nil) != tt.wantErr {
@@ -1731,3 +2191,1203 @@ func TestCloudFormationStackOperator_ListStacksFilteredByKeyword(t *testing.T) {
 		})
 	}
 }
+
+func TestCloudFormationStackOperator_PlanCloudFormationStack(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx                 context.Context
+		stackName           *string
+		isRootStack         bool
+		targetResourceTypes []string
+	}
+
+	type want struct {
+		stackPlans []StackPlan
+		err        error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "plan stack including nested child stack successfully",
+			args: args{
+				ctx:                 context.Background(),
+				stackName:           aws.String("test"),
+				isRootStack:         true,
+				targetResourceTypes: targetResourceTypesForPartialServices,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("test"),
+							StackStatus:                 "CREATE_COMPLETE",
+							EnableTerminationProtection: aws.Bool(false),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("Bucket"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::S3::Bucket"),
+							PhysicalResourceId: aws.String("bucket"),
+						},
+						{
+							LogicalResourceId:  aws.String("Table"),
+							ResourceStatus:     "DELETE_COMPLETE",
+							ResourceType:       aws.String("AWS::DynamoDB::Table"),
+							PhysicalResourceId: aws.String("table"),
+						},
+						{
+							LogicalResourceId:  aws.String("Child"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+							PhysicalResourceId: aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/child/abc"),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("child")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("child"),
+							StackStatus:                 "DELETE_FAILED",
+							EnableTerminationProtection: aws.Bool(true),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("child")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("Role"),
+							ResourceStatus:     "DELETE_FAILED",
+							ResourceType:       aws.String("AWS::IAM::Role"),
+							PhysicalResourceId: aws.String("role"),
+						},
+					},
+					nil,
+				)
+			},
+			want: want{
+				stackPlans: []StackPlan{
+					{
+						StackName:             "test",
+						StackStatus:           "CREATE_COMPLETE",
+						TerminationProtection: false,
+						ForceDeletionResources: []types.StackResourceSummary{
+							{
+								LogicalResourceId:  aws.String("Bucket"),
+								ResourceStatus:     "CREATE_COMPLETE",
+								ResourceType:       aws.String("AWS::S3::Bucket"),
+								PhysicalResourceId: aws.String("bucket"),
+							},
+						},
+						UnsupportedResources: []types.StackResourceSummary{
+							{
+								LogicalResourceId:  aws.String("Child"),
+								ResourceStatus:     "CREATE_COMPLETE",
+								ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+								PhysicalResourceId: aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/child/abc"),
+							},
+						},
+					},
+					{
+						StackName:             "child",
+						StackStatus:           "DELETE_FAILED",
+						TerminationProtection: true,
+						ForceDeletionResources: []types.StackResourceSummary{
+							{
+								LogicalResourceId:  aws.String("Role"),
+								ResourceStatus:     "DELETE_FAILED",
+								ResourceType:       aws.String("AWS::IAM::Role"),
+								PhysicalResourceId: aws.String("role"),
+							},
+						},
+						UnsupportedResources: []types.StackResourceSummary{},
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "plan stack successfully with the opt-in CloudControl",
+			args: args{
+				ctx:                 context.Background(),
+				stackName:           aws.String("test"),
+				isRootStack:         true,
+				targetResourceTypes: []string{"AWS::S3::Bucket", resourcetype.CloudControl},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("test"),
+							StackStatus:                 "CREATE_COMPLETE",
+							EnableTerminationProtection: aws.Bool(false),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("Table"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::DynamoDB::Table"),
+							PhysicalResourceId: aws.String("table"),
+						},
+						{
+							LogicalResourceId:  aws.String("Role"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::IAM::Role"),
+							PhysicalResourceId: aws.String("role"),
+						},
+					},
+					nil,
+				)
+			},
+			want: want{
+				stackPlans: []StackPlan{
+					{
+						StackName:             "test",
+						StackStatus:           "CREATE_COMPLETE",
+						TerminationProtection: false,
+						ForceDeletionResources: []types.StackResourceSummary{
+							{
+								LogicalResourceId:  aws.String("Table"),
+								ResourceStatus:     "CREATE_COMPLETE",
+								ResourceType:       aws.String("AWS::DynamoDB::Table"),
+								PhysicalResourceId: aws.String("table"),
+							},
+						},
+						UnsupportedResources: []types.StackResourceSummary{
+							{
+								LogicalResourceId:  aws.String("Role"),
+								ResourceStatus:     "CREATE_COMPLETE",
+								ResourceType:       aws.String("AWS::IAM::Role"),
+								PhysicalResourceId: aws.String("role"),
+							},
+						},
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "plan stack failure for root stack not exists",
+			args: args{
+				ctx:                 context.Background(),
+				stackName:           aws.String("test"),
+				isRootStack:         true,
+				targetResourceTypes: targetResourceTypesForAllServices,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{},
+					nil,
+				)
+			},
+			want: want{
+				stackPlans: []StackPlan{},
+				err:        fmt.Errorf("NotExistsError: test stack not found."),
+			},
+			wantErr: true,
+		},
+		{
+			name: "plan stack successfully for child stack already deleted",
+			args: args{
+				ctx:                 context.Background(),
+				stackName:           aws.String("test"),
+				isRootStack:         false,
+				targetResourceTypes: targetResourceTypesForAllServices,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{},
+					nil,
+				)
+			},
+			want: want{
+				stackPlans: []StackPlan{},
+				err:        nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "plan stack failure for list stack resources errors",
+			args: args{
+				ctx:                 context.Background(),
+				stackName:           aws.String("test"),
+				isRootStack:         true,
+				targetResourceTypes: targetResourceTypesForAllServices,
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("test")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("test"),
+							StackStatus: "CREATE_COMPLETE",
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("test")).Return(
+					[]types.StackResourceSummary{},
+					fmt.Errorf("ListStackResourcesError"),
+				)
+			},
+			want: want{
+				stackPlans: []StackPlan{},
+				err:        fmt.Errorf("ListStackResourcesError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, tt.args.targetResourceTypes, client.DefaultStackDeletionTimeout)
+
+			output, err := cloudformationStackOperator.PlanCloudFormationStack(tt.args.ctx, tt.args.stackName, tt.args.isRootStack)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err.Error(), tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(output, tt.want.stackPlans) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.stackPlans)
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackOperator_DeleteCloudFormationStacks(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx        context.Context
+		stackNames []string
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        error
+		wantErr                     bool
+	}{
+		{
+			name: "delete stacks in order of the dependencies successfully",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"exporter", "importer"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("exporter")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("exporter"),
+							StackStatus: "CREATE_COMPLETE",
+							Outputs: []types.Output{
+								{
+									OutputKey:  aws.String("Output"),
+									ExportName: aws.String("Export"),
+								},
+							},
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("importer")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("importer"),
+							StackStatus: "CREATE_COMPLETE",
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export")).Return([]string{"importer", "other"}, nil)
+
+				m.EXPECT().GetTemplate(gomock.Any(), aws.String("exporter")).Return(aws.String("{\"Resources\":{}}"), nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("exporter")).Return([]types.StackResourceSummary{}, nil)
+				m.EXPECT().GetTemplate(gomock.Any(), aws.String("importer")).Return(aws.String("{\"Resources\":{\"Bucket\":{\"Type\":\"AWS::S3::Bucket\",\"DeletionPolicy\":\"Retain\"}}}"), nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("importer")).Return(
+					[]types.StackResourceSummary{
+						{
+							LogicalResourceId:  aws.String("Bucket"),
+							ResourceStatus:     "CREATE_COMPLETE",
+							ResourceType:       aws.String("AWS::S3::Bucket"),
+							PhysicalResourceId: aws.String("PhysicalBucket"),
+						},
+					},
+					nil,
+				)
+
+				for _, stackName := range []string{"exporter", "importer"} {
+					m.EXPECT().DescribeStacks(gomock.Any(), aws.String(stackName)).Return(
+						[]types.Stack{
+							{
+								StackName:                   aws.String(stackName),
+								StackStatus:                 "CREATE_COMPLETE",
+								EnableTerminationProtection: aws.Bool(false),
+							},
+						},
+						nil,
+					)
+				}
+
+				gomock.InOrder(
+					m.EXPECT().DeleteStack(gomock.Any(), aws.String("importer"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil),
+					m.EXPECT().DeleteStack(gomock.Any(), aws.String("exporter"), []string{}, types.DeletionModeStandard, nil).Return(&client.DeleteStackResult{Status: client.StackDeletionStatusDeleted}, nil),
+				)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete stacks failure for importing stack deletion error",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"exporter", "importer"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("exporter")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("exporter"),
+							StackStatus: "CREATE_COMPLETE",
+							Outputs: []types.Output{
+								{
+									OutputKey:  aws.String("Output"),
+									ExportName: aws.String("Export"),
+								},
+							},
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("importer")).Return(
+					[]types.Stack{
+						{
+							StackName:   aws.String("importer"),
+							StackStatus: "CREATE_COMPLETE",
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export")).Return([]string{"importer"}, nil)
+
+				m.EXPECT().GetTemplate(gomock.Any(), aws.String("importer")).Return(aws.String("{\"Resources\":{}}"), nil)
+				m.EXPECT().ListStackResources(gomock.Any(), aws.String("importer")).Return([]types.StackResourceSummary{}, nil)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("importer")).Return(
+					[]types.Stack{
+						{
+							StackName:                   aws.String("importer"),
+							StackStatus:                 "CREATE_COMPLETE",
+							EnableTerminationProtection: aws.Bool(false),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DeleteStack(gomock.Any(), aws.String("importer"), []string{}, types.DeletionModeStandard, nil).Return(nil, fmt.Errorf("DeleteStackError"))
+			},
+			want:    fmt.Errorf("DeleteStackError"),
+			wantErr: true,
+		},
+		{
+			name: "delete stacks failure for get template error",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"test"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().GetTemplate(gomock.Any(), aws.String("test")).Return(nil, fmt.Errorf("GetTemplateError"))
+			},
+			want:    fmt.Errorf("GetTemplateError"),
+			wantErr: true,
+		},
+		{
+			name: "delete stacks failure for describe stacks error",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"exporter", "importer"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("exporter")).Return(
+					[]types.Stack{},
+					fmt.Errorf("DescribeStacksError"),
+				)
+			},
+			want:    fmt.Errorf("DescribeStacksError"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+			cloudformationMock.EXPECT().DescribeStackEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
+			cloudformationMock.EXPECT().DescribeStackEventsUntil(gomock.Any(), gomock.Any(), gomock.Any()).Return([]types.StackEvent{}, nil).AnyTimes()
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypesForAllServices, client.DefaultStackDeletionTimeout)
+
+			err := cloudformationStackOperator.DeleteCloudFormationStacks(tt.args.ctx, tt.args.stackNames)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackOperator_GetStackDependencies(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx        context.Context
+		stackNames []string
+	}
+
+	type want struct {
+		dependencies map[string][]string
+		err          error
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "get stack dependencies successfully",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"stack1", "stack2", "stack3"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("stack1")).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("stack1"),
+							Outputs: []types.Output{
+								{OutputKey: aws.String("Output1"), ExportName: aws.String("Export1")},
+								{OutputKey: aws.String("Output2")},
+							},
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("stack2")).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("stack2"),
+							Outputs: []types.Output{
+								{OutputKey: aws.String("Output3"), ExportName: aws.String("Export3")},
+							},
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("stack3")).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("stack3"),
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export1")).Return([]string{"stack2", "stack3", "outside"}, nil)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export3")).Return([]string{"stack3"}, nil)
+			},
+			want: want{
+				dependencies: map[string][]string{
+					"stack1": {"stack2", "stack3"},
+					"stack2": {"stack3"},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "get stack dependencies failure for circular dependencies",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"stack1", "stack2"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("stack1")).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("stack1"),
+							Outputs: []types.Output{
+								{OutputKey: aws.String("Output1"), ExportName: aws.String("Export1")},
+							},
+						},
+					},
+					nil,
+				)
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("stack2")).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("stack2"),
+							Outputs: []types.Output{
+								{OutputKey: aws.String("Output2"), ExportName: aws.String("Export2")},
+							},
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export1")).Return([]string{"stack2"}, nil)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export2")).Return([]string{"stack1"}, nil)
+			},
+			want: want{
+				dependencies: map[string][]string{
+					"stack1": {"stack2"},
+					"stack2": {"stack1"},
+				},
+				err: fmt.Errorf("CircularDependencyError: the exports and imports of the stacks are circular: stack1"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "get stack dependencies failure for stack not exists",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"stack1", "stack2"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("stack1")).Return(
+					[]types.Stack{},
+					nil,
+				)
+			},
+			want: want{
+				dependencies: map[string][]string{},
+				err:          fmt.Errorf("NotExistsError: stack1 stack not found."),
+			},
+			wantErr: true,
+		},
+		{
+			name: "get stack dependencies failure for list imports errors",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"stack1", "stack2"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), aws.String("stack1")).Return(
+					[]types.Stack{
+						{
+							StackName: aws.String("stack1"),
+							Outputs: []types.Output{
+								{OutputKey: aws.String("Output1"), ExportName: aws.String("Export1")},
+							},
+						},
+					},
+					nil,
+				)
+				m.EXPECT().ListImports(gomock.Any(), aws.String("Export1")).Return([]string{}, fmt.Errorf("ListImportsError"))
+			},
+			want: want{
+				dependencies: map[string][]string{},
+				err:          fmt.Errorf("ListImportsError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypesForAllServices, client.DefaultStackDeletionTimeout)
+
+			output, err := cloudformationStackOperator.GetStackDependencies(tt.args.ctx, tt.args.stackNames)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(output, tt.want.dependencies) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.dependencies)
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackOperator_ListStacksFilteredByPattern(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx     context.Context
+		pattern string
+	}
+
+	type want struct {
+		filteredStacks []string
+		err            error
+	}
+
+	stackSummaries := []types.StackSummary{
+		{
+			StackName:   aws.String("dev-feature-a-api"),
+			StackStatus: types.StackStatusCreateComplete,
+		},
+		{
+			StackName:   aws.String("dev-feature-a-web"),
+			StackStatus: types.StackStatusCreateComplete,
+		},
+		{
+			StackName:   aws.String("dev-feature-a-nested"),
+			StackStatus: types.StackStatusCreateComplete,
+			RootId:      aws.String("test-stack-root"),
+		},
+		{
+			StackName:   aws.String("prd-api"),
+			StackStatus: types.StackStatusCreateComplete,
+		},
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "list stacks filtered by glob pattern successfully",
+			args: args{
+				ctx:     context.Background(),
+				pattern: "dev-*",
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListStacks(gomock.Any(), gomock.Any()).Return(stackSummaries, nil)
+			},
+			want: want{
+				filteredStacks: []string{
+					"dev-feature-a-api",
+					"dev-feature-a-web",
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stacks filtered by regular expression successfully",
+			args: args{
+				ctx:     context.Background(),
+				pattern: "re:-api$",
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListStacks(gomock.Any(), gomock.Any()).Return(stackSummaries, nil)
+			},
+			want: want{
+				filteredStacks: []string{
+					"dev-feature-a-api",
+					"prd-api",
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stacks filtered by glob pattern but no stacks found successfully",
+			args: args{
+				ctx:     context.Background(),
+				pattern: "stg-*",
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListStacks(gomock.Any(), gomock.Any()).Return(stackSummaries, nil)
+			},
+			want: want{
+				filteredStacks: []string{},
+				err:            nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stacks filtered by pattern failure for invalid glob pattern",
+			args: args{
+				ctx:     context.Background(),
+				pattern: "dev-[",
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {},
+			want: want{
+				filteredStacks: []string{},
+				err:            fmt.Errorf("InvalidPatternError: syntax error in pattern: dev-["),
+			},
+			wantErr: true,
+		},
+		{
+			name: "list stacks filtered by pattern failure for invalid regular expression",
+			args: args{
+				ctx:     context.Background(),
+				pattern: "re:dev-(",
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {},
+			want: want{
+				filteredStacks: []string{},
+				err:            fmt.Errorf("InvalidPatternError: error parsing regexp: missing closing ): `dev-(`"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "list stacks filtered by pattern failure for list stacks errors",
+			args: args{
+				ctx:     context.Background(),
+				pattern: "dev-*",
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().ListStacks(gomock.Any(), gomock.Any()).Return([]types.StackSummary{}, fmt.Errorf("ListStacksError"))
+			},
+			want: want{
+				filteredStacks: []string{},
+				err:            fmt.Errorf("ListStacksError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypesForAllServices, client.DefaultStackDeletionTimeout)
+
+			output, err := cloudformationStackOperator.ListStacksFilteredByPattern(tt.args.ctx, tt.args.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(output, tt.want.filteredStacks) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.filteredStacks)
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackOperator_ListStacksFilteredByTags(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx  context.Context
+		tags map[string]string
+	}
+
+	type want struct {
+		filteredStacks []string
+		err            error
+	}
+
+	stacks := []types.Stack{
+		{
+			StackName:   aws.String("TestStack1"),
+			StackStatus: types.StackStatusCreateComplete,
+			Tags: []types.Tag{
+				{Key: aws.String("env"), Value: aws.String("ephemeral")},
+				{Key: aws.String("owner"), Value: aws.String("team-x")},
+			},
+		},
+		{
+			StackName:   aws.String("TestStack2"),
+			StackStatus: types.StackStatusUpdateRollbackComplete,
+			Tags: []types.Tag{
+				{Key: aws.String("env"), Value: aws.String("ephemeral")},
+				{Key: aws.String("owner"), Value: aws.String("team-y")},
+			},
+		},
+		{
+			StackName:   aws.String("TestStack3"),
+			StackStatus: types.StackStatusCreateComplete,
+			RootId:      aws.String("test-stack-root"),
+			Tags: []types.Tag{
+				{Key: aws.String("env"), Value: aws.String("ephemeral")},
+				{Key: aws.String("owner"), Value: aws.String("team-x")},
+			},
+		},
+		{
+			StackName:   aws.String("TestStack4"),
+			StackStatus: types.StackStatusUpdateInProgress,
+			Tags: []types.Tag{
+				{Key: aws.String("env"), Value: aws.String("ephemeral")},
+				{Key: aws.String("owner"), Value: aws.String("team-x")},
+			},
+		},
+		{
+			StackName:   aws.String("TestStack5"),
+			StackStatus: types.StackStatusCreateComplete,
+		},
+		{
+			StackName:   aws.String("TestStack6"),
+			StackStatus: types.StackStatusDeleteComplete,
+			Tags: []types.Tag{
+				{Key: aws.String("env"), Value: aws.String("ephemeral")},
+				{Key: aws.String("owner"), Value: aws.String("team-x")},
+			},
+		},
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "list stacks filtered by a tag successfully",
+			args: args{
+				ctx: context.Background(),
+				tags: map[string]string{
+					"env": "ephemeral",
+				},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+			},
+			want: want{
+				filteredStacks: []string{
+					"TestStack1",
+					"TestStack2",
+					"TestStack4",
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stacks filtered by multiple tags successfully",
+			args: args{
+				ctx: context.Background(),
+				tags: map[string]string{
+					"env":   "ephemeral",
+					"owner": "team-x",
+				},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+			},
+			want: want{
+				filteredStacks: []string{
+					"TestStack1",
+					"TestStack4",
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stacks filtered by tags but no stacks found successfully",
+			args: args{
+				ctx: context.Background(),
+				tags: map[string]string{
+					"env": "production",
+				},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+			},
+			want: want{
+				filteredStacks: []string{},
+				err:            nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stacks filtered by tags failure for describe stacks errors",
+			args: args{
+				ctx: context.Background(),
+				tags: map[string]string{
+					"env": "ephemeral",
+				},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return([]types.Stack{}, fmt.Errorf("DescribeStacksError"))
+			},
+			want: want{
+				filteredStacks: []string{},
+				err:            fmt.Errorf("DescribeStacksError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypesForAllServices, client.DefaultStackDeletionTimeout)
+
+			output, err := cloudformationStackOperator.ListStacksFilteredByTags(tt.args.ctx, tt.args.tags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(output, tt.want.filteredStacks) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.filteredStacks)
+			}
+		})
+	}
+}
+
+func TestCloudFormationStackOperator_ListExistingStacks(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx        context.Context
+		stackNames []string
+	}
+
+	type want struct {
+		existingStacks []string
+		err            error
+	}
+
+	stacks := []types.Stack{
+		{
+			StackName:   aws.String("TestStack1"),
+			StackId:     aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/TestStack1/xxx"),
+			StackStatus: types.StackStatusCreateComplete,
+		},
+		{
+			StackName:   aws.String("TestStack2"),
+			StackId:     aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/TestStack2/xxx"),
+			StackStatus: types.StackStatusDeleteComplete,
+		},
+		{
+			StackName:   aws.String("TestStack3"),
+			StackId:     aws.String("arn:aws:cloudformation:us-east-1:123456789012:stack/TestStack3/xxx"),
+			StackStatus: types.StackStatusUpdateInProgress,
+		},
+	}
+
+	cases := []struct {
+		name                        string
+		args                        args
+		prepareMockCloudFormationFn func(m *client.MockICloudFormation)
+		want                        want
+		wantErr                     bool
+	}{
+		{
+			name: "list existing stacks successfully",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"TestStack3", "TestStack2", "TestStack4", "TestStack1"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+			},
+			want: want{
+				existingStacks: []string{"TestStack3", "TestStack1"},
+				err:            nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list existing stacks successfully for stack ids",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"arn:aws:cloudformation:us-east-1:123456789012:stack/TestStack1/xxx"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(stacks, nil)
+			},
+			want: want{
+				existingStacks: []string{"arn:aws:cloudformation:us-east-1:123456789012:stack/TestStack1/xxx"},
+				err:            nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list existing stacks failure",
+			args: args{
+				ctx:        context.Background(),
+				stackNames: []string{"TestStack1"},
+			},
+			prepareMockCloudFormationFn: func(m *client.MockICloudFormation) {
+				m.EXPECT().DescribeStacks(gomock.Any(), nil).Return(nil, fmt.Errorf("DescribeStacksError"))
+			},
+			want: want{
+				existingStacks: []string{},
+				err:            fmt.Errorf("DescribeStacksError"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cloudformationMock := client.NewMockICloudFormation(ctrl)
+
+			tt.prepareMockCloudFormationFn(cloudformationMock)
+
+			cloudformationStackOperator := NewCloudFormationStackOperator(aws.Config{}, cloudformationMock, targetResourceTypesForAllServices, client.DefaultStackDeletionTimeout)
+
+			output, err := cloudformationStackOperator.ListExistingStacks(tt.args.ctx, tt.args.stackNames)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(output, tt.want.existingStacks) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.existingStacks)
+			}
+		})
+	}
+}
+
+func newJournalContext(t *testing.T, entries ...journal.Entry) context.Context {
+	t.Helper()
+
+	j, err := journal.Open(filepath.Join(t.TempDir(), journal.FileName), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { j.Close() })
+
+	for _, entry := range entries {
+		if err := j.Record(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return journal.ContextWithJournal(context.Background(), j)
+}
+
+func Test_ParseStackDeletionMode(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   string
+		want    StackDeletionMode
+		wantErr bool
+	}{
+		{
+			name:    "parse empty value as retain",
+			value:   "",
+			want:    StackDeletionModeRetain,
+			wantErr: false,
+		},
+		{
+			name:    "parse force",
+			value:   "force",
+			want:    StackDeletionModeForce,
+			wantErr: false,
+		},
+		{
+			name:    "parse abandon-unsupported",
+			value:   "abandon-unsupported",
+			want:    StackDeletionModeAbandonUnsupported,
+			wantErr: false,
+		},
+		{
+			name:    "parse failure for unknown value",
+			value:   "FORCE_DELETE_STACK",
+			want:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStackDeletionMode(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_StackNameRuleRegExp(t *testing.T) {
+	cases := []struct {
+		name    string
+		stackId string
+		want    string
+	}{
+		{
+			name:    "extract stack name in aws partition",
+			stackId: "arn:aws:cloudformation:us-east-1:123456789012:stack/child/abc",
+			want:    "child",
+		},
+		{
+			name:    "extract stack name in aws-cn partition",
+			stackId: "arn:aws-cn:cloudformation:cn-north-1:123456789012:stack/child/abc",
+			want:    "child",
+		},
+		{
+			name:    "extract stack name in aws-us-gov partition",
+			stackId: "arn:aws-us-gov:cloudformation:us-gov-west-1:123456789012:stack/child/abc",
+			want:    "child",
+		},
+		{
+			name:    "keep stack name as is",
+			stackId: "child",
+			want:    "child",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := StackNameRuleRegExp.ReplaceAllString(tt.stackId, `$1`)
+			if got != tt.want {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
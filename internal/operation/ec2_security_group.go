@@ -0,0 +1,280 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
+)
+
+var _ IOperator = (*Ec2SecurityGroupOperator)(nil)
+
+type Ec2SecurityGroupOperator struct {
+	client    client.IEc2
+	resources []*types.StackResourceSummary
+}
+
+func NewEc2SecurityGroupOperator(client client.IEc2) *Ec2SecurityGroupOperator {
+	return &Ec2SecurityGroupOperator{
+		client:    client,
+		resources: []*types.StackResourceSummary{},
+	}
+}
+
+func (o *Ec2SecurityGroupOperator) AddResource(resource *types.StackResourceSummary) {
+	o.resources = append(o.resources, resource)
+}
+
+func (o *Ec2SecurityGroupOperator) GetResourcesLength() int {
+	return len(o.resources)
+}
+
+func (o *Ec2SecurityGroupOperator) DeleteResources(ctx context.Context) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	sem := semaphore.NewWeighted(int64(runtime.NumCPU()))
+
+	for _, securityGroup := range o.resources {
+		securityGroup := securityGroup
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return err
+		}
+		eg.Go(func() error {
+			defer sem.Release(1)
+
+			return deleteResourceWithJournal(ctx, securityGroup, func() error {
+				return o.DeleteSecurityGroup(ctx, securityGroup.PhysicalResourceId)
+			})
+		})
+	}
+
+	return eg.Wait()
+}
+
+// DeleteSecurityGroup revokes the rules of the other security groups referencing the security group, deletes the
+// network interfaces using it, and then deletes the security group itself. Nothing is changed if the network
+// interfaces are attached to the instances not terminated, because the security group cannot be deleted then.
+func (o *Ec2SecurityGroupOperator) DeleteSecurityGroup(ctx context.Context, groupId *string) error {
+	exists, err := o.client.CheckSecurityGroupExists(ctx, groupId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	networkInterfaces, attachmentIds, err := o.listNetworkInterfacesToDelete(ctx, groupId)
+	if err != nil {
+		return err
+	}
+
+	if err := o.revokeReferencingRules(ctx, groupId); err != nil {
+		return err
+	}
+	if err := o.deleteNetworkInterfaces(ctx, groupId, networkInterfaces, attachmentIds); err != nil {
+		return err
+	}
+
+	return o.client.DeleteSecurityGroup(ctx, groupId)
+}
+
+// revokeReferencingRules revokes the rules referencing the security group, grouped by the security groups and the
+// directions of the rules. The rules are displayed before being revoked since they are outside the stack.
+func (o *Ec2SecurityGroupOperator) revokeReferencingRules(ctx context.Context, groupId *string) error {
+	rules, err := o.client.DescribeReferencingSecurityGroupRules(ctx, groupId)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	io.LoggerFromContext(ctx).Info().Msgf(
+		"Revoke the rules referencing %v in the other security groups\n%v",
+		*groupId,
+		*SecurityGroupRulesToTableFormat(rules),
+	)
+
+	type revokeTarget struct {
+		groupId  string
+		isEgress bool
+	}
+	targets := []revokeTarget{}
+	ruleIds := map[revokeTarget][]string{}
+	for _, rule := range rules {
+		target := revokeTarget{
+			groupId:  aws.ToString(rule.GroupId),
+			isEgress: aws.ToBool(rule.IsEgress),
+		}
+		if _, ok := ruleIds[target]; !ok {
+			targets = append(targets, target)
+		}
+		ruleIds[target] = append(ruleIds[target], aws.ToString(rule.SecurityGroupRuleId))
+	}
+
+	for _, target := range targets {
+		if err := o.client.RevokeSecurityGroupRules(ctx, aws.String(target.groupId), ruleIds[target], target.isEgress); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listNetworkInterfacesToDelete returns the network interfaces using the security group and the attachments of them
+// to be detached. The ones managed by AWS services (e.g. Lambda) cannot be detached, so they are waited for until the
+// services release them. The ones attached to EC2 instances are only detached if the instances are terminated,
+// because the instances may be outside the stack, and the primary ones are released by EC2. It returns
+// SecurityGroupInUseError if any of them are attached to the instances not terminated.
+func (o *Ec2SecurityGroupOperator) listNetworkInterfacesToDelete(
+	ctx context.Context,
+	groupId *string,
+) ([]ec2Types.NetworkInterface, []*string, error) {
+	networkInterfaces, err := o.client.DescribeNetworkInterfaces(ctx, groupId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(networkInterfaces) == 0 {
+		return networkInterfaces, nil, nil
+	}
+
+	inUseNetworkInterfaces := []ec2Types.NetworkInterface{}
+	attachmentIds := []*string{}
+	instanceStates := map[string]ec2Types.InstanceStateName{}
+	for _, networkInterface := range networkInterfaces {
+		if networkInterface.Attachment == nil || aws.ToBool(networkInterface.RequesterManaged) {
+			continue
+		}
+		instanceId := networkInterface.Attachment.InstanceId
+		if instanceId == nil {
+			attachmentIds = append(attachmentIds, networkInterface.Attachment.AttachmentId)
+			continue
+		}
+
+		state, ok := instanceStates[*instanceId]
+		if !ok {
+			state, err = o.client.DescribeInstanceState(ctx, instanceId)
+			if err != nil {
+				return nil, nil, err
+			}
+			instanceStates[*instanceId] = state
+		}
+		if state != ec2Types.InstanceStateNameShuttingDown && state != ec2Types.InstanceStateNameTerminated {
+			inUseNetworkInterfaces = append(inUseNetworkInterfaces, networkInterface)
+			continue
+		}
+		if !isPrimaryNetworkInterface(networkInterface) {
+			attachmentIds = append(attachmentIds, networkInterface.Attachment.AttachmentId)
+		}
+	}
+	if len(inUseNetworkInterfaces) > 0 {
+		errMsg := fmt.Sprintf(
+			"%v: the network interfaces are attached to the instances not terminated, so terminate the instances or detach the network interfaces first\n%v",
+			*groupId,
+			*NetworkInterfacesToTableFormat(inUseNetworkInterfaces),
+		)
+		return nil, nil, fmt.Errorf("SecurityGroupInUseError: %v", errMsg)
+	}
+
+	return networkInterfaces, attachmentIds, nil
+}
+
+// deleteNetworkInterfaces detaches the network interfaces listed by listNetworkInterfacesToDelete, waits until they
+// are available, and deletes them.
+func (o *Ec2SecurityGroupOperator) deleteNetworkInterfaces(
+	ctx context.Context,
+	groupId *string,
+	networkInterfaces []ec2Types.NetworkInterface,
+	attachmentIds []*string,
+) error {
+	if len(networkInterfaces) == 0 {
+		return nil
+	}
+
+	io.LoggerFromContext(ctx).Info().Msgf(
+		"Delete the network interfaces using %v\n%v",
+		*groupId,
+		*NetworkInterfacesToTableFormat(networkInterfaces),
+	)
+
+	for _, attachmentId := range attachmentIds {
+		if err := o.client.DetachNetworkInterface(ctx, attachmentId); err != nil {
+			return err
+		}
+	}
+
+	availableNetworkInterfaces, err := o.client.WaitNetworkInterfacesAvailable(ctx, groupId)
+	if err != nil {
+		return err
+	}
+
+	for _, networkInterface := range availableNetworkInterfaces {
+		if err := o.client.DeleteNetworkInterface(ctx, networkInterface.NetworkInterfaceId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isPrimaryNetworkInterface(networkInterface ec2Types.NetworkInterface) bool {
+	attachment := networkInterface.Attachment
+	return attachment != nil && attachment.InstanceId != nil && aws.ToInt32(attachment.DeviceIndex) == 0
+}
+
+// SecurityGroupRulesToTableFormat returns the rules as a table of the security groups, the rule IDs, the directions,
+// the protocols and the ports.
+func SecurityGroupRulesToTableFormat(rules []ec2Types.SecurityGroupRule) *string {
+	header := []string{"GroupId", "RuleId", "Direction", "Protocol", "Ports"}
+	data := [][]string{}
+	for _, rule := range rules {
+		direction := "Ingress"
+		if aws.ToBool(rule.IsEgress) {
+			direction = "Egress"
+		}
+		protocol := aws.ToString(rule.IpProtocol)
+		ports := fmt.Sprintf("%d-%d", aws.ToInt32(rule.FromPort), aws.ToInt32(rule.ToPort))
+		if protocol == "-1" {
+			protocol = "all"
+			ports = "all"
+		}
+		data = append(data, []string{
+			aws.ToString(rule.GroupId),
+			aws.ToString(rule.SecurityGroupRuleId),
+			direction,
+			protocol,
+			ports,
+		})
+	}
+	return io.ToStringAsTableFormat(header, data)
+}
+
+// NetworkInterfacesToTableFormat returns the network interfaces as a table of the IDs, the types, the statuses and
+// the attachments (instance IDs or requesters).
+func NetworkInterfacesToTableFormat(networkInterfaces []ec2Types.NetworkInterface) *string {
+	header := []string{"NetworkInterfaceId", "InterfaceType", "Status", "AttachedTo"}
+	data := [][]string{}
+	for _, networkInterface := range networkInterfaces {
+		attachedTo := aws.ToString(networkInterface.RequesterId)
+		if networkInterface.Attachment != nil && networkInterface.Attachment.InstanceId != nil {
+			attachedTo = fmt.Sprintf(
+				"%v (device index %d)",
+				aws.ToString(networkInterface.Attachment.InstanceId),
+				aws.ToInt32(networkInterface.Attachment.DeviceIndex),
+			)
+		}
+		data = append(data, []string{
+			aws.ToString(networkInterface.NetworkInterfaceId),
+			string(networkInterface.InterfaceType),
+			string(networkInterface.Status),
+			attachedTo,
+		})
+	}
+	return io.ToStringAsTableFormat(header, data)
+}
@@ -0,0 +1,366 @@
+package operation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	cfnTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
+	gomock "github.com/golang/mock/gomock"
+)
+
+/*
+	Test Cases
+*/
+
+func TestEc2SecurityGroupOperator_DeleteSecurityGroup(t *testing.T) {
+	io.NewLogger(false)
+
+	referencingRules := []ec2Types.SecurityGroupRule{
+		{
+			GroupId:             aws.String("sg-2"),
+			SecurityGroupRuleId: aws.String("sgr-1"),
+			IsEgress:            aws.Bool(false),
+			IpProtocol:          aws.String("tcp"),
+			FromPort:            aws.Int32(443),
+			ToPort:              aws.Int32(443),
+		},
+		{
+			GroupId:             aws.String("sg-2"),
+			SecurityGroupRuleId: aws.String("sgr-2"),
+			IsEgress:            aws.Bool(true),
+			IpProtocol:          aws.String("-1"),
+		},
+		{
+			GroupId:             aws.String("sg-2"),
+			SecurityGroupRuleId: aws.String("sgr-3"),
+			IsEgress:            aws.Bool(false),
+			IpProtocol:          aws.String("tcp"),
+			FromPort:            aws.Int32(80),
+			ToPort:              aws.Int32(80),
+		},
+	}
+	instanceNetworkInterface := ec2Types.NetworkInterface{
+		NetworkInterfaceId: aws.String("eni-1"),
+		Status:             ec2Types.NetworkInterfaceStatusInUse,
+		Attachment: &ec2Types.NetworkInterfaceAttachment{
+			AttachmentId: aws.String("eni-attach-1"),
+			InstanceId:   aws.String("i-1"),
+			DeviceIndex:  aws.Int32(1),
+		},
+	}
+	lambdaNetworkInterface := ec2Types.NetworkInterface{
+		NetworkInterfaceId: aws.String("eni-2"),
+		InterfaceType:      ec2Types.NetworkInterfaceTypeLambda,
+		Status:             ec2Types.NetworkInterfaceStatusInUse,
+		RequesterManaged:   aws.Bool(true),
+		Attachment: &ec2Types.NetworkInterfaceAttachment{
+			AttachmentId: aws.String("eni-attach-2"),
+		},
+	}
+	primaryNetworkInterface := ec2Types.NetworkInterface{
+		NetworkInterfaceId: aws.String("eni-3"),
+		Status:             ec2Types.NetworkInterfaceStatusInUse,
+		Attachment: &ec2Types.NetworkInterfaceAttachment{
+			AttachmentId: aws.String("eni-attach-3"),
+			InstanceId:   aws.String("i-3"),
+			DeviceIndex:  aws.Int32(0),
+		},
+	}
+	availableNetworkInterfaces := []ec2Types.NetworkInterface{
+		{NetworkInterfaceId: aws.String("eni-1"), Status: ec2Types.NetworkInterfaceStatusAvailable},
+		{NetworkInterfaceId: aws.String("eni-2"), Status: ec2Types.NetworkInterfaceStatusAvailable},
+	}
+
+	type args struct {
+		ctx     context.Context
+		groupId *string
+	}
+
+	cases := []struct {
+		name          string
+		args          args
+		prepareMockFn func(m *client.MockIEc2)
+		want          error
+		wantErr       bool
+	}{
+		{
+			name: "delete security group successfully",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(true, nil)
+				m.EXPECT().DescribeReferencingSecurityGroupRules(gomock.Any(), aws.String("sg-1")).Return([]ec2Types.SecurityGroupRule{}, nil)
+				m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), aws.String("sg-1")).Return([]ec2Types.NetworkInterface{}, nil)
+				m.EXPECT().DeleteSecurityGroup(gomock.Any(), aws.String("sg-1")).Return(nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete security group successfully with referencing rules and network interfaces",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(true, nil)
+				m.EXPECT().DescribeReferencingSecurityGroupRules(gomock.Any(), aws.String("sg-1")).Return(referencingRules, nil)
+				m.EXPECT().RevokeSecurityGroupRules(gomock.Any(), aws.String("sg-2"), []string{"sgr-1", "sgr-3"}, false).Return(nil)
+				m.EXPECT().RevokeSecurityGroupRules(gomock.Any(), aws.String("sg-2"), []string{"sgr-2"}, true).Return(nil)
+				m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), aws.String("sg-1")).Return(
+					[]ec2Types.NetworkInterface{instanceNetworkInterface, lambdaNetworkInterface}, nil,
+				)
+				m.EXPECT().DescribeInstanceState(gomock.Any(), aws.String("i-1")).Return(ec2Types.InstanceStateNameTerminated, nil)
+				m.EXPECT().DetachNetworkInterface(gomock.Any(), aws.String("eni-attach-1")).Return(nil)
+				m.EXPECT().WaitNetworkInterfacesAvailable(gomock.Any(), aws.String("sg-1")).Return(availableNetworkInterfaces, nil)
+				m.EXPECT().DeleteNetworkInterface(gomock.Any(), aws.String("eni-1")).Return(nil)
+				m.EXPECT().DeleteNetworkInterface(gomock.Any(), aws.String("eni-2")).Return(nil)
+				m.EXPECT().DeleteSecurityGroup(gomock.Any(), aws.String("sg-1")).Return(nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete security group successfully for security group not exists",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(false, nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete security group failure for check security group exists errors",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(false, fmt.Errorf("DescribeSecurityGroupsError"))
+			},
+			want:    fmt.Errorf("DescribeSecurityGroupsError"),
+			wantErr: true,
+		},
+		{
+			name: "delete security group failure for revoke security group rules errors",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(true, nil)
+				m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), aws.String("sg-1")).Return([]ec2Types.NetworkInterface{}, nil)
+				m.EXPECT().DescribeReferencingSecurityGroupRules(gomock.Any(), aws.String("sg-1")).Return(referencingRules, nil)
+				m.EXPECT().RevokeSecurityGroupRules(gomock.Any(), aws.String("sg-2"), []string{"sgr-1", "sgr-3"}, false).Return(fmt.Errorf("RevokeSecurityGroupIngressError"))
+			},
+			want:    fmt.Errorf("RevokeSecurityGroupIngressError"),
+			wantErr: true,
+		},
+		{
+			name: "delete security group successfully without detaching primary network interfaces of terminated instances",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(true, nil)
+				m.EXPECT().DescribeReferencingSecurityGroupRules(gomock.Any(), aws.String("sg-1")).Return([]ec2Types.SecurityGroupRule{}, nil)
+				m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), aws.String("sg-1")).Return(
+					[]ec2Types.NetworkInterface{primaryNetworkInterface}, nil,
+				)
+				m.EXPECT().DescribeInstanceState(gomock.Any(), aws.String("i-3")).Return(ec2Types.InstanceStateNameShuttingDown, nil)
+				m.EXPECT().WaitNetworkInterfacesAvailable(gomock.Any(), aws.String("sg-1")).Return([]ec2Types.NetworkInterface{}, nil)
+				m.EXPECT().DeleteSecurityGroup(gomock.Any(), aws.String("sg-1")).Return(nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete security group failure for network interfaces of instances not terminated",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(true, nil)
+				m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), aws.String("sg-1")).Return(
+					[]ec2Types.NetworkInterface{instanceNetworkInterface, lambdaNetworkInterface, primaryNetworkInterface}, nil,
+				)
+				m.EXPECT().DescribeInstanceState(gomock.Any(), aws.String("i-1")).Return(ec2Types.InstanceStateNameRunning, nil)
+				m.EXPECT().DescribeInstanceState(gomock.Any(), aws.String("i-3")).Return(ec2Types.InstanceStateNameStopped, nil)
+			},
+			want: fmt.Errorf(
+				"SecurityGroupInUseError: sg-1: the network interfaces are attached to the instances not terminated, so terminate the instances or detach the network interfaces first\n%v",
+				*NetworkInterfacesToTableFormat([]ec2Types.NetworkInterface{instanceNetworkInterface, primaryNetworkInterface}),
+			),
+			wantErr: true,
+		},
+		{
+			name: "delete security group failure without revoking referencing rules for network interfaces of instances not terminated",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(true, nil)
+				m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), aws.String("sg-1")).Return(
+					[]ec2Types.NetworkInterface{instanceNetworkInterface}, nil,
+				)
+				m.EXPECT().DescribeInstanceState(gomock.Any(), aws.String("i-1")).Return(ec2Types.InstanceStateNameRunning, nil)
+				m.EXPECT().DescribeReferencingSecurityGroupRules(gomock.Any(), gomock.Any()).Return(referencingRules, nil).Times(0)
+				m.EXPECT().RevokeSecurityGroupRules(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			want: fmt.Errorf(
+				"SecurityGroupInUseError: sg-1: the network interfaces are attached to the instances not terminated, so terminate the instances or detach the network interfaces first\n%v",
+				*NetworkInterfacesToTableFormat([]ec2Types.NetworkInterface{instanceNetworkInterface}),
+			),
+			wantErr: true,
+		},
+		{
+			name: "delete security group failure for describe instance state errors",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(true, nil)
+				m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), aws.String("sg-1")).Return(
+					[]ec2Types.NetworkInterface{instanceNetworkInterface}, nil,
+				)
+				m.EXPECT().DescribeInstanceState(gomock.Any(), aws.String("i-1")).Return(ec2Types.InstanceStateName(""), fmt.Errorf("DescribeInstancesError"))
+			},
+			want:    fmt.Errorf("DescribeInstancesError"),
+			wantErr: true,
+		},
+		{
+			name: "delete security group failure for wait network interfaces available errors",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(true, nil)
+				m.EXPECT().DescribeReferencingSecurityGroupRules(gomock.Any(), aws.String("sg-1")).Return([]ec2Types.SecurityGroupRule{}, nil)
+				m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), aws.String("sg-1")).Return(
+					[]ec2Types.NetworkInterface{lambdaNetworkInterface}, nil,
+				)
+				m.EXPECT().WaitNetworkInterfacesAvailable(gomock.Any(), aws.String("sg-1")).Return(nil, fmt.Errorf("ResourceDeletionTimeoutError"))
+			},
+			want:    fmt.Errorf("ResourceDeletionTimeoutError"),
+			wantErr: true,
+		},
+		{
+			name: "delete security group failure",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("sg-1")).Return(true, nil)
+				m.EXPECT().DescribeReferencingSecurityGroupRules(gomock.Any(), aws.String("sg-1")).Return([]ec2Types.SecurityGroupRule{}, nil)
+				m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), aws.String("sg-1")).Return([]ec2Types.NetworkInterface{}, nil)
+				m.EXPECT().DeleteSecurityGroup(gomock.Any(), aws.String("sg-1")).Return(fmt.Errorf("DeleteSecurityGroupError"))
+			},
+			want:    fmt.Errorf("DeleteSecurityGroupError"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ec2Mock := client.NewMockIEc2(ctrl)
+			tt.prepareMockFn(ec2Mock)
+
+			ec2SecurityGroupOperator := NewEc2SecurityGroupOperator(ec2Mock)
+
+			err := ec2SecurityGroupOperator.DeleteSecurityGroup(tt.args.ctx, tt.args.groupId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
+				return
+			}
+		})
+	}
+}
+
+func TestEc2SecurityGroupOperator_DeleteResourcesForEc2SecurityGroup(t *testing.T) {
+	io.NewLogger(false)
+
+	type args struct {
+		ctx context.Context
+	}
+
+	cases := []struct {
+		name          string
+		args          args
+		prepareMockFn func(m *client.MockIEc2)
+		want          error
+		wantErr       bool
+	}{
+		{
+			name: "delete resources successfully",
+			args: args{
+				ctx: context.Background(),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("PhysicalResourceId1")).Return(true, nil)
+				m.EXPECT().DescribeReferencingSecurityGroupRules(gomock.Any(), aws.String("PhysicalResourceId1")).Return([]ec2Types.SecurityGroupRule{}, nil)
+				m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), aws.String("PhysicalResourceId1")).Return([]ec2Types.NetworkInterface{}, nil)
+				m.EXPECT().DeleteSecurityGroup(gomock.Any(), aws.String("PhysicalResourceId1")).Return(nil)
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "delete resources failure",
+			args: args{
+				ctx: context.Background(),
+			},
+			prepareMockFn: func(m *client.MockIEc2) {
+				m.EXPECT().CheckSecurityGroupExists(gomock.Any(), aws.String("PhysicalResourceId1")).Return(false, fmt.Errorf("DescribeSecurityGroupsError"))
+			},
+			want:    fmt.Errorf("DescribeSecurityGroupsError"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ec2Mock := client.NewMockIEc2(ctrl)
+			tt.prepareMockFn(ec2Mock)
+
+			ec2SecurityGroupOperator := NewEc2SecurityGroupOperator(ec2Mock)
+			ec2SecurityGroupOperator.AddResource(&cfnTypes.StackResourceSummary{
+				LogicalResourceId:  aws.String("LogicalResourceId1"),
+				ResourceStatus:     "DELETE_FAILED",
+				ResourceType:       aws.String("AWS::EC2::SecurityGroup"),
+				PhysicalResourceId: aws.String("PhysicalResourceId1"),
+			})
+
+			err := ec2SecurityGroupOperator.DeleteResources(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
+				return
+			}
+		})
+	}
+}
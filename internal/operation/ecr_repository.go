@@ -44,7 +44,9 @@ func (o *EcrRepositoryOperator) DeleteResources(ctx context.Context) error {
 		eg.Go(func() (err error) {
 			defer sem.Release(1)
 
-			return o.DeleteEcrRepository(ctx, repository.PhysicalResourceId)
+			return deleteResourceWithJournal(ctx, repository, func() error {
+				return o.DeleteEcrRepository(ctx, repository.PhysicalResourceId)
+			})
 		})
 	}
 
@@ -44,7 +44,9 @@ func (o *IamRoleOperator) DeleteResources(ctx context.Context) error {
 		eg.Go(func() error {
 			defer sem.Release(1)
 
-			return o.DeleteIamRole(ctx, role.PhysicalResourceId)
+			return deleteResourceWithJournal(ctx, role, func() error {
+				return o.DeleteIamRole(ctx, role.PhysicalResourceId)
+			})
 		})
 	}
 
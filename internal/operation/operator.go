@@ -4,7 +4,9 @@ package operation
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/go-to-k/delstack/internal/journal"
 )
 
 type IOperator interface {
@@ -12,3 +14,24 @@ type IOperator interface {
 	GetResourcesLength() int
 	DeleteResources(ctx context.Context) error
 }
+
+// deleteResourceWithJournal skips the resource already deleted in the previous run, and records the deletion in
+// the journal of the context.
+func deleteResourceWithJournal(ctx context.Context, resource *types.StackResourceSummary, deleteFn func() error) error {
+	entry := journal.Entry{
+		Step:               journal.StepResourceDeleted,
+		ResourceType:       aws.ToString(resource.ResourceType),
+		PhysicalResourceId: aws.ToString(resource.PhysicalResourceId),
+	}
+
+	j := journal.FromContext(ctx)
+	if j.IsDone(entry) {
+		return nil
+	}
+
+	if err := deleteFn(); err != nil {
+		return err
+	}
+
+	return j.Record(entry)
+}
@@ -12,8 +12,12 @@ import (
 )
 
 type IOperatorCollection interface {
-	SetOperatorCollection(stackName *string, stackResourceSummaries []types.StackResourceSummary)
+	// SetOperatorCollection sets the DELETE_FAILED resources of the stack. The stack is given as the path from the
+	// root stack, e.g. Root/Child, to be displayed in the errors. The resources set before are replaced, so that
+	// the collection can be set again for the next pass of the deletion.
+	SetOperatorCollection(stackPath *string, stackResourceSummaries []types.StackResourceSummary)
 	GetLogicalResourceIds() []string
+	GetUnsupportedResources() []types.StackResourceSummary
 	GetOperators() []IOperator
 	RaiseUnsupportedResourceError() error
 }
@@ -21,7 +25,7 @@ type IOperatorCollection interface {
 var _ IOperatorCollection = (*OperatorCollection)(nil)
 
 type OperatorCollection struct {
-	stackName                 string
+	stackPath                 string
 	operatorFactory           *OperatorFactory
 	logicalResourceIds        []string
 	unsupportedStackResources []types.StackResourceSummary
@@ -36,22 +40,30 @@ func NewOperatorCollection(config aws.Config, operatorFactory *OperatorFactory,
 	}
 }
 
-func (c *OperatorCollection) SetOperatorCollection(stackName *string, stackResourceSummaries []types.StackResourceSummary) {
-	c.stackName = aws.ToString(stackName)
+func (c *OperatorCollection) SetOperatorCollection(stackPath *string, stackResourceSummaries []types.StackResourceSummary) {
+	c.stackPath = aws.ToString(stackPath)
+	c.logicalResourceIds = nil
+	c.unsupportedStackResources = nil
+	c.operators = nil
 
 	s3BucketOperator := c.operatorFactory.CreateS3BucketOperator()
 	iamRoleOperator := c.operatorFactory.CreateIamRoleOperator()
 	ecrRepositoryOperator := c.operatorFactory.CreateEcrRepositoryOperator()
 	backupVaultOperator := c.operatorFactory.CreateBackupVaultOperator()
+	ec2SecurityGroupOperator := c.operatorFactory.CreateEc2SecurityGroupOperator()
 	cloudformationStackOperator := c.operatorFactory.CreateCloudFormationStackOperator(c.targetResourceTypes)
+	cloudformationStackSetOperator := c.operatorFactory.CreateCloudFormationStackSetOperator()
 	customOperator := c.operatorFactory.CreateCustomOperator()
+	cloudControlOperator := c.operatorFactory.CreateCloudControlOperator()
 
 	for _, v := range stackResourceSummaries {
 		if v.ResourceStatus == "DELETE_FAILED" {
 			stackResource := v // Copy for pointer used below
 			c.logicalResourceIds = append(c.logicalResourceIds, aws.ToString(stackResource.LogicalResourceId))
 
-			if !c.containsResourceType(*stackResource.ResourceType) {
+			if c.usesCloudControl(*stackResource.ResourceType) {
+				cloudControlOperator.AddResource(&stackResource)
+			} else if !c.containsResourceType(*stackResource.ResourceType) {
 				c.unsupportedStackResources = append(c.unsupportedStackResources, stackResource)
 			} else {
 				switch *stackResource.ResourceType {
@@ -63,8 +75,12 @@ func (c *OperatorCollection) SetOperatorCollection(stackName *string, stackResou
 					ecrRepositoryOperator.AddResource(&stackResource)
 				case resourcetype.BackupVault:
 					backupVaultOperator.AddResource(&stackResource)
+				case resourcetype.Ec2SecurityGroup:
+					ec2SecurityGroupOperator.AddResource(&stackResource)
 				case resourcetype.CloudformationStack:
 					cloudformationStackOperator.AddResource(&stackResource)
+				case resourcetype.CloudformationStackSet:
+					cloudformationStackSetOperator.AddResource(&stackResource)
 				default:
 					if strings.Contains(*stackResource.ResourceType, resourcetype.CustomResource) {
 						customOperator.AddResource(&stackResource)
@@ -78,8 +94,11 @@ func (c *OperatorCollection) SetOperatorCollection(stackName *string, stackResou
 	c.operators = append(c.operators, iamRoleOperator)
 	c.operators = append(c.operators, ecrRepositoryOperator)
 	c.operators = append(c.operators, backupVaultOperator)
+	c.operators = append(c.operators, ec2SecurityGroupOperator)
 	c.operators = append(c.operators, cloudformationStackOperator)
+	c.operators = append(c.operators, cloudformationStackSetOperator)
 	c.operators = append(c.operators, customOperator)
+	c.operators = append(c.operators, cloudControlOperator)
 }
 
 func (c *OperatorCollection) containsResourceType(resource string) bool {
@@ -91,24 +110,74 @@ func (c *OperatorCollection) containsResourceType(resource string) bool {
 	return false
 }
 
+// usesCloudControl returns whether the resources of the type are deleted by Cloud Control API, which is the case
+// only if the CloudControl type is selected and the type has no dedicated operator. The types with dedicated
+// operators but not selected are not deleted by Cloud Control either.
+func (c *OperatorCollection) usesCloudControl(resourceType string) bool {
+	if !c.containsResourceType(resourcetype.CloudControl) {
+		return false
+	}
+	if strings.Contains(resourceType, resourcetype.CustomResource) {
+		return false
+	}
+	for _, t := range resourcetype.GetResourceTypes() {
+		if t == resourceType {
+			return false
+		}
+	}
+	return true
+}
+
+// getOperatorName returns the name of the operator which force deletes the resources of the type if they become
+// DELETE_FAILED, or an empty string if the type is unsupported or not selected.
+func (c *OperatorCollection) getOperatorName(resourceType string) string {
+	if c.usesCloudControl(resourceType) {
+		return "CloudControlOperator"
+	}
+	if !c.containsResourceType(resourceType) {
+		return ""
+	}
+
+	switch resourceType {
+	case resourcetype.S3Bucket:
+		return "S3BucketOperator"
+	case resourcetype.IamRole:
+		return "IamRoleOperator"
+	case resourcetype.EcrRepository:
+		return "EcrRepositoryOperator"
+	case resourcetype.BackupVault:
+		return "BackupVaultOperator"
+	case resourcetype.Ec2SecurityGroup:
+		return "Ec2SecurityGroupOperator"
+	case resourcetype.CloudformationStack:
+		return "CloudFormationStackOperator"
+	case resourcetype.CloudformationStackSet:
+		return "CloudFormationStackSetOperator"
+	default:
+		if strings.Contains(resourceType, resourcetype.CustomResource) {
+			return "CustomOperator"
+		}
+	}
+	return ""
+}
+
 func (c *OperatorCollection) GetLogicalResourceIds() []string {
 	return c.logicalResourceIds
 }
 
+// GetUnsupportedResources returns the DELETE_FAILED resources of types without operators or not selected.
+func (c *OperatorCollection) GetUnsupportedResources() []types.StackResourceSummary {
+	return c.unsupportedStackResources
+}
+
 func (c *OperatorCollection) GetOperators() []IOperator {
 	return c.operators
 }
 
 func (c *OperatorCollection) RaiseUnsupportedResourceError() error {
-	title := fmt.Sprintf("%v deletion is FAILED !!!\n", c.stackName)
+	title := fmt.Sprintf("%v deletion is FAILED !!!\n", c.stackPath)
 
-	unsupportedStackResourcesHeader := []string{"ResourceType", "Resource"}
-	unsupportedStackResourcesData := [][]string{}
-
-	for _, resource := range c.unsupportedStackResources {
-		unsupportedStackResourcesData = append(unsupportedStackResourcesData, []string{*resource.ResourceType, *resource.LogicalResourceId})
-	}
-	unsupportedStackResources := "\nThese are the resources unsupported (or you did not selected in the interactive prompt), so failed delete:\n" + *io.ToStringAsTableFormat(unsupportedStackResourcesHeader, unsupportedStackResourcesData)
+	unsupportedStackResources := "\nThese are the resources unsupported (or you did not selected in the interactive prompt), so failed delete:\n" + *DeleteFailedResourcesToTableFormat(c.stackPath, c.unsupportedStackResources)
 
 	supportedStackResourcesHeader := []string{"ResourceType", "Description"}
 	supportedStackResourcesData := [][]string{
@@ -116,8 +185,11 @@ func (c *OperatorCollection) RaiseUnsupportedResourceError() error {
 		{resourcetype.IamRole, "IAM Roles, including roles with policies from outside the stack."},
 		{resourcetype.EcrRepository, "ECR Repositories, including repositories containing images."},
 		{resourcetype.BackupVault, "Backup Vaults, including vaults containing recovery points."},
+		{resourcetype.Ec2SecurityGroup, "Security Groups, including groups used by network interfaces or referenced by other groups."},
 		{resourcetype.CloudformationStack, "Nested Child Stacks that failed to delete."},
+		{resourcetype.CloudformationStackSet, "StackSets, including stack instances across accounts and regions."},
 		{"Custom::Xxx", "Custom Resources, but they will be deleted on its own."},
+		{resourcetype.CloudControl, "Resources of the other types via Cloud Control API, only if selected by --include-type."},
 	}
 	supportedStackResources := "\nSupported resources for force deletion of DELETE_FAILED resources are followings.\n" + *io.ToStringAsTableFormat(supportedStackResourcesHeader, supportedStackResourcesData)
 
@@ -61,6 +61,20 @@ func (mr *MockIOperatorCollectionMockRecorder) GetOperators() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperators", reflect.TypeOf((*MockIOperatorCollection)(nil).GetOperators))
 }
 
+// GetUnsupportedResources mocks base method.
+func (m *MockIOperatorCollection) GetUnsupportedResources() []types.StackResourceSummary {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnsupportedResources")
+	ret0, _ := ret[0].([]types.StackResourceSummary)
+	return ret0
+}
+
+// GetUnsupportedResources indicates an expected call of GetUnsupportedResources.
+func (mr *MockIOperatorCollectionMockRecorder) GetUnsupportedResources() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnsupportedResources", reflect.TypeOf((*MockIOperatorCollection)(nil).GetUnsupportedResources))
+}
+
 // RaiseUnsupportedResourceError mocks base method.
 func (m *MockIOperatorCollection) RaiseUnsupportedResourceError() error {
 	m.ctrl.T.Helper()
@@ -76,13 +90,13 @@ func (mr *MockIOperatorCollectionMockRecorder) RaiseUnsupportedResourceError() *
 }
 
 // SetOperatorCollection mocks base method.
-func (m *MockIOperatorCollection) SetOperatorCollection(stackName *string, stackResourceSummaries []types.StackResourceSummary) {
+func (m *MockIOperatorCollection) SetOperatorCollection(stackPath *string, stackResourceSummaries []types.StackResourceSummary) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetOperatorCollection", stackName, stackResourceSummaries)
+	m.ctrl.Call(m, "SetOperatorCollection", stackPath, stackResourceSummaries)
 }
 
 // SetOperatorCollection indicates an expected call of SetOperatorCollection.
-func (mr *MockIOperatorCollectionMockRecorder) SetOperatorCollection(stackName, stackResourceSummaries interface{}) *gomock.Call {
+func (mr *MockIOperatorCollectionMockRecorder) SetOperatorCollection(stackPath, stackResourceSummaries interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOperatorCollection", reflect.TypeOf((*MockIOperatorCollection)(nil).SetOperatorCollection), stackName, stackResourceSummaries)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOperatorCollection", reflect.TypeOf((*MockIOperatorCollection)(nil).SetOperatorCollection), stackPath, stackResourceSummaries)
 }
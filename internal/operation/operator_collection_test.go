@@ -8,6 +8,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/pkg/client"
 )
 
 var targetResourceTypesForAllServices = []string{
@@ -15,7 +16,9 @@ var targetResourceTypesForAllServices = []string{
 	"AWS::IAM::Role",
 	"AWS::ECR::Repository",
 	"AWS::Backup::BackupVault",
+	"AWS::EC2::SecurityGroup",
 	"AWS::CloudFormation::Stack",
+	"AWS::CloudFormation::StackSet",
 	"Custom::",
 }
 
@@ -40,14 +43,17 @@ func TestOperatorCollection_SetOperatorCollection(t *testing.T) {
 	}
 
 	type want struct {
-		logicalResourceIdsLength                   int
-		unsupportedStackResourcesLength            int
-		s3BucketOperatorResourcesLength            int
-		iamRoleOperatorResourcesLength             int
-		ecrRepositoryOperatorResourcesLength       int
-		backupVaultOperatorResourcesLength         int
-		cloudformationStackOperatorResourcesLength int
-		customOperatorResourcesLength              int
+		logicalResourceIdsLength                      int
+		unsupportedStackResourcesLength               int
+		s3BucketOperatorResourcesLength               int
+		iamRoleOperatorResourcesLength                int
+		ecrRepositoryOperatorResourcesLength          int
+		backupVaultOperatorResourcesLength            int
+		ec2SecurityGroupOperatorResourcesLength       int
+		cloudformationStackOperatorResourcesLength    int
+		cloudformationStackSetOperatorResourcesLength int
+		customOperatorResourcesLength                 int
+		cloudControlOperatorResourcesLength           int
 	}
 
 	cases := []struct {
@@ -98,17 +104,31 @@ func TestOperatorCollection_SetOperatorCollection(t *testing.T) {
 						ResourceType:       aws.String("Custom::CustomResource"),
 						PhysicalResourceId: aws.String("PhysicalResourceId6"),
 					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId7"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::CloudFormation::StackSet"),
+						PhysicalResourceId: aws.String("PhysicalResourceId7"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId8"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::EC2::SecurityGroup"),
+						PhysicalResourceId: aws.String("PhysicalResourceId8"),
+					},
 				},
 			},
 			want: want{
-				logicalResourceIdsLength:                   6,
-				unsupportedStackResourcesLength:            0,
-				s3BucketOperatorResourcesLength:            1,
-				iamRoleOperatorResourcesLength:             1,
-				ecrRepositoryOperatorResourcesLength:       1,
-				backupVaultOperatorResourcesLength:         1,
-				cloudformationStackOperatorResourcesLength: 1,
-				customOperatorResourcesLength:              1,
+				logicalResourceIdsLength:                      8,
+				unsupportedStackResourcesLength:               0,
+				s3BucketOperatorResourcesLength:               1,
+				iamRoleOperatorResourcesLength:                1,
+				ecrRepositoryOperatorResourcesLength:          1,
+				backupVaultOperatorResourcesLength:            1,
+				ec2SecurityGroupOperatorResourcesLength:       1,
+				cloudformationStackOperatorResourcesLength:    1,
+				cloudformationStackSetOperatorResourcesLength: 1,
+				customOperatorResourcesLength:                 1,
 			},
 		},
 		{
@@ -1079,12 +1099,53 @@ func TestOperatorCollection_SetOperatorCollection(t *testing.T) {
 				customOperatorResourcesLength:              2,
 			},
 		},
+		{
+			name: "resource counts check 20 for partial target resource types with CloudControl",
+			args: args{
+				ctx:                 context.Background(),
+				stackName:           aws.String("test"),
+				targetResourceTypes: append([]string{"CloudControl"}, targetResourceTypesForPartialServices...),
+				stackResourceSummaries: []types.StackResourceSummary{
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId1"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::S3::Bucket"),
+						PhysicalResourceId: aws.String("PhysicalResourceId1"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId2"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::DynamoDB::Table"),
+						PhysicalResourceId: aws.String("PhysicalResourceId2"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId3"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::ECR::Repository"),
+						PhysicalResourceId: aws.String("PhysicalResourceId3"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId4"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("Custom::Resource"),
+						PhysicalResourceId: aws.String("PhysicalResourceId4"),
+					},
+				},
+			},
+			want: want{
+				logicalResourceIdsLength:            4,
+				unsupportedStackResourcesLength:     1,
+				s3BucketOperatorResourcesLength:     1,
+				customOperatorResourcesLength:       1,
+				cloudControlOperatorResourcesLength: 1,
+			},
+		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config := aws.Config{}
-			operatorFactory := NewOperatorFactory(config)
+			operatorFactory := NewOperatorFactory(config, client.DefaultStackDeletionTimeout, StackDeletionModeRetain, nil, 0)
 			operatorCollection := NewOperatorCollection(config, operatorFactory, tt.args.targetResourceTypes)
 
 			operatorCollection.SetOperatorCollection(tt.args.stackName, tt.args.stackResourceSummaries)
@@ -1093,8 +1154,11 @@ func TestOperatorCollection_SetOperatorCollection(t *testing.T) {
 			iamRoleOperatorResourcesLength := 0
 			ecrRepositoryOperatorResourcesLength := 0
 			backupVaultOperatorResourcesLength := 0
+			ec2SecurityGroupOperatorResourcesLength := 0
 			cloudformationStackOperatorResourcesLength := 0
+			cloudformationStackSetOperatorResourcesLength := 0
 			customOperatorResourcesLength := 0
+			cloudControlOperatorResourcesLength := 0
 
 			for _, operator := range operatorCollection.GetOperators() {
 				switch operator.(type) {
@@ -1106,23 +1170,32 @@ func TestOperatorCollection_SetOperatorCollection(t *testing.T) {
 					ecrRepositoryOperatorResourcesLength += operator.GetResourcesLength()
 				case *BackupVaultOperator:
 					backupVaultOperatorResourcesLength += operator.GetResourcesLength()
+				case *Ec2SecurityGroupOperator:
+					ec2SecurityGroupOperatorResourcesLength += operator.GetResourcesLength()
 				case *CloudFormationStackOperator:
 					cloudformationStackOperatorResourcesLength += operator.GetResourcesLength()
+				case *CloudFormationStackSetOperator:
+					cloudformationStackSetOperatorResourcesLength += operator.GetResourcesLength()
 				case *CustomOperator:
 					customOperatorResourcesLength += operator.GetResourcesLength()
+				case *CloudControlOperator:
+					cloudControlOperatorResourcesLength += operator.GetResourcesLength()
 				default:
 				}
 			}
 
 			got := want{
-				logicalResourceIdsLength:                   len(operatorCollection.logicalResourceIds),
-				unsupportedStackResourcesLength:            len(operatorCollection.unsupportedStackResources),
-				s3BucketOperatorResourcesLength:            s3BucketOperatorResourcesLength,
-				iamRoleOperatorResourcesLength:             iamRoleOperatorResourcesLength,
-				ecrRepositoryOperatorResourcesLength:       ecrRepositoryOperatorResourcesLength,
-				backupVaultOperatorResourcesLength:         backupVaultOperatorResourcesLength,
-				cloudformationStackOperatorResourcesLength: cloudformationStackOperatorResourcesLength,
-				customOperatorResourcesLength:              customOperatorResourcesLength,
+				logicalResourceIdsLength:                      len(operatorCollection.logicalResourceIds),
+				unsupportedStackResourcesLength:               len(operatorCollection.unsupportedStackResources),
+				s3BucketOperatorResourcesLength:               s3BucketOperatorResourcesLength,
+				iamRoleOperatorResourcesLength:                iamRoleOperatorResourcesLength,
+				ecrRepositoryOperatorResourcesLength:          ecrRepositoryOperatorResourcesLength,
+				backupVaultOperatorResourcesLength:            backupVaultOperatorResourcesLength,
+				ec2SecurityGroupOperatorResourcesLength:       ec2SecurityGroupOperatorResourcesLength,
+				cloudformationStackOperatorResourcesLength:    cloudformationStackOperatorResourcesLength,
+				cloudformationStackSetOperatorResourcesLength: cloudformationStackSetOperatorResourcesLength,
+				customOperatorResourcesLength:                 customOperatorResourcesLength,
+				cloudControlOperatorResourcesLength:           cloudControlOperatorResourcesLength,
 			}
 
 			if !reflect.DeepEqual(got, tt.want) {
@@ -1262,7 +1335,7 @@ func TestOperatorCollection_containsResourceType(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			config := aws.Config{}
-			operatorFactory := NewOperatorFactory(config)
+			operatorFactory := NewOperatorFactory(config, client.DefaultStackDeletionTimeout, StackDeletionModeRetain, nil, 0)
 			operatorCollection := NewOperatorCollection(config, operatorFactory, tt.args.targetResourceTypes)
 
 			got := operatorCollection.containsResourceType(tt.args.resource)
@@ -1,24 +1,44 @@
 package operation
 
 import (
+	"time"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ecr"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/go-to-k/delstack/pkg/client"
 )
 
 const SDKRetryMaxAttempts = 3
 
 type OperatorFactory struct {
-	config aws.Config
+	config               aws.Config
+	stackDeletionTimeout time.Duration
+	stackDeletionMode    StackDeletionMode
+	cfnRoleArn           *string
+	maxDeletionPasses    int
 }
 
-func NewOperatorFactory(config aws.Config) *OperatorFactory {
+func NewOperatorFactory(
+	config aws.Config,
+	stackDeletionTimeout time.Duration,
+	stackDeletionMode StackDeletionMode,
+	cfnRoleArn *string,
+	maxDeletionPasses int,
+) *OperatorFactory {
 	return &OperatorFactory{
 		config,
+		stackDeletionTimeout,
+		stackDeletionMode,
+		cfnRoleArn,
+		maxDeletionPasses,
 	}
 }
 
@@ -27,16 +47,61 @@ func (f *OperatorFactory) CreateCloudFormationStackOperator(targetResourceTypes
 		o.RetryMaxAttempts = SDKRetryMaxAttempts
 		o.RetryMode = aws.RetryModeStandard
 	})
-	sdkCfnWaiter := cloudformation.NewStackDeleteCompleteWaiter(sdkCfnClient)
 
-	return NewCloudFormationStackOperator(
+	operator := NewCloudFormationStackOperator(
 		f.config,
 		client.NewCloudFormation(
 			sdkCfnClient,
-			sdkCfnWaiter,
+			f.stackDeletionTimeout,
 		),
 		targetResourceTypes,
+		f.stackDeletionTimeout,
+	)
+	operator.SetStackDeletionMode(f.stackDeletionMode)
+	operator.SetMaxDeletionPasses(f.maxDeletionPasses)
+
+	sdkIamClient := iam.NewFromConfig(f.config, func(o *iam.Options) {
+		o.RetryMaxAttempts = SDKRetryMaxAttempts
+		o.RetryMode = aws.RetryModeStandard
+	})
+	sdkStsClient := sts.NewFromConfig(f.config, func(o *sts.Options) {
+		o.RetryMaxAttempts = SDKRetryMaxAttempts
+		o.RetryMode = aws.RetryModeStandard
+	})
+	operator.SetServiceRole(
+		f.cfnRoleArn,
+		client.NewIam(
+			sdkIamClient,
+		),
+		client.NewSts(
+			sdkStsClient,
+		),
+	)
+
+	return operator
+}
+
+// CreateCloudFormationStackSetOperator returns the operator deleting the StackSets in the stacks as SELF with the
+// default operation preferences of the stackset command.
+func (f *OperatorFactory) CreateCloudFormationStackSetOperator() *CloudFormationStackSetOperator {
+	sdkCfnClient := cloudformation.NewFromConfig(f.config, func(o *cloudformation.Options) {
+		o.RetryMaxAttempts = SDKRetryMaxAttempts
+		o.RetryMode = aws.RetryModeStandard
+	})
+
+	operator := NewCloudFormationStackSetOperator(
+		client.NewCloudFormation(
+			sdkCfnClient,
+			f.stackDeletionTimeout,
+		),
 	)
+	operator.SetOperationPreferences(&types.StackSetOperationPreferences{
+		MaxConcurrentPercentage:    aws.Int32(DefaultMaxConcurrentPercentage),
+		FailureTolerancePercentage: aws.Int32(DefaultFailureTolerancePercentage),
+		RegionConcurrencyType:      types.RegionConcurrencyTypeParallel,
+	})
+
+	return operator
 }
 
 func (f *OperatorFactory) CreateBackupVaultOperator() *BackupVaultOperator {
@@ -52,6 +117,20 @@ func (f *OperatorFactory) CreateBackupVaultOperator() *BackupVaultOperator {
 	)
 }
 
+func (f *OperatorFactory) CreateEc2SecurityGroupOperator() *Ec2SecurityGroupOperator {
+	sdkEc2Client := ec2.NewFromConfig(f.config, func(o *ec2.Options) {
+		o.RetryMaxAttempts = SDKRetryMaxAttempts
+		o.RetryMode = aws.RetryModeStandard
+	})
+
+	return NewEc2SecurityGroupOperator(
+		client.NewEc2(
+			sdkEc2Client,
+			f.stackDeletionTimeout,
+		),
+	)
+}
+
 func (f *OperatorFactory) CreateEcrRepositoryOperator() *EcrRepositoryOperator {
 	sdkEcrClient := ecr.NewFromConfig(f.config, func(o *ecr.Options) {
 		o.RetryMaxAttempts = SDKRetryMaxAttempts
@@ -91,6 +170,20 @@ func (f *OperatorFactory) CreateS3BucketOperator() *S3BucketOperator {
 	)
 }
 
+func (f *OperatorFactory) CreateCloudControlOperator() *CloudControlOperator {
+	sdkCloudControlClient := cloudcontrol.NewFromConfig(f.config, func(o *cloudcontrol.Options) {
+		o.RetryMaxAttempts = SDKRetryMaxAttempts
+		o.RetryMode = aws.RetryModeStandard
+	})
+
+	return NewCloudControlOperator(
+		client.NewCloudControl(
+			sdkCloudControlClient,
+			f.stackDeletionTimeout,
+		),
+	)
+}
+
 func (f *OperatorFactory) CreateCustomOperator() *CustomOperator {
 	return NewCustomOperator() // Implicit instances that do not actually delete resources
 }
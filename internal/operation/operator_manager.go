@@ -9,9 +9,10 @@ import (
 )
 
 type IOperatorManager interface {
-	SetOperatorCollection(stackName *string, stackResourceSummaries []types.StackResourceSummary)
+	SetOperatorCollection(stackPath *string, stackResourceSummaries []types.StackResourceSummary)
 	CheckResourceCounts() error
 	GetLogicalResourceIds() []string
+	GetUnsupportedResources() []types.StackResourceSummary
 	DeleteResourceCollection(ctx context.Context) error
 }
 
@@ -27,8 +28,8 @@ func NewOperatorManager(operatorCollection IOperatorCollection) *OperatorManager
 	}
 }
 
-func (m *OperatorManager) SetOperatorCollection(stackName *string, stackResourceSummaries []types.StackResourceSummary) {
-	m.operatorCollection.SetOperatorCollection(stackName, stackResourceSummaries)
+func (m *OperatorManager) SetOperatorCollection(stackPath *string, stackResourceSummaries []types.StackResourceSummary) {
+	m.operatorCollection.SetOperatorCollection(stackPath, stackResourceSummaries)
 }
 
 func (m *OperatorManager) getOperatorResourcesLength() int {
@@ -54,6 +55,10 @@ func (m *OperatorManager) GetLogicalResourceIds() []string {
 	return m.operatorCollection.GetLogicalResourceIds()
 }
 
+func (m *OperatorManager) GetUnsupportedResources() []types.StackResourceSummary {
+	return m.operatorCollection.GetUnsupportedResources()
+}
+
 func (m *OperatorManager) DeleteResourceCollection(ctx context.Context) error {
 	eg, ctx := errgroup.WithContext(ctx)
 
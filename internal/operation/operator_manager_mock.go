@@ -76,14 +76,28 @@ func (mr *MockIOperatorManagerMockRecorder) GetLogicalResourceIds() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogicalResourceIds", reflect.TypeOf((*MockIOperatorManager)(nil).GetLogicalResourceIds))
 }
 
+// GetUnsupportedResources mocks base method.
+func (m *MockIOperatorManager) GetUnsupportedResources() []types.StackResourceSummary {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnsupportedResources")
+	ret0, _ := ret[0].([]types.StackResourceSummary)
+	return ret0
+}
+
+// GetUnsupportedResources indicates an expected call of GetUnsupportedResources.
+func (mr *MockIOperatorManagerMockRecorder) GetUnsupportedResources() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnsupportedResources", reflect.TypeOf((*MockIOperatorManager)(nil).GetUnsupportedResources))
+}
+
 // SetOperatorCollection mocks base method.
-func (m *MockIOperatorManager) SetOperatorCollection(stackName *string, stackResourceSummaries []types.StackResourceSummary) {
+func (m *MockIOperatorManager) SetOperatorCollection(stackPath *string, stackResourceSummaries []types.StackResourceSummary) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SetOperatorCollection", stackName, stackResourceSummaries)
+	m.ctrl.Call(m, "SetOperatorCollection", stackPath, stackResourceSummaries)
 }
 
 // SetOperatorCollection indicates an expected call of SetOperatorCollection.
-func (mr *MockIOperatorManagerMockRecorder) SetOperatorCollection(stackName, stackResourceSummaries interface{}) *gomock.Call {
+func (mr *MockIOperatorManagerMockRecorder) SetOperatorCollection(stackPath, stackResourceSummaries interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOperatorCollection", reflect.TypeOf((*MockIOperatorManager)(nil).SetOperatorCollection), stackName, stackResourceSummaries)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOperatorCollection", reflect.TypeOf((*MockIOperatorManager)(nil).SetOperatorCollection), stackPath, stackResourceSummaries)
 }
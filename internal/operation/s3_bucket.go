@@ -45,7 +45,9 @@ func (o *S3BucketOperator) DeleteResources(ctx context.Context) error {
 		eg.Go(func() error {
 			defer sem.Release(1)
 
-			return o.DeleteS3Bucket(ctx, bucket.PhysicalResourceId)
+			return deleteResourceWithJournal(ctx, bucket, func() error {
+				return o.DeleteS3Bucket(ctx, bucket.PhysicalResourceId)
+			})
 		})
 	}
 
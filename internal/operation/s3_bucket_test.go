@@ -9,6 +9,7 @@ import (
 	cfnTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/go-to-k/delstack/internal/io"
+	"github.com/go-to-k/delstack/internal/journal"
 	"github.com/go-to-k/delstack/pkg/client"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -249,6 +250,19 @@ func TestS3BucketOperator_DeleteResourcesForS3Bucket(t *testing.T) {
 			want:    nil,
 			wantErr: false,
 		},
+		{
+			name: "skip resources deleted in the previous run",
+			args: args{
+				ctx: newJournalContext(t, journal.Entry{
+					Step:               journal.StepResourceDeleted,
+					ResourceType:       "AWS::S3::Bucket",
+					PhysicalResourceId: "PhysicalResourceId1",
+				}),
+			},
+			prepareMockFn: func(m *client.MockIS3) {},
+			want:          nil,
+			wantErr:       false,
+		},
 		{
 			name: "delete resources failure",
 			args: args{
@@ -1,12 +1,23 @@
 package resourcetype
 
+import (
+	"fmt"
+	"strings"
+)
+
 const (
-	S3Bucket            = "AWS::S3::Bucket"
-	IamRole             = "AWS::IAM::Role"
-	EcrRepository       = "AWS::ECR::Repository"
-	BackupVault         = "AWS::Backup::BackupVault"
-	CloudformationStack = "AWS::CloudFormation::Stack"
-	CustomResource      = "Custom::"
+	S3Bucket               = "AWS::S3::Bucket"
+	IamRole                = "AWS::IAM::Role"
+	EcrRepository          = "AWS::ECR::Repository"
+	BackupVault            = "AWS::Backup::BackupVault"
+	Ec2SecurityGroup       = "AWS::EC2::SecurityGroup"
+	CloudformationStack    = "AWS::CloudFormation::Stack"
+	CloudformationStackSet = "AWS::CloudFormation::StackSet"
+	CustomResource         = "Custom::"
+
+	// CloudControl is not a resource type of CloudFormation, but selects the fallback to delete DELETE_FAILED
+	// resources of the other types by Cloud Control API. It is opt-in, so it is selected only by the include types.
+	CloudControl = "CloudControl"
 )
 
 func GetResourceTypes() []string {
@@ -15,7 +26,84 @@ func GetResourceTypes() []string {
 		IamRole,
 		EcrRepository,
 		BackupVault,
+		Ec2SecurityGroup,
 		CloudformationStack,
+		CloudformationStackSet,
 		CustomResource,
+		CloudControl,
+	}
+}
+
+func isOptIn(resourceType string) bool {
+	return resourceType == CloudControl
+}
+
+// FilterResourceTypes returns the supported resource types narrowed down by includeTypes (all types except the
+// opt-in CloudControl if empty) and excludeTypes. Custom resource types (e.g. Custom::Xxx) are handled as a whole
+// as "Custom::".
+func FilterResourceTypes(includeTypes []string, excludeTypes []string) ([]string, error) {
+	return filterResourceTypes(includeTypes, excludeTypes, false)
+}
+
+// FilterSelectableResourceTypes returns the resource types offered in the interactive mode, which are the ones of
+// FilterResourceTypes and the opt-in CloudControl unless it is excluded, so that it can be selected there.
+func FilterSelectableResourceTypes(includeTypes []string, excludeTypes []string) ([]string, error) {
+	return filterResourceTypes(includeTypes, excludeTypes, true)
+}
+
+func filterResourceTypes(includeTypes []string, excludeTypes []string, withOptIn bool) ([]string, error) {
+	includes, err := normalizeResourceTypes(includeTypes)
+	if err != nil {
+		return nil, err
+	}
+	excludes, err := normalizeResourceTypes(excludeTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTypes := []string{}
+	for _, resourceType := range GetResourceTypes() {
+		if len(includes) > 0 && !includes[resourceType] {
+			continue
+		}
+		if len(includes) == 0 && isOptIn(resourceType) && !withOptIn {
+			continue
+		}
+		if excludes[resourceType] {
+			continue
+		}
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+
+	return resourceTypes, nil
+}
+
+func normalizeResourceTypes(resourceTypes []string) (map[string]bool, error) {
+	normalized := map[string]bool{}
+
+	for _, resourceType := range resourceTypes {
+		resourceType = strings.TrimSpace(resourceType)
+		if resourceType == "" {
+			continue
+		}
+		if strings.HasPrefix(resourceType, CustomResource) {
+			normalized[CustomResource] = true
+			continue
+		}
+		if !isSupported(resourceType) {
+			return nil, fmt.Errorf("InvalidResourceTypeError: %v is not supported, supported types are %v", resourceType, strings.Join(GetResourceTypes(), ", "))
+		}
+		normalized[resourceType] = true
+	}
+
+	return normalized, nil
+}
+
+func isSupported(resourceType string) bool {
+	for _, t := range GetResourceTypes() {
+		if t == resourceType {
+			return true
+		}
 	}
+	return false
 }
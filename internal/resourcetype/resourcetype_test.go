@@ -0,0 +1,174 @@
+package resourcetype
+
+import (
+	"reflect"
+	"testing"
+)
+
+/*
+	Test Cases
+*/
+
+func TestFilterResourceTypes(t *testing.T) {
+	type args struct {
+		includeTypes []string
+		excludeTypes []string
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "all resource types if no include and exclude types",
+			args: args{
+				includeTypes: []string{},
+				excludeTypes: []string{},
+			},
+			want:    []string{S3Bucket, IamRole, EcrRepository, BackupVault, Ec2SecurityGroup, CloudformationStack, CloudformationStackSet, CustomResource},
+			wantErr: false,
+		},
+		{
+			name: "only include types",
+			args: args{
+				includeTypes: []string{EcrRepository, S3Bucket},
+				excludeTypes: []string{},
+			},
+			want:    []string{S3Bucket, EcrRepository},
+			wantErr: false,
+		},
+		{
+			name: "all resource types except exclude types",
+			args: args{
+				includeTypes: []string{},
+				excludeTypes: []string{IamRole, CloudformationStack},
+			},
+			want:    []string{S3Bucket, EcrRepository, BackupVault, Ec2SecurityGroup, CloudformationStackSet, CustomResource},
+			wantErr: false,
+		},
+		{
+			name: "CloudControl only if included",
+			args: args{
+				includeTypes: []string{S3Bucket, CloudControl},
+				excludeTypes: []string{},
+			},
+			want:    []string{S3Bucket, CloudControl},
+			wantErr: false,
+		},
+		{
+			name: "exclude types take precedence over include types",
+			args: args{
+				includeTypes: []string{S3Bucket, IamRole},
+				excludeTypes: []string{IamRole},
+			},
+			want:    []string{S3Bucket},
+			wantErr: false,
+		},
+		{
+			name: "specific custom resource types are handled as Custom::",
+			args: args{
+				includeTypes: []string{"Custom::MyResource", S3Bucket},
+				excludeTypes: []string{},
+			},
+			want:    []string{S3Bucket, CustomResource},
+			wantErr: false,
+		},
+		{
+			name: "unsupported include type",
+			args: args{
+				includeTypes: []string{"AWS::DynamoDB::Table"},
+				excludeTypes: []string{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "unsupported exclude type",
+			args: args{
+				includeTypes: []string{},
+				excludeTypes: []string{"AWS::S3::Buckets"},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterResourceTypes(tt.args.includeTypes, tt.args.excludeTypes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterSelectableResourceTypes(t *testing.T) {
+	type args struct {
+		includeTypes []string
+		excludeTypes []string
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "all resource types including CloudControl if no include and exclude types",
+			args: args{
+				includeTypes: []string{},
+				excludeTypes: []string{},
+			},
+			want:    []string{S3Bucket, IamRole, EcrRepository, BackupVault, Ec2SecurityGroup, CloudformationStack, CloudformationStackSet, CustomResource, CloudControl},
+			wantErr: false,
+		},
+		{
+			name: "only include types",
+			args: args{
+				includeTypes: []string{EcrRepository, S3Bucket},
+				excludeTypes: []string{},
+			},
+			want:    []string{S3Bucket, EcrRepository},
+			wantErr: false,
+		},
+		{
+			name: "all resource types except CloudControl excluded",
+			args: args{
+				includeTypes: []string{},
+				excludeTypes: []string{CloudControl},
+			},
+			want:    []string{S3Bucket, IamRole, EcrRepository, BackupVault, Ec2SecurityGroup, CloudformationStack, CloudformationStackSet, CustomResource},
+			wantErr: false,
+		},
+		{
+			name: "unsupported include type",
+			args: args{
+				includeTypes: []string{"AWS::DynamoDB::Table"},
+				excludeTypes: []string{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterSelectableResourceTypes(tt.args.includeTypes, tt.args.excludeTypes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
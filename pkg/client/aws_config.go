@@ -2,14 +2,36 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
-const DefaultAwsRegion = "us-east-1"
+const (
+	DefaultAwsRegion       = "us-east-1"
+	DefaultRoleSessionName = "delstack"
+)
+
+// defaultRegionsOfPartitions is the default region of each partition used if no region is specified.
+var defaultRegionsOfPartitions = map[string]string{
+	"aws":        DefaultAwsRegion,
+	"aws-cn":     "cn-north-1",
+	"aws-us-gov": "us-gov-west-1",
+}
 
-func LoadAWSConfig(ctx context.Context, region string, profile string) (aws.Config, error) {
+// AssumeRoleOption is the option to assume the role with the base credentials.
+type AssumeRoleOption struct {
+	RoleArn         string
+	ExternalId      string
+	RoleSessionName string
+}
+
+func LoadAWSConfig(ctx context.Context, region string, profile string, assumeRoleOption *AssumeRoleOption) (aws.Config, error) {
 	var (
 		cfg aws.Config
 		err error
@@ -29,8 +51,79 @@ func LoadAWSConfig(ctx context.Context, region string, profile string) (aws.Conf
 		cfg.Region = region
 	}
 	if cfg.Region == "" {
-		cfg.Region = DefaultAwsRegion
+		cfg.Region, err = resolveDefaultRegion(ctx, cfg, assumeRoleOption)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	if assumeRoleOption != nil && assumeRoleOption.RoleArn != "" {
+		provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), assumeRoleOption.RoleArn, func(o *stscreds.AssumeRoleOptions) {
+			if assumeRoleOption.ExternalId != "" {
+				o.ExternalID = aws.String(assumeRoleOption.ExternalId)
+			}
+			o.RoleSessionName = DefaultRoleSessionName
+			if assumeRoleOption.RoleSessionName != "" {
+				o.RoleSessionName = assumeRoleOption.RoleSessionName
+			}
+		})
+		cfg.Credentials = aws.NewCredentialsCache(provider)
 	}
 
 	return cfg, nil
 }
+
+// getDefaultRegion returns the default region of the partition of the role to assume, so that the role in
+// China or GovCloud is assumed by the STS of the partition. It returns DefaultAwsRegion and false if the partition
+// is unknown, e.g. no role is assumed.
+func getDefaultRegion(assumeRoleOption *AssumeRoleOption) (string, bool) {
+	if assumeRoleOption == nil {
+		return DefaultAwsRegion, false
+	}
+
+	parsedArn, err := arn.Parse(assumeRoleOption.RoleArn)
+	if err != nil {
+		return DefaultAwsRegion, false
+	}
+	if region, ok := defaultRegionsOfPartitions[parsedArn.Partition]; ok {
+		return region, true
+	}
+	return DefaultAwsRegion, false
+}
+
+// resolveDefaultRegion returns the default region used if no region is specified. STS is called only if the
+// partition is not known from the role to assume, because the credentials of the profile may be of China or
+// GovCloud, where the stacks are never in DefaultAwsRegion.
+func resolveDefaultRegion(ctx context.Context, cfg aws.Config, assumeRoleOption *AssumeRoleOption) (string, error) {
+	defaultRegion, ok := getDefaultRegion(assumeRoleOption)
+	if ok {
+		return defaultRegion, nil
+	}
+
+	cfg.Region = defaultRegion
+	if err := checkDefaultRegion(ctx, sts.NewFromConfig(cfg)); err != nil {
+		return defaultRegion, err
+	}
+	return defaultRegion, nil
+}
+
+// checkDefaultRegion returns RegionNotFoundError if the credentials are rejected in DefaultAwsRegion, so that the
+// stacks of another partition are not silently searched for in the commercial region. The other errors, e.g.
+// throttling, expired credentials or network errors, are returned as they are.
+func checkDefaultRegion(ctx context.Context, client *sts.Client) error {
+	_, err := client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil && strings.Contains(err.Error(), "InvalidClientTokenId") {
+		errMsg := fmt.Sprintf(
+			"no region is specified and the credentials cannot be used in %v, so specify -r, --region option, e.g. cn-north-1 or us-gov-west-1 for China or GovCloud: %v",
+			DefaultAwsRegion,
+			err,
+		)
+		return fmt.Errorf("RegionNotFoundError: %v", errMsg)
+	}
+	if err != nil {
+		return &ClientError{
+			Err: err,
+		}
+	}
+	return nil
+}
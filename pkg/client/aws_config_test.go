@@ -0,0 +1,252 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+)
+
+/*
+	Test Cases
+*/
+
+func Test_getDefaultRegion(t *testing.T) {
+	cases := []struct {
+		name             string
+		assumeRoleOption *AssumeRoleOption
+		want             string
+		wantOk           bool
+	}{
+		{
+			name:             "default region without role",
+			assumeRoleOption: nil,
+			want:             "us-east-1",
+			wantOk:           false,
+		},
+		{
+			name:             "default region of aws partition",
+			assumeRoleOption: &AssumeRoleOption{RoleArn: "arn:aws:iam::123456789012:role/Role"},
+			want:             "us-east-1",
+			wantOk:           true,
+		},
+		{
+			name:             "default region of aws-cn partition",
+			assumeRoleOption: &AssumeRoleOption{RoleArn: "arn:aws-cn:iam::123456789012:role/Role"},
+			want:             "cn-north-1",
+			wantOk:           true,
+		},
+		{
+			name:             "default region of aws-us-gov partition",
+			assumeRoleOption: &AssumeRoleOption{RoleArn: "arn:aws-us-gov:iam::123456789012:role/Role"},
+			want:             "us-gov-west-1",
+			wantOk:           true,
+		},
+		{
+			name:             "default region of unknown partition",
+			assumeRoleOption: &AssumeRoleOption{RoleArn: "arn:aws-iso:iam::123456789012:role/Role"},
+			want:             "us-east-1",
+			wantOk:           false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := getDefaultRegion(tt.assumeRoleOption)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("got = %#v, %#v, want %#v, %#v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_checkDefaultRegion(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    error
+		wantErr bool
+	}{
+		{
+			name: "check default region successfully for the credentials of the aws partition",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetCallerIdentityMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &sts.GetCallerIdentityOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "check default region failure for the credentials of another partition",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetCallerIdentityErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &sts.GetCallerIdentityOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("api error InvalidClientTokenId: The security token included in the request is invalid.")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    fmt.Errorf("RegionNotFoundError: no region is specified and the credentials cannot be used in us-east-1, so specify -r, --region option, e.g. cn-north-1 or us-gov-west-1 for China or GovCloud: operation error STS: GetCallerIdentity, api error InvalidClientTokenId: The security token included in the request is invalid."),
+			wantErr: true,
+		},
+		{
+			name: "check default region failure for the other errors",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetCallerIdentityErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &sts.GetCallerIdentityOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("api error ExpiredToken: The security token included in the request is expired")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: &ClientError{
+				Err: fmt.Errorf("operation error STS: GetCallerIdentity, api error ExpiredToken: The security token included in the request is expired"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion(DefaultAwsRegion),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = checkDefaultRegion(tt.args.ctx, sts.NewFromConfig(cfg))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.Error())
+			}
+		})
+	}
+}
+
+func Test_resolveDefaultRegion(t *testing.T) {
+	type args struct {
+		ctx              context.Context
+		assumeRoleOption *AssumeRoleOption
+	}
+
+	type want struct {
+		region                 string
+		getCallerIdentityCalls int
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "resolve default region of the partition of the role without STS",
+			args: args{
+				ctx: context.Background(),
+				assumeRoleOption: &AssumeRoleOption{
+					RoleArn: "arn:aws-cn:iam::123456789012:role/test",
+				},
+			},
+			want: want{
+				region:                 "cn-north-1",
+				getCallerIdentityCalls: 0,
+			},
+			wantErr: false,
+		},
+		{
+			name: "resolve default region by STS without role",
+			args: args{
+				ctx:              context.Background(),
+				assumeRoleOption: nil,
+			},
+			want: want{
+				region:                 DefaultAwsRegion,
+				getCallerIdentityCalls: 1,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			getCallerIdentityCalls := 0
+			withAPIOptionsFunc := func(stack *middleware.Stack) error {
+				return stack.Finalize.Add(
+					middleware.FinalizeMiddlewareFunc(
+						"GetCallerIdentityMock",
+						func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+							getCallerIdentityCalls++
+							return middleware.FinalizeOutput{
+								Result: &sts.GetCallerIdentityOutput{},
+							}, middleware.Metadata{}, nil
+						},
+					),
+					middleware.Before,
+				)
+			}
+
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithAPIOptions([]func(*middleware.Stack) error{withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			region, err := resolveDefaultRegion(tt.args.ctx, cfg, tt.args.assumeRoleOption)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if region != tt.want.region {
+				t.Errorf("region = %#v, want %#v", region, tt.want.region)
+			}
+			if getCallerIdentityCalls != tt.want.getCallerIdentityCalls {
+				t.Errorf("getCallerIdentityCalls = %#v, want %#v", getCallerIdentityCalls, tt.want.getCallerIdentityCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+//go:generate mockgen -source=$GOFILE -destination=cloudcontrol_mock.go -package=$GOPACKAGE -write_package_comment=false
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+)
+
+const (
+	ResourceRequestPollingInterval = 5 * time.Second
+	MaxResourceRequestPollingDelay = 60 * time.Second
+)
+
+type ICloudControl interface {
+	DeleteResource(ctx context.Context, typeName *string, identifier *string) (*types.ProgressEvent, error)
+}
+
+var _ ICloudControl = (*CloudControl)(nil)
+
+type CloudControl struct {
+	client          *cloudcontrol.Client
+	deletionTimeout time.Duration
+	pollingInterval time.Duration
+}
+
+// NewCloudControl returns the client waiting for the resource deletion up to deletionTimeout, or
+// DefaultStackDeletionTimeout if it is not positive.
+func NewCloudControl(client *cloudcontrol.Client, deletionTimeout time.Duration) *CloudControl {
+	if deletionTimeout <= 0 {
+		deletionTimeout = DefaultStackDeletionTimeout
+	}
+	return &CloudControl{
+		client:          client,
+		deletionTimeout: deletionTimeout,
+		pollingInterval: ResourceRequestPollingInterval,
+	}
+}
+
+// DeleteResource deletes the resource of the type by its primary identifier, and waits until the request is no
+// longer in progress. The final progress event is returned, whose status is SUCCESS, FAILED or CANCEL_COMPLETE.
+// It returns ResourceDeletionTimeoutError if the request does not finish within the timeout.
+func (c *CloudControl) DeleteResource(ctx context.Context, typeName *string, identifier *string) (*types.ProgressEvent, error) {
+	input := &cloudcontrol.DeleteResourceInput{
+		TypeName:   typeName,
+		Identifier: identifier,
+	}
+
+	output, err := c.client.DeleteResource(ctx, input)
+	if err != nil {
+		return nil, &ClientError{
+			ResourceName: identifier,
+			Err:          err,
+		}
+	}
+	if isResourceRequestFinished(output.ProgressEvent) {
+		return output.ProgressEvent, nil
+	}
+
+	progressEvent, err := c.waitResourceRequest(ctx, output.ProgressEvent.RequestToken)
+	if err != nil {
+		return nil, &ClientError{
+			ResourceName: identifier,
+			Err:          err,
+		}
+	}
+
+	return progressEvent, nil
+}
+
+// waitResourceRequest polls the status of the request until it is no longer in progress.
+// Throttling errors while polling are retried with a longer delay instead of failing the wait.
+func (c *CloudControl) waitResourceRequest(ctx context.Context, requestToken *string) (*types.ProgressEvent, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, c.deletionTimeout)
+	defer cancel()
+
+	delay := c.pollingInterval
+
+	for {
+		input := &cloudcontrol.GetResourceRequestStatusInput{
+			RequestToken: requestToken,
+		}
+
+		output, err := c.client.GetResourceRequestStatus(waitCtx, input)
+		switch {
+		case err != nil && strings.Contains(err.Error(), "api error Throttling"):
+			delay *= 2
+			if delay > MaxResourceRequestPollingDelay {
+				delay = MaxResourceRequestPollingDelay
+			}
+		case err != nil && waitCtx.Err() == nil:
+			return nil, err // return non wrapping error because wrap in public callers
+		case err == nil:
+			delay = c.pollingInterval
+			if isResourceRequestFinished(output.ProgressEvent) {
+				return output.ProgressEvent, nil
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, &ResourceDeletionTimeoutError{
+				Timeout: c.deletionTimeout,
+			}
+		case <-time.After(delay):
+		}
+	}
+}
+
+func isResourceRequestFinished(progressEvent *types.ProgressEvent) bool {
+	if progressEvent == nil {
+		return false
+	}
+	switch progressEvent.OperationStatus {
+	case types.OperationStatusSuccess, types.OperationStatusFailed, types.OperationStatusCancelComplete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: cloudcontrol.go
+
+package client
+
+import (
+	context "context"
+	reflect "reflect"
+
+	types "github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockICloudControl is a mock of ICloudControl interface.
+type MockICloudControl struct {
+	ctrl     *gomock.Controller
+	recorder *MockICloudControlMockRecorder
+}
+
+// MockICloudControlMockRecorder is the mock recorder for MockICloudControl.
+type MockICloudControlMockRecorder struct {
+	mock *MockICloudControl
+}
+
+// NewMockICloudControl creates a new mock instance.
+func NewMockICloudControl(ctrl *gomock.Controller) *MockICloudControl {
+	mock := &MockICloudControl{ctrl: ctrl}
+	mock.recorder = &MockICloudControlMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICloudControl) EXPECT() *MockICloudControlMockRecorder {
+	return m.recorder
+}
+
+// DeleteResource mocks base method.
+func (m *MockICloudControl) DeleteResource(ctx context.Context, typeName, identifier *string) (*types.ProgressEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResource", ctx, typeName, identifier)
+	ret0, _ := ret[0].(*types.ProgressEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteResource indicates an expected call of DeleteResource.
+func (mr *MockICloudControlMockRecorder) DeleteResource(ctx, typeName, identifier interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResource", reflect.TypeOf((*MockICloudControl)(nil).DeleteResource), ctx, typeName, identifier)
+}
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsMiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
+	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol/types"
+	"github.com/aws/smithy-go/middleware"
+)
+
+/*
+	Test Cases
+*/
+
+func TestCloudControl_DeleteResource(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		typeName           *string
+		identifier         *string
+		deletionTimeout    time.Duration
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    *types.ProgressEvent
+		wantErr error
+	}{
+		{
+			name: "delete resource successfully",
+			args: args{
+				ctx:        context.Background(),
+				typeName:   aws.String("AWS::SNS::Topic"),
+				identifier: aws.String("test"),
+				withAPIOptionsFunc: newCloudControlDeleteResourceMiddleware(
+					nil,
+					getResourceRequestStatusResult{status: types.OperationStatusInProgress},
+					getResourceRequestStatusResult{status: types.OperationStatusSuccess},
+				),
+			},
+			want: &types.ProgressEvent{
+				OperationStatus: types.OperationStatusSuccess,
+				RequestToken:    aws.String("RequestToken"),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete resource successfully for FAILED request after throttling",
+			args: args{
+				ctx:        context.Background(),
+				typeName:   aws.String("AWS::SNS::Topic"),
+				identifier: aws.String("test"),
+				withAPIOptionsFunc: newCloudControlDeleteResourceMiddleware(
+					nil,
+					getResourceRequestStatusResult{err: fmt.Errorf("api error ThrottlingException: Rate exceeded")},
+					getResourceRequestStatusResult{status: types.OperationStatusFailed, errorCode: types.HandlerErrorCodeNotFound},
+				),
+			},
+			want: &types.ProgressEvent{
+				OperationStatus: types.OperationStatusFailed,
+				ErrorCode:       types.HandlerErrorCodeNotFound,
+				RequestToken:    aws.String("RequestToken"),
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete resource failure",
+			args: args{
+				ctx:        context.Background(),
+				typeName:   aws.String("AWS::SNS::Topic"),
+				identifier: aws.String("test"),
+				withAPIOptionsFunc: newCloudControlDeleteResourceMiddleware(
+					fmt.Errorf("DeleteResourceError"),
+				),
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          fmt.Errorf("operation error CloudControl: DeleteResource, DeleteResourceError"),
+			},
+		},
+		{
+			name: "delete resource failure for wait errors",
+			args: args{
+				ctx:        context.Background(),
+				typeName:   aws.String("AWS::SNS::Topic"),
+				identifier: aws.String("test"),
+				withAPIOptionsFunc: newCloudControlDeleteResourceMiddleware(
+					nil,
+					getResourceRequestStatusResult{err: fmt.Errorf("WaitError")},
+				),
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          fmt.Errorf("operation error CloudControl: GetResourceRequestStatus, WaitError"),
+			},
+		},
+		{
+			name: "delete resource failure for timeout",
+			args: args{
+				ctx:             context.Background(),
+				typeName:        aws.String("AWS::SNS::Topic"),
+				identifier:      aws.String("test"),
+				deletionTimeout: 10 * time.Millisecond,
+				withAPIOptionsFunc: newCloudControlDeleteResourceMiddleware(
+					nil,
+					getResourceRequestStatusResult{status: types.OperationStatusInProgress},
+				),
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          &ResourceDeletionTimeoutError{Timeout: 10 * time.Millisecond},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudcontrol.NewFromConfig(cfg)
+			cloudControlClient := NewCloudControl(
+				client,
+				tt.args.deletionTimeout,
+			)
+			cloudControlClient.pollingInterval = time.Millisecond
+
+			got, err := cloudControlClient.DeleteResource(tt.args.ctx, tt.args.typeName, tt.args.identifier)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+type getResourceRequestStatusResult struct {
+	status    types.OperationStatus
+	errorCode types.HandlerErrorCode
+	err       error
+}
+
+// newCloudControlDeleteResourceMiddleware returns the mock of DeleteResource and GetResourceRequestStatus, which
+// returns the results in order and then repeats the last one.
+func newCloudControlDeleteResourceMiddleware(deleteResourceErr error, getResourceRequestStatusResults ...getResourceRequestStatusResult) func(*middleware.Stack) error {
+	count := 0
+
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(
+			middleware.FinalizeMiddlewareFunc(
+				"DeleteResourceOrGetResourceRequestStatusMock",
+				func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+					operationName := awsMiddleware.GetOperationName(ctx)
+					if operationName == "DeleteResource" {
+						return middleware.FinalizeOutput{
+							Result: &cloudcontrol.DeleteResourceOutput{
+								ProgressEvent: &types.ProgressEvent{
+									OperationStatus: types.OperationStatusPending,
+									RequestToken:    aws.String("RequestToken"),
+								},
+							},
+						}, middleware.Metadata{}, deleteResourceErr
+					}
+					if operationName == "GetResourceRequestStatus" {
+						result := getResourceRequestStatusResults[len(getResourceRequestStatusResults)-1]
+						if count < len(getResourceRequestStatusResults) {
+							result = getResourceRequestStatusResults[count]
+						}
+						count++
+
+						return middleware.FinalizeOutput{
+							Result: &cloudcontrol.GetResourceRequestStatusOutput{
+								ProgressEvent: &types.ProgressEvent{
+									OperationStatus: result.status,
+									ErrorCode:       result.errorCode,
+									RequestToken:    aws.String("RequestToken"),
+								},
+							},
+						}, middleware.Metadata{}, result.err
+					}
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				},
+			),
+			middleware.Before,
+		)
+	}
+}
@@ -6,54 +6,134 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 )
 
-const CloudFormationWaitNanoSecTime = time.Duration(4500000000000)
+const (
+	DefaultStackDeletionTimeout = 75 * time.Minute
+	StackEventsPollingInterval  = 10 * time.Second
+	MaxStackEventsPollingDelay  = 60 * time.Second
+)
+
+// StackDeletionStatus is the final status of the stack deletion waited by DeleteStack.
+type StackDeletionStatus string
+
+const (
+	StackDeletionStatusDeleted StackDeletionStatus = "DELETE_COMPLETE"
+	StackDeletionStatusFailed  StackDeletionStatus = "DELETE_FAILED"
+)
+
+type DeleteStackResult struct {
+	Status StackDeletionStatus
+	// StatusReason is the reason of the DELETE_FAILED event of the stack.
+	StatusReason string
+}
+
+// StackSetOperationResult is the final status of the StackSet operation waited by DeleteStackInstances.
+type StackSetOperationResult struct {
+	Status       types.StackSetOperationStatus
+	StatusReason string
+}
+
+// StackInstancesTarget is the stack instances of a StackSet deleted by an operation. They are specified by the
+// accounts for a self-managed StackSet, or by the organizational units for a service-managed StackSet, in the regions.
+type StackInstancesTarget struct {
+	Accounts              []string
+	OrganizationalUnitIds []string
+	Regions               []string
+}
 
 type ICloudFormation interface {
-	DeleteStack(ctx context.Context, stackName *string, retainResources []string) error
+	DeleteStack(ctx context.Context, stackName *string, retainResources []string, deletionMode types.DeletionMode, roleArn *string) (*DeleteStackResult, error)
 	DescribeStacks(ctx context.Context, stackName *string) ([]types.Stack, error)
+	DescribeStackEvents(ctx context.Context, stackName *string, lastEventId string) ([]types.StackEvent, error)
+	DescribeStackEventsUntil(ctx context.Context, stackName *string, isLast func(event types.StackEvent) bool) ([]types.StackEvent, error)
+	WaitStackOperationComplete(ctx context.Context, stackName *string) (*types.Stack, error)
+	ContinueUpdateRollback(ctx context.Context, stackName *string, resourcesToSkip []string) error
+	UpdateTerminationProtection(ctx context.Context, stackName *string, enable bool) error
+	GetTemplate(ctx context.Context, stackName *string) (*string, error)
 	ListStackResources(ctx context.Context, stackName *string) ([]types.StackResourceSummary, error)
 	ListStacks(ctx context.Context, stackStatusFilter []types.StackStatus) ([]types.StackSummary, error)
+	ListImports(ctx context.Context, exportName *string) ([]string, error)
+	ListExports(ctx context.Context) ([]types.Export, error)
+	DescribeStackSet(ctx context.Context, stackSetName *string, callAs types.CallAs) (*types.StackSet, error)
+	ListStackInstances(ctx context.Context, stackSetName *string, callAs types.CallAs) ([]types.StackInstanceSummary, error)
+	DeleteStackInstances(
+		ctx context.Context,
+		stackSetName *string,
+		target StackInstancesTarget,
+		preferences *types.StackSetOperationPreferences,
+		callAs types.CallAs,
+	) (*StackSetOperationResult, error)
+	DeleteStackSet(ctx context.Context, stackSetName *string, callAs types.CallAs) error
 }
 
 var _ ICloudFormation = (*CloudFormation)(nil)
 
 type CloudFormation struct {
-	client *cloudformation.Client
-	waiter *cloudformation.StackDeleteCompleteWaiter
+	client          *cloudformation.Client
+	deletionTimeout time.Duration
+	pollingInterval time.Duration
 }
 
-func NewCloudFormation(client *cloudformation.Client, waiter *cloudformation.StackDeleteCompleteWaiter) *CloudFormation {
+// NewCloudFormation returns the client waiting for the stack deletion up to deletionTimeout, or
+// DefaultStackDeletionTimeout if it is not positive.
+func NewCloudFormation(client *cloudformation.Client, deletionTimeout time.Duration) *CloudFormation {
+	if deletionTimeout <= 0 {
+		deletionTimeout = DefaultStackDeletionTimeout
+	}
 	return &CloudFormation{
-		client,
-		waiter,
+		client:          client,
+		deletionTimeout: deletionTimeout,
+		pollingInterval: StackEventsPollingInterval,
 	}
 }
 
-func (c *CloudFormation) DeleteStack(ctx context.Context, stackName *string, retainResources []string) error {
+// DeleteStack deletes the stack and waits until the stack is DELETE_COMPLETE or DELETE_FAILED.
+// FORCE_DELETE_STACK as deletionMode abandons the DELETE_FAILED resources of the stack in DELETE_FAILED.
+// roleArn is the service role for the deletion, or nil to use the role recorded in the stack.
+// It returns StackDeletionTimeoutError if the deletion does not finish within the timeout.
+func (c *CloudFormation) DeleteStack(
+	ctx context.Context,
+	stackName *string,
+	retainResources []string,
+	deletionMode types.DeletionMode,
+	roleArn *string,
+) (*DeleteStackResult, error) {
+	// events before the deletion, such as DELETE_FAILED in the previous deletion, are ignored in the wait
+	lastEventId, err := c.getLatestStackEventId(ctx, stackName)
+	if err != nil {
+		return nil, &ClientError{
+			ResourceName: stackName,
+			Err:          err,
+		}
+	}
+
 	input := &cloudformation.DeleteStackInput{
 		StackName:       stackName,
 		RetainResources: retainResources,
+		DeletionMode:    deletionMode,
+		RoleARN:         roleArn,
 	}
 
 	if _, err := c.client.DeleteStack(ctx, input); err != nil {
-		return &ClientError{
+		return nil, &ClientError{
 			ResourceName: stackName,
 			Err:          err,
 		}
 	}
 
-	if err := c.waitDeleteStack(ctx, stackName); err != nil {
-		return &ClientError{
+	result, err := c.waitDeleteStack(ctx, stackName, lastEventId)
+	if err != nil {
+		return nil, &ClientError{
 			ResourceName: stackName,
 			Err:          err,
 		}
 	}
 
-	return nil
+	return result, nil
 }
 
 func (c *CloudFormation) DescribeStacks(ctx context.Context, stackName *string) ([]types.Stack, error) {
@@ -100,19 +180,316 @@ func (c *CloudFormation) DescribeStacks(ctx context.Context, stackName *string)
 	return stacks, nil
 }
 
-func (c *CloudFormation) waitDeleteStack(ctx context.Context, stackName *string) error {
-	input := &cloudformation.DescribeStacksInput{
-		StackName: stackName,
+// DescribeStackEvents returns the events newer than lastEventId in the newest order, or the events in the first
+// page if lastEventId is empty. It returns no events if the stack does not exist.
+func (c *CloudFormation) DescribeStackEvents(ctx context.Context, stackName *string, lastEventId string) ([]types.StackEvent, error) {
+	var nextToken *string
+	stackEvents := []types.StackEvent{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return stackEvents, &ClientError{
+				ResourceName: stackName,
+				Err:          ctx.Err(),
+			}
+		default:
+		}
+
+		input := &cloudformation.DescribeStackEventsInput{
+			StackName: stackName,
+			NextToken: nextToken,
+		}
+
+		output, err := c.client.DescribeStackEvents(ctx, input)
+		if err != nil && strings.Contains(err.Error(), "does not exist") {
+			return stackEvents, nil
+		}
+		if err != nil {
+			return stackEvents, &ClientError{
+				ResourceName: stackName,
+				Err:          err,
+			}
+		}
+
+		for _, event := range output.StackEvents {
+			if aws.ToString(event.EventId) == lastEventId {
+				return stackEvents, nil
+			}
+			stackEvents = append(stackEvents, event)
+		}
+
+		nextToken = output.NextToken
+		if nextToken == nil || lastEventId == "" {
+			break
+		}
 	}
 
-	err := c.waiter.Wait(ctx, input, CloudFormationWaitNanoSecTime)
-	if err != nil && !strings.Contains(err.Error(), "waiter state transitioned to Failure") {
-		return err // return non wrapping error because wrap in public callers
+	return stackEvents, nil
+}
+
+// DescribeStackEventsUntil returns the events in the newest order across the pages up to the first event for which
+// isLast returns true, or all the events of the stack. It returns no events if the stack does not exist.
+func (c *CloudFormation) DescribeStackEventsUntil(
+	ctx context.Context,
+	stackName *string,
+	isLast func(event types.StackEvent) bool,
+) ([]types.StackEvent, error) {
+	var nextToken *string
+	stackEvents := []types.StackEvent{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return stackEvents, &ClientError{
+				ResourceName: stackName,
+				Err:          ctx.Err(),
+			}
+		default:
+		}
+
+		input := &cloudformation.DescribeStackEventsInput{
+			StackName: stackName,
+			NextToken: nextToken,
+		}
+
+		output, err := c.client.DescribeStackEvents(ctx, input)
+		if err != nil && strings.Contains(err.Error(), "does not exist") {
+			return stackEvents, nil
+		}
+		if err != nil {
+			return stackEvents, &ClientError{
+				ResourceName: stackName,
+				Err:          err,
+			}
+		}
+
+		for _, event := range output.StackEvents {
+			stackEvents = append(stackEvents, event)
+			if isLast(event) {
+				return stackEvents, nil
+			}
+		}
+
+		nextToken = output.NextToken
+		if nextToken == nil {
+			break
+		}
+	}
+
+	return stackEvents, nil
+}
+
+// waitDeleteStack polls the stack events newer than lastEventId until the stack is deleted or DELETE_FAILED.
+// Throttling errors while polling are retried with a longer delay instead of failing the wait.
+func (c *CloudFormation) waitDeleteStack(ctx context.Context, stackName *string, lastEventId string) (*DeleteStackResult, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, c.deletionTimeout)
+	defer cancel()
+
+	delay := c.pollingInterval
+
+	for {
+		input := &cloudformation.DescribeStackEventsInput{
+			StackName: stackName,
+		}
+
+		output, err := c.client.DescribeStackEvents(waitCtx, input)
+		switch {
+		case err != nil && strings.Contains(err.Error(), "does not exist"):
+			return &DeleteStackResult{
+				Status: StackDeletionStatusDeleted,
+			}, nil
+		case err != nil && strings.Contains(err.Error(), "api error Throttling"):
+			delay *= 2
+			if delay > MaxStackEventsPollingDelay {
+				delay = MaxStackEventsPollingDelay
+			}
+		case err != nil && waitCtx.Err() == nil:
+			return nil, err // return non wrapping error because wrap in public callers
+		case err == nil:
+			delay = c.pollingInterval
+			if result := getStackDeletionResult(output.StackEvents, lastEventId); result != nil {
+				return result, nil
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, &StackDeletionTimeoutError{
+				Timeout: c.deletionTimeout,
+			}
+		case <-time.After(delay):
+		}
+	}
+}
+
+// WaitStackOperationComplete waits until the stack is no longer in XXX_IN_PROGRESS status, such as an in-flight
+// deletion or rollback, and returns the stack. It returns nil if the stack has been deleted.
+func (c *CloudFormation) WaitStackOperationComplete(ctx context.Context, stackName *string) (*types.Stack, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, c.deletionTimeout)
+	defer cancel()
+
+	delay := c.pollingInterval
+
+	for {
+		input := &cloudformation.DescribeStacksInput{
+			StackName: stackName,
+		}
+
+		output, err := c.client.DescribeStacks(waitCtx, input)
+		switch {
+		case err != nil && strings.Contains(err.Error(), "does not exist"):
+			return nil, nil
+		case err != nil && strings.Contains(err.Error(), "api error Throttling"):
+			delay *= 2
+			if delay > MaxStackEventsPollingDelay {
+				delay = MaxStackEventsPollingDelay
+			}
+		case err != nil && waitCtx.Err() == nil:
+			return nil, &ClientError{
+				ResourceName: stackName,
+				Err:          err,
+			}
+		case err == nil:
+			delay = c.pollingInterval
+			if len(output.Stacks) == 0 || output.Stacks[0].StackStatus == types.StackStatusDeleteComplete {
+				return nil, nil
+			}
+			if !IsStackInProgress(output.Stacks[0].StackStatus) {
+				return &output.Stacks[0], nil
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, &ClientError{
+					ResourceName: stackName,
+					Err:          ctx.Err(),
+				}
+			}
+			return nil, &ClientError{
+				ResourceName: stackName,
+				Err: &StackDeletionTimeoutError{
+					Timeout: c.deletionTimeout,
+				},
+			}
+		case <-time.After(delay):
+		}
+	}
+}
+
+// ContinueUpdateRollback starts to roll back the UPDATE_ROLLBACK_FAILED stack again, skipping resourcesToSkip.
+// It does not wait for the rollback, so use WaitStackOperationComplete.
+func (c *CloudFormation) ContinueUpdateRollback(ctx context.Context, stackName *string, resourcesToSkip []string) error {
+	input := &cloudformation.ContinueUpdateRollbackInput{
+		StackName:       stackName,
+		ResourcesToSkip: resourcesToSkip,
+	}
+
+	if _, err := c.client.ContinueUpdateRollback(ctx, input); err != nil {
+		return &ClientError{
+			ResourceName: stackName,
+			Err:          err,
+		}
 	}
 
 	return nil
 }
 
+// UpdateTerminationProtection enables or disables the termination protection of the stack.
+func (c *CloudFormation) UpdateTerminationProtection(ctx context.Context, stackName *string, enable bool) error {
+	input := &cloudformation.UpdateTerminationProtectionInput{
+		StackName:                   stackName,
+		EnableTerminationProtection: aws.Bool(enable),
+	}
+
+	if _, err := c.client.UpdateTerminationProtection(ctx, input); err != nil {
+		return &ClientError{
+			ResourceName: stackName,
+			Err:          err,
+		}
+	}
+
+	return nil
+}
+
+// GetTemplate returns the template of the stack after the transforms are processed, or nil if the stack does not exist.
+func (c *CloudFormation) GetTemplate(ctx context.Context, stackName *string) (*string, error) {
+	input := &cloudformation.GetTemplateInput{
+		StackName:     stackName,
+		TemplateStage: types.TemplateStageProcessed,
+	}
+
+	output, err := c.client.GetTemplate(ctx, input)
+	if err != nil && strings.Contains(err.Error(), "does not exist") {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &ClientError{
+			ResourceName: stackName,
+			Err:          err,
+		}
+	}
+
+	return output.TemplateBody, nil
+}
+
+// IsStackInProgress reports whether a CloudFormation operation is in progress on the stack. REVIEW_IN_PROGRESS is
+// not regarded as in progress, because the stack only waits for a change set to be executed.
+func IsStackInProgress(status types.StackStatus) bool {
+	return strings.HasSuffix(string(status), "_IN_PROGRESS") && status != types.StackStatusReviewInProgress
+}
+
+// getStackDeletionResult returns the result if the events newer than lastEventId include the terminal event of
+// the stack itself, or nil if the deletion is still in progress. The events are sorted in the newest order.
+func getStackDeletionResult(events []types.StackEvent, lastEventId string) *DeleteStackResult {
+	for _, event := range events {
+		if aws.ToString(event.EventId) == lastEventId {
+			break
+		}
+		// events of the stack itself, not of the resources (including nested stacks) in the stack
+		if aws.ToString(event.PhysicalResourceId) != aws.ToString(event.StackId) {
+			continue
+		}
+
+		switch event.ResourceStatus {
+		case types.ResourceStatusDeleteComplete:
+			return &DeleteStackResult{
+				Status: StackDeletionStatusDeleted,
+			}
+		case types.ResourceStatusDeleteFailed:
+			return &DeleteStackResult{
+				Status:       StackDeletionStatusFailed,
+				StatusReason: aws.ToString(event.ResourceStatusReason),
+			}
+		}
+	}
+	return nil
+}
+
+func (c *CloudFormation) getLatestStackEventId(ctx context.Context, stackName *string) (string, error) {
+	input := &cloudformation.DescribeStackEventsInput{
+		StackName: stackName,
+	}
+
+	output, err := c.client.DescribeStackEvents(ctx, input)
+	if err != nil && strings.Contains(err.Error(), "does not exist") {
+		return "", nil
+	}
+	if err != nil {
+		return "", err // return non wrapping error because wrap in public callers
+	}
+	if len(output.StackEvents) == 0 {
+		return "", nil
+	}
+
+	return aws.ToString(output.StackEvents[0].EventId), nil
+}
+
 func (c *CloudFormation) ListStackResources(ctx context.Context, stackName *string) ([]types.StackResourceSummary, error) {
 	var nextToken *string
 	stackResourceSummaries := []types.StackResourceSummary{}
@@ -190,3 +567,275 @@ func (c *CloudFormation) ListStacks(ctx context.Context, stackStatusFilter []typ
 
 	return stackSummaries, nil
 }
+
+func (c *CloudFormation) ListImports(ctx context.Context, exportName *string) ([]string, error) {
+	var nextToken *string
+	importingStackNames := []string{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return importingStackNames, &ClientError{
+				ResourceName: exportName,
+				Err:          ctx.Err(),
+			}
+		default:
+		}
+
+		input := &cloudformation.ListImportsInput{
+			ExportName: exportName,
+			NextToken:  nextToken,
+		}
+
+		output, err := c.client.ListImports(ctx, input)
+		if err != nil && strings.Contains(err.Error(), "is not imported by any stack") {
+			return importingStackNames, nil
+		}
+		if err != nil {
+			return importingStackNames, &ClientError{
+				ResourceName: exportName,
+				Err:          err,
+			}
+		}
+
+		importingStackNames = append(importingStackNames, output.Imports...)
+		nextToken = output.NextToken
+
+		if nextToken == nil {
+			break
+		}
+	}
+
+	return importingStackNames, nil
+}
+
+func (c *CloudFormation) ListExports(ctx context.Context) ([]types.Export, error) {
+	var nextToken *string
+	exports := []types.Export{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return exports, &ClientError{
+				Err: ctx.Err(),
+			}
+		default:
+		}
+
+		input := &cloudformation.ListExportsInput{
+			NextToken: nextToken,
+		}
+
+		output, err := c.client.ListExports(ctx, input)
+		if err != nil {
+			return exports, &ClientError{
+				Err: err,
+			}
+		}
+
+		exports = append(exports, output.Exports...)
+		nextToken = output.NextToken
+
+		if nextToken == nil {
+			break
+		}
+	}
+
+	return exports, nil
+}
+
+// DescribeStackSet returns the StackSet, or nil if it does not exist.
+func (c *CloudFormation) DescribeStackSet(ctx context.Context, stackSetName *string, callAs types.CallAs) (*types.StackSet, error) {
+	input := &cloudformation.DescribeStackSetInput{
+		StackSetName: stackSetName,
+		CallAs:       callAs,
+	}
+
+	output, err := c.client.DescribeStackSet(ctx, input)
+	if err != nil && strings.Contains(err.Error(), "StackSetNotFoundException") {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &ClientError{
+			ResourceName: stackSetName,
+			Err:          err,
+		}
+	}
+
+	return output.StackSet, nil
+}
+
+func (c *CloudFormation) ListStackInstances(ctx context.Context, stackSetName *string, callAs types.CallAs) ([]types.StackInstanceSummary, error) {
+	var nextToken *string
+	stackInstances := []types.StackInstanceSummary{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return stackInstances, &ClientError{
+				ResourceName: stackSetName,
+				Err:          ctx.Err(),
+			}
+		default:
+		}
+
+		input := &cloudformation.ListStackInstancesInput{
+			StackSetName: stackSetName,
+			CallAs:       callAs,
+			NextToken:    nextToken,
+		}
+
+		output, err := c.client.ListStackInstances(ctx, input)
+		if err != nil && strings.Contains(err.Error(), "StackSetNotFoundException") {
+			return stackInstances, nil
+		}
+		if err != nil {
+			return stackInstances, &ClientError{
+				ResourceName: stackSetName,
+				Err:          err,
+			}
+		}
+
+		stackInstances = append(stackInstances, output.Summaries...)
+		nextToken = output.NextToken
+
+		if nextToken == nil {
+			break
+		}
+	}
+
+	return stackInstances, nil
+}
+
+// DeleteStackInstances deletes the stack instances of the target without retaining their stacks, and waits until
+// the operation is no longer running. The result is not SUCCEEDED if the deletion failed in more accounts than the
+// failure tolerance of preferences. It returns StackDeletionTimeoutError if the operation does not finish within
+// the timeout.
+func (c *CloudFormation) DeleteStackInstances(
+	ctx context.Context,
+	stackSetName *string,
+	target StackInstancesTarget,
+	preferences *types.StackSetOperationPreferences,
+	callAs types.CallAs,
+) (*StackSetOperationResult, error) {
+	input := &cloudformation.DeleteStackInstancesInput{
+		StackSetName:         stackSetName,
+		Regions:              target.Regions,
+		RetainStacks:         aws.Bool(false),
+		OperationPreferences: preferences,
+		CallAs:               callAs,
+	}
+	if len(target.OrganizationalUnitIds) > 0 {
+		input.DeploymentTargets = &types.DeploymentTargets{
+			OrganizationalUnitIds: target.OrganizationalUnitIds,
+		}
+	} else {
+		input.Accounts = target.Accounts
+	}
+
+	output, err := c.client.DeleteStackInstances(ctx, input)
+	if err != nil {
+		return nil, &ClientError{
+			ResourceName: stackSetName,
+			Err:          err,
+		}
+	}
+
+	result, err := c.waitStackSetOperation(ctx, stackSetName, output.OperationId, callAs)
+	if err != nil {
+		return nil, &ClientError{
+			ResourceName: stackSetName,
+			Err:          err,
+		}
+	}
+
+	return result, nil
+}
+
+// waitStackSetOperation polls the StackSet operation until it is no longer queued or running.
+// Throttling errors while polling are retried with a longer delay instead of failing the wait.
+func (c *CloudFormation) waitStackSetOperation(
+	ctx context.Context,
+	stackSetName *string,
+	operationId *string,
+	callAs types.CallAs,
+) (*StackSetOperationResult, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, c.deletionTimeout)
+	defer cancel()
+
+	delay := c.pollingInterval
+
+	for {
+		input := &cloudformation.DescribeStackSetOperationInput{
+			StackSetName: stackSetName,
+			OperationId:  operationId,
+			CallAs:       callAs,
+		}
+
+		output, err := c.client.DescribeStackSetOperation(waitCtx, input)
+		switch {
+		case err != nil && strings.Contains(err.Error(), "api error Throttling"):
+			delay *= 2
+			if delay > MaxStackEventsPollingDelay {
+				delay = MaxStackEventsPollingDelay
+			}
+		case err != nil && waitCtx.Err() == nil:
+			return nil, err // return non wrapping error because wrap in public callers
+		case err == nil:
+			delay = c.pollingInterval
+			if result := getStackSetOperationResult(output.StackSetOperation); result != nil {
+				return result, nil
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, &StackDeletionTimeoutError{
+				Timeout: c.deletionTimeout,
+			}
+		case <-time.After(delay):
+		}
+	}
+}
+
+// getStackSetOperationResult returns the result if the operation has finished, or nil if it is still in progress.
+func getStackSetOperationResult(operation *types.StackSetOperation) *StackSetOperationResult {
+	if operation == nil {
+		return nil
+	}
+
+	switch operation.Status {
+	case types.StackSetOperationStatusQueued, types.StackSetOperationStatusRunning, types.StackSetOperationStatusStopping:
+		return nil
+	}
+
+	return &StackSetOperationResult{
+		Status:       operation.Status,
+		StatusReason: aws.ToString(operation.StatusReason),
+	}
+}
+
+// DeleteStackSet deletes the StackSet, which must have no stack instances. It does nothing if the StackSet does
+// not exist.
+func (c *CloudFormation) DeleteStackSet(ctx context.Context, stackSetName *string, callAs types.CallAs) error {
+	input := &cloudformation.DeleteStackSetInput{
+		StackSetName: stackSetName,
+		CallAs:       callAs,
+	}
+
+	_, err := c.client.DeleteStackSet(ctx, input)
+	if err != nil && strings.Contains(err.Error(), "StackSetNotFoundException") {
+		return nil
+	}
+	if err != nil {
+		return &ClientError{
+			ResourceName: stackSetName,
+			Err:          err,
+		}
+	}
+
+	return nil
+}
@@ -34,18 +34,107 @@ func (m *MockICloudFormation) EXPECT() *MockICloudFormationMockRecorder {
 	return m.recorder
 }
 
-// DeleteStack mocks base method.
-func (m *MockICloudFormation) DeleteStack(ctx context.Context, stackName *string, retainResources []string) error {
+// ContinueUpdateRollback mocks base method.
+func (m *MockICloudFormation) ContinueUpdateRollback(ctx context.Context, stackName *string, resourcesToSkip []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteStack", ctx, stackName, retainResources)
+	ret := m.ctrl.Call(m, "ContinueUpdateRollback", ctx, stackName, resourcesToSkip)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// ContinueUpdateRollback indicates an expected call of ContinueUpdateRollback.
+func (mr *MockICloudFormationMockRecorder) ContinueUpdateRollback(ctx, stackName, resourcesToSkip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContinueUpdateRollback", reflect.TypeOf((*MockICloudFormation)(nil).ContinueUpdateRollback), ctx, stackName, resourcesToSkip)
+}
+
+// DeleteStack mocks base method.
+func (m *MockICloudFormation) DeleteStack(ctx context.Context, stackName *string, retainResources []string, deletionMode types.DeletionMode, roleArn *string) (*DeleteStackResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStack", ctx, stackName, retainResources, deletionMode, roleArn)
+	ret0, _ := ret[0].(*DeleteStackResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // DeleteStack indicates an expected call of DeleteStack.
-func (mr *MockICloudFormationMockRecorder) DeleteStack(ctx, stackName, retainResources interface{}) *gomock.Call {
+func (mr *MockICloudFormationMockRecorder) DeleteStack(ctx, stackName, retainResources, deletionMode, roleArn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStack", reflect.TypeOf((*MockICloudFormation)(nil).DeleteStack), ctx, stackName, retainResources, deletionMode, roleArn)
+}
+
+// DeleteStackInstances mocks base method.
+func (m *MockICloudFormation) DeleteStackInstances(ctx context.Context, stackSetName *string, target StackInstancesTarget, preferences *types.StackSetOperationPreferences, callAs types.CallAs) (*StackSetOperationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStackInstances", ctx, stackSetName, target, preferences, callAs)
+	ret0, _ := ret[0].(*StackSetOperationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStackInstances indicates an expected call of DeleteStackInstances.
+func (mr *MockICloudFormationMockRecorder) DeleteStackInstances(ctx, stackSetName, target, preferences, callAs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStackInstances", reflect.TypeOf((*MockICloudFormation)(nil).DeleteStackInstances), ctx, stackSetName, target, preferences, callAs)
+}
+
+// DeleteStackSet mocks base method.
+func (m *MockICloudFormation) DeleteStackSet(ctx context.Context, stackSetName *string, callAs types.CallAs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStackSet", ctx, stackSetName, callAs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStackSet indicates an expected call of DeleteStackSet.
+func (mr *MockICloudFormationMockRecorder) DeleteStackSet(ctx, stackSetName, callAs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStackSet", reflect.TypeOf((*MockICloudFormation)(nil).DeleteStackSet), ctx, stackSetName, callAs)
+}
+
+// DescribeStackEvents mocks base method.
+func (m *MockICloudFormation) DescribeStackEvents(ctx context.Context, stackName *string, lastEventId string) ([]types.StackEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeStackEvents", ctx, stackName, lastEventId)
+	ret0, _ := ret[0].([]types.StackEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeStackEvents indicates an expected call of DescribeStackEvents.
+func (mr *MockICloudFormationMockRecorder) DescribeStackEvents(ctx, stackName, lastEventId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeStackEvents", reflect.TypeOf((*MockICloudFormation)(nil).DescribeStackEvents), ctx, stackName, lastEventId)
+}
+
+// DescribeStackEventsUntil mocks base method.
+func (m *MockICloudFormation) DescribeStackEventsUntil(ctx context.Context, stackName *string, isLast func(types.StackEvent) bool) ([]types.StackEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeStackEventsUntil", ctx, stackName, isLast)
+	ret0, _ := ret[0].([]types.StackEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeStackEventsUntil indicates an expected call of DescribeStackEventsUntil.
+func (mr *MockICloudFormationMockRecorder) DescribeStackEventsUntil(ctx, stackName, isLast interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeStackEventsUntil", reflect.TypeOf((*MockICloudFormation)(nil).DescribeStackEventsUntil), ctx, stackName, isLast)
+}
+
+// DescribeStackSet mocks base method.
+func (m *MockICloudFormation) DescribeStackSet(ctx context.Context, stackSetName *string, callAs types.CallAs) (*types.StackSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeStackSet", ctx, stackSetName, callAs)
+	ret0, _ := ret[0].(*types.StackSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeStackSet indicates an expected call of DescribeStackSet.
+func (mr *MockICloudFormationMockRecorder) DescribeStackSet(ctx, stackSetName, callAs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStack", reflect.TypeOf((*MockICloudFormation)(nil).DeleteStack), ctx, stackName, retainResources)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeStackSet", reflect.TypeOf((*MockICloudFormation)(nil).DescribeStackSet), ctx, stackSetName, callAs)
 }
 
 // DescribeStacks mocks base method.
@@ -63,6 +152,66 @@ func (mr *MockICloudFormationMockRecorder) DescribeStacks(ctx, stackName interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeStacks", reflect.TypeOf((*MockICloudFormation)(nil).DescribeStacks), ctx, stackName)
 }
 
+// GetTemplate mocks base method.
+func (m *MockICloudFormation) GetTemplate(ctx context.Context, stackName *string) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, stackName)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockICloudFormationMockRecorder) GetTemplate(ctx, stackName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockICloudFormation)(nil).GetTemplate), ctx, stackName)
+}
+
+// ListExports mocks base method.
+func (m *MockICloudFormation) ListExports(ctx context.Context) ([]types.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExports", ctx)
+	ret0, _ := ret[0].([]types.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExports indicates an expected call of ListExports.
+func (mr *MockICloudFormationMockRecorder) ListExports(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExports", reflect.TypeOf((*MockICloudFormation)(nil).ListExports), ctx)
+}
+
+// ListImports mocks base method.
+func (m *MockICloudFormation) ListImports(ctx context.Context, exportName *string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImports", ctx, exportName)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImports indicates an expected call of ListImports.
+func (mr *MockICloudFormationMockRecorder) ListImports(ctx, exportName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImports", reflect.TypeOf((*MockICloudFormation)(nil).ListImports), ctx, exportName)
+}
+
+// ListStackInstances mocks base method.
+func (m *MockICloudFormation) ListStackInstances(ctx context.Context, stackSetName *string, callAs types.CallAs) ([]types.StackInstanceSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStackInstances", ctx, stackSetName, callAs)
+	ret0, _ := ret[0].([]types.StackInstanceSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStackInstances indicates an expected call of ListStackInstances.
+func (mr *MockICloudFormationMockRecorder) ListStackInstances(ctx, stackSetName, callAs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStackInstances", reflect.TypeOf((*MockICloudFormation)(nil).ListStackInstances), ctx, stackSetName, callAs)
+}
+
 // ListStackResources mocks base method.
 func (m *MockICloudFormation) ListStackResources(ctx context.Context, stackName *string) ([]types.StackResourceSummary, error) {
 	m.ctrl.T.Helper()
@@ -92,3 +241,32 @@ func (mr *MockICloudFormationMockRecorder) ListStacks(ctx, stackStatusFilter int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStacks", reflect.TypeOf((*MockICloudFormation)(nil).ListStacks), ctx, stackStatusFilter)
 }
+
+// UpdateTerminationProtection mocks base method.
+func (m *MockICloudFormation) UpdateTerminationProtection(ctx context.Context, stackName *string, enable bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTerminationProtection", ctx, stackName, enable)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTerminationProtection indicates an expected call of UpdateTerminationProtection.
+func (mr *MockICloudFormationMockRecorder) UpdateTerminationProtection(ctx, stackName, enable interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTerminationProtection", reflect.TypeOf((*MockICloudFormation)(nil).UpdateTerminationProtection), ctx, stackName, enable)
+}
+
+// WaitStackOperationComplete mocks base method.
+func (m *MockICloudFormation) WaitStackOperationComplete(ctx context.Context, stackName *string) (*types.Stack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitStackOperationComplete", ctx, stackName)
+	ret0, _ := ret[0].(*types.Stack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitStackOperationComplete indicates an expected call of WaitStackOperationComplete.
+func (mr *MockICloudFormationMockRecorder) WaitStackOperationComplete(ctx, stackName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitStackOperationComplete", reflect.TypeOf((*MockICloudFormation)(nil).WaitStackOperationComplete), ctx, stackName)
+}
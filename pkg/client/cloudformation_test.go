@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsMiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
@@ -28,6 +29,12 @@ func getNextTokenForCloudFormationInitialize(
 		ctx = middleware.WithStackValue(ctx, tokenKeyForCloudFormation{}, v.NextToken)
 	case *cloudformation.ListStacksInput:
 		ctx = middleware.WithStackValue(ctx, tokenKeyForCloudFormation{}, v.NextToken)
+	case *cloudformation.ListImportsInput:
+		ctx = middleware.WithStackValue(ctx, tokenKeyForCloudFormation{}, v.NextToken)
+	case *cloudformation.DescribeStackEventsInput:
+		ctx = middleware.WithStackValue(ctx, tokenKeyForCloudFormation{}, v.NextToken)
+	case *cloudformation.ListExportsInput:
+		ctx = middleware.WithStackValue(ctx, tokenKeyForCloudFormation{}, v.NextToken)
 	}
 	return next.HandleInitialize(ctx, in)
 }
@@ -41,14 +48,45 @@ func TestCloudFormation_DeleteStack(t *testing.T) {
 		ctx                context.Context
 		stackName          *string
 		retainResources    []string
+		deletionMode       types.DeletionMode
+		roleArn            *string
+		deletionTimeout    time.Duration
 		withAPIOptionsFunc func(*middleware.Stack) error
 	}
 
+	oldFailedEvent := types.StackEvent{
+		EventId:              aws.String("OldEventId"),
+		StackId:              aws.String("StackId"),
+		PhysicalResourceId:   aws.String("StackId"),
+		ResourceStatus:       types.ResourceStatusDeleteFailed,
+		ResourceStatusReason: aws.String("OldReason"),
+	}
+	inProgressEvent := types.StackEvent{
+		EventId:            aws.String("InProgressEventId"),
+		StackId:            aws.String("StackId"),
+		PhysicalResourceId: aws.String("StackId"),
+		ResourceStatus:     types.ResourceStatusDeleteInProgress,
+	}
+	nestedStackFailedEvent := types.StackEvent{
+		EventId:              aws.String("NestedStackEventId"),
+		StackId:              aws.String("StackId"),
+		PhysicalResourceId:   aws.String("NestedStackId"),
+		ResourceStatus:       types.ResourceStatusDeleteFailed,
+		ResourceStatusReason: aws.String("NestedStackReason"),
+	}
+	failedEvent := types.StackEvent{
+		EventId:              aws.String("FailedEventId"),
+		StackId:              aws.String("StackId"),
+		PhysicalResourceId:   aws.String("StackId"),
+		ResourceStatus:       types.ResourceStatusDeleteFailed,
+		ResourceStatusReason: aws.String("Reason"),
+	}
+
 	cases := []struct {
 		name    string
 		args    args
-		want    error
-		wantErr bool
+		want    *DeleteStackResult
+		wantErr error
 	}{
 		{
 			name: "delete stack successfully",
@@ -56,109 +94,98 @@ func TestCloudFormation_DeleteStack(t *testing.T) {
 				ctx:             context.Background(),
 				stackName:       aws.String("test"),
 				retainResources: []string{"test1", "test2"},
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"DeleteStackOrDescribeStacksForWaiterMock",
-							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								operationName := awsMiddleware.GetOperationName(ctx)
-								if operationName == "DeleteStack" {
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.DeleteStackOutput{},
-									}, middleware.Metadata{}, nil
-								}
-								if operationName == "DescribeStacks" {
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.DescribeStacksOutput{
-											Stacks: []types.Stack{
-												{
-													StackName:   aws.String("StackName"),
-													StackStatus: "DELETE_COMPLETE",
-												},
-											},
-										},
-									}, middleware.Metadata{}, nil
-								}
-								return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
-							},
-						),
-						middleware.Before,
-					)
-				},
+				withAPIOptionsFunc: newDeleteStackMiddleware(
+					nil,
+					describeStackEventsResult{events: []types.StackEvent{oldFailedEvent}},
+					describeStackEventsResult{events: []types.StackEvent{inProgressEvent, oldFailedEvent}},
+					describeStackEventsResult{err: fmt.Errorf("Stack with id test does not exist")},
+				),
 			},
-			want:    nil,
-			wantErr: false,
+			want: &DeleteStackResult{
+				Status: StackDeletionStatusDeleted,
+			},
+			wantErr: nil,
 		},
 		{
-			name: "delete stack successfully including non retainResources",
+			name: "delete stack successfully for DELETE_COMPLETE event",
 			args: args{
 				ctx:             context.Background(),
 				stackName:       aws.String("test"),
 				retainResources: []string{},
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"DeleteStackOrDescribeStacksForWaiterIncludingNonRetainResourcesMock",
-							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								operationName := awsMiddleware.GetOperationName(ctx)
-								if operationName == "DeleteStack" {
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.DeleteStackOutput{},
-									}, middleware.Metadata{}, nil
-								}
-								if operationName == "DescribeStacks" {
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.DescribeStacksOutput{
-											Stacks: []types.Stack{
-												{
-													StackName:   aws.String("StackName"),
-													StackStatus: "DELETE_COMPLETE",
-												},
-											},
-										},
-									}, middleware.Metadata{}, nil
-								}
-								return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
-							},
-						),
-						middleware.Before,
-					)
-				},
+				withAPIOptionsFunc: newDeleteStackMiddleware(
+					nil,
+					describeStackEventsResult{events: []types.StackEvent{}},
+					describeStackEventsResult{events: []types.StackEvent{
+						{
+							EventId:            aws.String("CompleteEventId"),
+							StackId:            aws.String("StackId"),
+							PhysicalResourceId: aws.String("StackId"),
+							ResourceStatus:     types.ResourceStatusDeleteComplete,
+						},
+						inProgressEvent,
+					}},
+				),
 			},
-			want:    nil,
-			wantErr: false,
+			want: &DeleteStackResult{
+				Status: StackDeletionStatusDeleted,
+			},
+			wantErr: nil,
 		},
 		{
-			name: "delete stack successfully for transitioned to Failure",
+			name: "delete stack successfully for DELETE_FAILED",
 			args: args{
 				ctx:             context.Background(),
 				stackName:       aws.String("test"),
-				retainResources: []string{"test1", "test2"},
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"DeleteStackOrDescribeStacksForWaiterStateTransitionedToFailureMock",
-							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								operationName := awsMiddleware.GetOperationName(ctx)
-								if operationName == "DeleteStack" {
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.DeleteStackOutput{},
-									}, middleware.Metadata{}, nil
-								}
-								if operationName == "DescribeStacks" {
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.DescribeStacksOutput{},
-									}, middleware.Metadata{}, fmt.Errorf("waiter state transitioned to Failure")
-								}
-								return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
-							},
-						),
-						middleware.Before,
-					)
-				},
+				retainResources: []string{},
+				withAPIOptionsFunc: newDeleteStackMiddleware(
+					nil,
+					describeStackEventsResult{events: []types.StackEvent{oldFailedEvent}},
+					describeStackEventsResult{events: []types.StackEvent{failedEvent, nestedStackFailedEvent, inProgressEvent, oldFailedEvent}},
+				),
 			},
-			want:    nil,
-			wantErr: false,
+			want: &DeleteStackResult{
+				Status:       StackDeletionStatusFailed,
+				StatusReason: "Reason",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete stack successfully with FORCE_DELETE_STACK and service role",
+			args: args{
+				ctx:             context.Background(),
+				stackName:       aws.String("test"),
+				retainResources: []string{},
+				deletionMode:    types.DeletionModeForceDeleteStack,
+				roleArn:         aws.String("arn:aws:iam::123456789012:role/CfnRole"),
+				withAPIOptionsFunc: newDeleteStackMiddleware(
+					nil,
+					describeStackEventsResult{events: []types.StackEvent{oldFailedEvent}},
+					describeStackEventsResult{err: fmt.Errorf("Stack with id test does not exist")},
+				),
+			},
+			want: &DeleteStackResult{
+				Status: StackDeletionStatusDeleted,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete stack successfully after throttling",
+			args: args{
+				ctx:             context.Background(),
+				stackName:       aws.String("test"),
+				retainResources: []string{},
+				withAPIOptionsFunc: newDeleteStackMiddleware(
+					nil,
+					describeStackEventsResult{events: []types.StackEvent{oldFailedEvent}},
+					describeStackEventsResult{err: fmt.Errorf("api error Throttling: Rate exceeded")},
+					describeStackEventsResult{events: []types.StackEvent{failedEvent, oldFailedEvent}},
+				),
+			},
+			want: &DeleteStackResult{
+				Status:       StackDeletionStatusFailed,
+				StatusReason: "Reason",
+			},
+			wantErr: nil,
 		},
 		{
 			name: "delete stack failure",
@@ -166,25 +193,16 @@ func TestCloudFormation_DeleteStack(t *testing.T) {
 				ctx:             context.Background(),
 				stackName:       aws.String("test"),
 				retainResources: []string{"test1", "test2"},
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"DeleteStackErrorMock",
-							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								return middleware.FinalizeOutput{
-									Result: &cloudformation.DeleteStackOutput{},
-								}, middleware.Metadata{}, fmt.Errorf("DeleteStackError")
-							},
-						),
-						middleware.Before,
-					)
-				},
+				withAPIOptionsFunc: newDeleteStackMiddleware(
+					fmt.Errorf("DeleteStackError"),
+					describeStackEventsResult{events: []types.StackEvent{}},
+				),
 			},
-			want: &ClientError{
+			want: nil,
+			wantErr: &ClientError{
 				ResourceName: aws.String("test"),
 				Err:          fmt.Errorf("operation error CloudFormation: DeleteStack, DeleteStackError"),
 			},
-			wantErr: true,
 		},
 		{
 			name: "delete stack failure for wait errors",
@@ -192,34 +210,36 @@ func TestCloudFormation_DeleteStack(t *testing.T) {
 				ctx:             context.Background(),
 				stackName:       aws.String("test"),
 				retainResources: []string{"test1", "test2"},
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"DeleteStackOrDescribeStacksForWaiterErrorMock",
-							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								operationName := awsMiddleware.GetOperationName(ctx)
-								if operationName == "DeleteStack" {
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.DeleteStackOutput{},
-									}, middleware.Metadata{}, nil
-								}
-								if operationName == "DescribeStacks" {
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.DescribeStacksOutput{},
-									}, middleware.Metadata{}, fmt.Errorf("WaitError")
-								}
-								return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
-							},
-						),
-						middleware.Before,
-					)
-				},
+				withAPIOptionsFunc: newDeleteStackMiddleware(
+					nil,
+					describeStackEventsResult{events: []types.StackEvent{}},
+					describeStackEventsResult{err: fmt.Errorf("WaitError")},
+				),
 			},
-			want: &ClientError{
+			want: nil,
+			wantErr: &ClientError{
 				ResourceName: aws.String("test"),
-				Err:          fmt.Errorf("expected err to be of type smithy.APIError, got %w", fmt.Errorf("operation error CloudFormation: DescribeStacks, WaitError")),
+				Err:          fmt.Errorf("operation error CloudFormation: DescribeStackEvents, WaitError"),
+			},
+		},
+		{
+			name: "delete stack failure for timeout",
+			args: args{
+				ctx:             context.Background(),
+				stackName:       aws.String("test"),
+				retainResources: []string{"test1", "test2"},
+				deletionTimeout: 10 * time.Millisecond,
+				withAPIOptionsFunc: newDeleteStackMiddleware(
+					nil,
+					describeStackEventsResult{events: []types.StackEvent{}},
+					describeStackEventsResult{events: []types.StackEvent{inProgressEvent}},
+				),
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          &StackDeletionTimeoutError{Timeout: 10 * time.Millisecond},
 			},
-			wantErr: true,
 		},
 	}
 
@@ -235,24 +255,70 @@ func TestCloudFormation_DeleteStack(t *testing.T) {
 			}
 
 			client := cloudformation.NewFromConfig(cfg)
-			cfnWaiter := cloudformation.NewStackDeleteCompleteWaiter(client)
 			cfnClient := NewCloudFormation(
 				client,
-				cfnWaiter,
+				tt.args.deletionTimeout,
 			)
+			cfnClient.pollingInterval = time.Millisecond
 
-			err = cfnClient.DeleteStack(tt.args.ctx, tt.args.stackName, tt.args.retainResources)
-			if (err != nil) != tt.wantErr {
+			got, err := cfnClient.DeleteStack(tt.args.ctx, tt.args.stackName, tt.args.retainResources, tt.args.deletionMode, tt.args.roleArn)
+			if (err != nil) != (tt.wantErr != nil) {
 				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
 				return
 			}
-			if tt.wantErr && err.Error() != tt.want.Error() {
-				t.Errorf("err = %#v, want %#v", err, tt.want)
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
 			}
 		})
 	}
 }
 
+type describeStackEventsResult struct {
+	events []types.StackEvent
+	err    error
+}
+
+// newDeleteStackMiddleware returns the mock of DeleteStack and DescribeStackEvents, which returns the results in
+// order and then repeats the last one.
+func newDeleteStackMiddleware(deleteStackErr error, describeStackEventsResults ...describeStackEventsResult) func(*middleware.Stack) error {
+	count := 0
+
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(
+			middleware.FinalizeMiddlewareFunc(
+				"DeleteStackOrDescribeStackEventsMock",
+				func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+					operationName := awsMiddleware.GetOperationName(ctx)
+					if operationName == "DeleteStack" {
+						return middleware.FinalizeOutput{
+							Result: &cloudformation.DeleteStackOutput{},
+						}, middleware.Metadata{}, deleteStackErr
+					}
+					if operationName == "DescribeStackEvents" {
+						result := describeStackEventsResults[len(describeStackEventsResults)-1]
+						if count < len(describeStackEventsResults) {
+							result = describeStackEventsResults[count]
+						}
+						count++
+
+						return middleware.FinalizeOutput{
+							Result: &cloudformation.DescribeStackEventsOutput{
+								StackEvents: result.events,
+							},
+						}, middleware.Metadata{}, result.err
+					}
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				},
+			),
+			middleware.Before,
+		)
+	}
+}
+
 func TestCloudFormation_DescribeStacks(t *testing.T) {
 	type args struct {
 		ctx                context.Context
@@ -517,10 +583,9 @@ func TestCloudFormation_DescribeStacks(t *testing.T) {
 			}
 
 			client := cloudformation.NewFromConfig(cfg)
-			cfnWaiter := cloudformation.NewStackDeleteCompleteWaiter(client)
 			cfnClient := NewCloudFormation(
 				client,
-				cfnWaiter,
+				DefaultStackDeletionTimeout,
 			)
 
 			output, err := cfnClient.DescribeStacks(tt.args.ctx, tt.args.stackName)
@@ -540,91 +605,113 @@ func TestCloudFormation_DescribeStacks(t *testing.T) {
 	}
 }
 
-func TestCloudFormation_waitDeleteStack(t *testing.T) {
+func TestCloudFormation_WaitStackOperationComplete(t *testing.T) {
 	type args struct {
 		ctx                context.Context
 		stackName          *string
+		deletionTimeout    time.Duration
 		withAPIOptionsFunc func(*middleware.Stack) error
 	}
 
+	inProgressStack := types.Stack{
+		StackName:   aws.String("test"),
+		StackStatus: types.StackStatusUpdateRollbackInProgress,
+	}
+
 	cases := []struct {
 		name    string
 		args    args
-		want    error
-		wantErr bool
+		want    *types.Stack
+		wantErr error
 	}{
 		{
-			name: "wait successfully",
+			name: "wait stack operation complete successfully",
 			args: args{
 				ctx:       context.Background(),
 				stackName: aws.String("test"),
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"DescribeStacksForWaiterMock",
-							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								return middleware.FinalizeOutput{
-									Result: &cloudformation.DescribeStacksOutput{
-										Stacks: []types.Stack{
-											{
-												StackName:   aws.String("StackName"),
-												StackStatus: "DELETE_COMPLETE",
-											},
-										},
-									},
-								}, middleware.Metadata{}, nil
-							},
-						),
-						middleware.Before,
-					)
-				},
+				withAPIOptionsFunc: newDescribeStacksMiddleware(
+					describeStacksResult{stacks: []types.Stack{inProgressStack}},
+					describeStacksResult{stacks: []types.Stack{
+						{
+							StackName:   aws.String("test"),
+							StackStatus: types.StackStatusUpdateRollbackComplete,
+						},
+					}},
+				),
 			},
-			want:    nil,
-			wantErr: false,
+			want: &types.Stack{
+				StackName:   aws.String("test"),
+				StackStatus: types.StackStatusUpdateRollbackComplete,
+			},
+			wantErr: nil,
 		},
 		{
-			name: "wait failure for wait error",
+			name: "wait stack operation complete successfully for deleted stack",
 			args: args{
 				ctx:       context.Background(),
 				stackName: aws.String("test"),
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"DescribeStacksForWaiterErrorMock",
-							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								return middleware.FinalizeOutput{
-									Result: &cloudformation.DescribeStacksOutput{},
-								}, middleware.Metadata{}, fmt.Errorf("WaitError")
-							},
-						),
-						middleware.Before,
-					)
-				},
+				withAPIOptionsFunc: newDescribeStacksMiddleware(
+					describeStacksResult{stacks: []types.Stack{
+						{
+							StackName:   aws.String("test"),
+							StackStatus: types.StackStatusDeleteInProgress,
+						},
+					}},
+					describeStacksResult{err: fmt.Errorf("Stack with id test does not exist")},
+				),
 			},
-			want:    fmt.Errorf("expected err to be of type smithy.APIError, got %w", fmt.Errorf("operation error CloudFormation: DescribeStacks, WaitError")),
-			wantErr: true,
+			want:    nil,
+			wantErr: nil,
 		},
 		{
-			name: "wait failure for transitioned to Failure",
+			name: "wait stack operation complete successfully after throttling",
 			args: args{
 				ctx:       context.Background(),
 				stackName: aws.String("test"),
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"DescribeStacksForWaiterStateTransitionedToFailureMock",
-							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								return middleware.FinalizeOutput{
-									Result: &cloudformation.DescribeStacksOutput{},
-								}, middleware.Metadata{}, fmt.Errorf("waiter state transitioned to Failure")
-							},
-						),
-						middleware.Before,
-					)
-				},
+				withAPIOptionsFunc: newDescribeStacksMiddleware(
+					describeStacksResult{err: fmt.Errorf("api error Throttling: Rate exceeded")},
+					describeStacksResult{stacks: []types.Stack{
+						{
+							StackName:   aws.String("test"),
+							StackStatus: types.StackStatusDeleteComplete,
+						},
+					}},
+				),
 			},
 			want:    nil,
-			wantErr: false,
+			wantErr: nil,
+		},
+		{
+			name: "wait stack operation complete failure",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				withAPIOptionsFunc: newDescribeStacksMiddleware(
+					describeStacksResult{stacks: []types.Stack{inProgressStack}},
+					describeStacksResult{err: fmt.Errorf("DescribeStacksError")},
+				),
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          fmt.Errorf("operation error CloudFormation: DescribeStacks, DescribeStacksError"),
+			},
+		},
+		{
+			name: "wait stack operation complete failure for timeout",
+			args: args{
+				ctx:             context.Background(),
+				stackName:       aws.String("test"),
+				deletionTimeout: 10 * time.Millisecond,
+				withAPIOptionsFunc: newDescribeStacksMiddleware(
+					describeStacksResult{stacks: []types.Stack{inProgressStack}},
+				),
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          &StackDeletionTimeoutError{Timeout: 10 * time.Millisecond},
+			},
 		},
 	}
 
@@ -640,36 +727,116 @@ func TestCloudFormation_waitDeleteStack(t *testing.T) {
 			}
 
 			client := cloudformation.NewFromConfig(cfg)
-			cfnWaiter := cloudformation.NewStackDeleteCompleteWaiter(client)
 			cfnClient := NewCloudFormation(
 				client,
-				cfnWaiter,
+				tt.args.deletionTimeout,
 			)
+			cfnClient.pollingInterval = time.Millisecond
 
-			err = cfnClient.waitDeleteStack(tt.args.ctx, tt.args.stackName)
-			if (err != nil) != tt.wantErr {
+			got, err := cfnClient.WaitStackOperationComplete(tt.args.ctx, tt.args.stackName)
+			if (err != nil) != (tt.wantErr != nil) {
 				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
 				return
 			}
-			if tt.wantErr && err.Error() != tt.want.Error() {
-				t.Errorf("err = %#v, want %#v", err, tt.want)
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestCloudFormation_ListStackResources(t *testing.T) {
+type describeStacksResult struct {
+	stacks []types.Stack
+	err    error
+}
+
+// newDescribeStacksMiddleware returns the mock of DescribeStacks, which returns the results in order and then
+// repeats the last one.
+func newDescribeStacksMiddleware(describeStacksResults ...describeStacksResult) func(*middleware.Stack) error {
+	count := 0
+
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(
+			middleware.FinalizeMiddlewareFunc(
+				"DescribeStacksMock",
+				func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+					result := describeStacksResults[len(describeStacksResults)-1]
+					if count < len(describeStacksResults) {
+						result = describeStacksResults[count]
+					}
+					count++
+
+					return middleware.FinalizeOutput{
+						Result: &cloudformation.DescribeStacksOutput{
+							Stacks: result.stacks,
+						},
+					}, middleware.Metadata{}, result.err
+				},
+			),
+			middleware.Before,
+		)
+	}
+}
+
+func TestCloudFormation_DescribeStackEvents(t *testing.T) {
 	type args struct {
 		ctx                context.Context
 		stackName          *string
+		lastEventId        string
 		withAPIOptionsFunc func(*middleware.Stack) error
 	}
 
 	type want struct {
-		output []types.StackResourceSummary
+		output []types.StackEvent
 		err    error
 	}
 
+	withPagesFunc := func(stack *middleware.Stack) error {
+		err := stack.Initialize.Add(
+			middleware.InitializeMiddlewareFunc(
+				"GetNextToken",
+				getNextTokenForCloudFormationInitialize,
+			), middleware.Before,
+		)
+		if err != nil {
+			return err
+		}
+
+		return stack.Finalize.Add(
+			middleware.FinalizeMiddlewareFunc(
+				"DescribeStackEventsWithNextTokenMock",
+				func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+					token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
+
+					if token == nil {
+						return middleware.FinalizeOutput{
+							Result: &cloudformation.DescribeStackEventsOutput{
+								NextToken: aws.String("NextToken"),
+								StackEvents: []types.StackEvent{
+									{EventId: aws.String("EventId4")},
+									{EventId: aws.String("EventId3")},
+								},
+							},
+						}, middleware.Metadata{}, nil
+					}
+					return middleware.FinalizeOutput{
+						Result: &cloudformation.DescribeStackEventsOutput{
+							StackEvents: []types.StackEvent{
+								{EventId: aws.String("EventId2")},
+								{EventId: aws.String("EventId1")},
+							},
+						},
+					}, middleware.Metadata{}, nil
+				},
+			),
+			middleware.Before,
+		)
+	}
+
 	cases := []struct {
 		name    string
 		args    args
@@ -677,295 +844,106 @@ func TestCloudFormation_ListStackResources(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "list stack resources successfully",
+			name: "describe stack events newer than the last event successfully",
 			args: args{
-				ctx:       context.Background(),
-				stackName: aws.String("test"),
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"ListStackResourcesMock",
-							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								return middleware.FinalizeOutput{
-									Result: &cloudformation.ListStackResourcesOutput{
-										StackResourceSummaries: []types.StackResourceSummary{
-											{
-												LogicalResourceId:  aws.String("LogicalResourceId1"),
-												ResourceStatus:     "DELETE_FAILED",
-												ResourceType:       aws.String("AWS::CloudFormation::Stack"),
-												PhysicalResourceId: aws.String("PhysicalResourceId1"),
-											},
-											{
-												LogicalResourceId:  aws.String("LogicalResourceId2"),
-												ResourceStatus:     "DELETE_FAILED",
-												ResourceType:       aws.String("AWS::S3::Bucket"),
-												PhysicalResourceId: aws.String("PhysicalResourceId2"),
-											},
-										},
-									},
-								}, middleware.Metadata{}, nil
-							},
-						),
-						middleware.Before,
-					)
-				},
+				ctx:                context.Background(),
+				stackName:          aws.String("test"),
+				lastEventId:        "EventId2",
+				withAPIOptionsFunc: withPagesFunc,
 			},
 			want: want{
-				output: []types.StackResourceSummary{
-					{
-						LogicalResourceId:  aws.String("LogicalResourceId1"),
-						ResourceStatus:     "DELETE_FAILED",
-						ResourceType:       aws.String("AWS::CloudFormation::Stack"),
-						PhysicalResourceId: aws.String("PhysicalResourceId1"),
-					},
-					{
-						LogicalResourceId:  aws.String("LogicalResourceId2"),
-						ResourceStatus:     "DELETE_FAILED",
-						ResourceType:       aws.String("AWS::S3::Bucket"),
-						PhysicalResourceId: aws.String("PhysicalResourceId2"),
-					},
+				output: []types.StackEvent{
+					{EventId: aws.String("EventId4")},
+					{EventId: aws.String("EventId3")},
 				},
 				err: nil,
 			},
 			wantErr: false,
 		},
 		{
-			name: "list stack resources failure",
+			name: "describe stack events newer than the last event in the first page successfully",
 			args: args{
-				ctx:       context.Background(),
-				stackName: aws.String("test"),
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"ListStackResourcesErrorMock",
-							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								return middleware.FinalizeOutput{
-									Result: &cloudformation.ListStackResourcesOutput{},
-								}, middleware.Metadata{}, fmt.Errorf("ListStackResourcesError")
-							},
-						),
-						middleware.Before,
-					)
-				},
+				ctx:                context.Background(),
+				stackName:          aws.String("test"),
+				lastEventId:        "EventId3",
+				withAPIOptionsFunc: withPagesFunc,
 			},
 			want: want{
-				output: []types.StackResourceSummary{},
-				err: &ClientError{
-					ResourceName: aws.String("test"),
-					Err:          fmt.Errorf("operation error CloudFormation: ListStackResources, ListStackResourcesError"),
+				output: []types.StackEvent{
+					{EventId: aws.String("EventId4")},
 				},
+				err: nil,
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "list stack resources but not exist",
+			name: "describe stack events in the first page without the last event successfully",
 			args: args{
-				ctx:       context.Background(),
-				stackName: aws.String("test"),
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"ListStackResourcesMock",
-							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								return middleware.FinalizeOutput{
-									Result: &cloudformation.ListStackResourcesOutput{
-										StackResourceSummaries: []types.StackResourceSummary{},
-									},
-								}, middleware.Metadata{}, nil
-							},
-						),
-						middleware.Before,
-					)
-				},
+				ctx:                context.Background(),
+				stackName:          aws.String("test"),
+				lastEventId:        "",
+				withAPIOptionsFunc: withPagesFunc,
 			},
 			want: want{
-				output: []types.StackResourceSummary{},
-				err:    nil,
+				output: []types.StackEvent{
+					{EventId: aws.String("EventId4")},
+					{EventId: aws.String("EventId3")},
+				},
+				err: nil,
 			},
 			wantErr: false,
 		},
 		{
-			name: "list stack resources with next token successfully",
+			name: "describe stack events but not exist successfully",
 			args: args{
-				ctx:       context.Background(),
-				stackName: aws.String("test"),
+				ctx:         context.Background(),
+				stackName:   aws.String("test"),
+				lastEventId: "EventId1",
 				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					err := stack.Initialize.Add(
-						middleware.InitializeMiddlewareFunc(
-							"GetNextToken",
-							getNextTokenForCloudFormationInitialize,
-						), middleware.Before,
-					)
-					if err != nil {
-						return err
-					}
-
-					err = stack.Finalize.Add(
+					return stack.Finalize.Add(
 						middleware.FinalizeMiddlewareFunc(
-							"ListStackResourcesWithNextTokenMock",
-							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
-
-								var nextToken *string
-								var stackResourceSummaries []types.StackResourceSummary
-								if token == nil {
-									nextToken = aws.String("NextToken")
-									stackResourceSummaries = []types.StackResourceSummary{
-										{
-											LogicalResourceId:  aws.String("LogicalResourceId1"),
-											ResourceStatus:     "DELETE_FAILED",
-											ResourceType:       aws.String("AWS::CloudFormation::Stack"),
-											PhysicalResourceId: aws.String("PhysicalResourceId1"),
-										},
-										{
-											LogicalResourceId:  aws.String("LogicalResourceId2"),
-											ResourceStatus:     "DELETE_FAILED",
-											ResourceType:       aws.String("AWS::S3::Bucket"),
-											PhysicalResourceId: aws.String("PhysicalResourceId2"),
-										},
-									}
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.ListStackResourcesOutput{
-											NextToken:              nextToken,
-											StackResourceSummaries: stackResourceSummaries,
-										},
-									}, middleware.Metadata{}, nil
-								} else {
-									stackResourceSummaries = []types.StackResourceSummary{
-										{
-											LogicalResourceId:  aws.String("LogicalResourceId3"),
-											ResourceStatus:     "DELETE_FAILED",
-											ResourceType:       aws.String("AWS::CloudFormation::Stack"),
-											PhysicalResourceId: aws.String("PhysicalResourceId3"),
-										},
-										{
-											LogicalResourceId:  aws.String("LogicalResourceId4"),
-											ResourceStatus:     "DELETE_FAILED",
-											ResourceType:       aws.String("AWS::S3::Bucket"),
-											PhysicalResourceId: aws.String("PhysicalResourceId4"),
-										},
-									}
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.ListStackResourcesOutput{
-											NextToken:              nextToken,
-											StackResourceSummaries: stackResourceSummaries,
-										},
-									}, middleware.Metadata{}, nil
-								}
+							"DescribeStackEventsNotExistsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.DescribeStackEventsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("Stack [test] does not exist")
 							},
 						),
 						middleware.Before,
 					)
-					return err
 				},
 			},
 			want: want{
-				output: []types.StackResourceSummary{
-					{
-						LogicalResourceId:  aws.String("LogicalResourceId1"),
-						ResourceStatus:     "DELETE_FAILED",
-						ResourceType:       aws.String("AWS::CloudFormation::Stack"),
-						PhysicalResourceId: aws.String("PhysicalResourceId1"),
-					},
-					{
-						LogicalResourceId:  aws.String("LogicalResourceId2"),
-						ResourceStatus:     "DELETE_FAILED",
-						ResourceType:       aws.String("AWS::S3::Bucket"),
-						PhysicalResourceId: aws.String("PhysicalResourceId2"),
-					},
-					{
-						LogicalResourceId:  aws.String("LogicalResourceId3"),
-						ResourceStatus:     "DELETE_FAILED",
-						ResourceType:       aws.String("AWS::CloudFormation::Stack"),
-						PhysicalResourceId: aws.String("PhysicalResourceId3"),
-					},
-					{
-						LogicalResourceId:  aws.String("LogicalResourceId4"),
-						ResourceStatus:     "DELETE_FAILED",
-						ResourceType:       aws.String("AWS::S3::Bucket"),
-						PhysicalResourceId: aws.String("PhysicalResourceId4"),
-					},
-				},
-				err: nil,
+				output: []types.StackEvent{},
+				err:    nil,
 			},
 			wantErr: false,
 		},
 		{
-			name: "list stack resources with next token failure",
+			name: "describe stack events failure",
 			args: args{
-				ctx:       context.Background(),
-				stackName: aws.String("test"),
+				ctx:         context.Background(),
+				stackName:   aws.String("test"),
+				lastEventId: "EventId1",
 				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					err := stack.Initialize.Add(
-						middleware.InitializeMiddlewareFunc(
-							"GetNextToken",
-							getNextTokenForCloudFormationInitialize,
-						), middleware.Before,
-					)
-					if err != nil {
-						return err
-					}
-
-					err = stack.Finalize.Add(
+					return stack.Finalize.Add(
 						middleware.FinalizeMiddlewareFunc(
-							"ListStackResourcesWithNextTokenErrorMock",
-							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
-
-								var nextToken *string
-								var stackResourceSummaries []types.StackResourceSummary
-								if token == nil {
-									nextToken = aws.String("NextToken")
-									stackResourceSummaries = []types.StackResourceSummary{
-										{
-											LogicalResourceId:  aws.String("LogicalResourceId1"),
-											ResourceStatus:     "DELETE_FAILED",
-											ResourceType:       aws.String("AWS::CloudFormation::Stack"),
-											PhysicalResourceId: aws.String("PhysicalResourceId1"),
-										},
-										{
-											LogicalResourceId:  aws.String("LogicalResourceId2"),
-											ResourceStatus:     "DELETE_FAILED",
-											ResourceType:       aws.String("AWS::S3::Bucket"),
-											PhysicalResourceId: aws.String("PhysicalResourceId2"),
-										},
-									}
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.ListStackResourcesOutput{
-											NextToken:              nextToken,
-											StackResourceSummaries: stackResourceSummaries,
-										},
-									}, middleware.Metadata{}, nil
-								} else {
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.ListStackResourcesOutput{},
-									}, middleware.Metadata{}, fmt.Errorf("ListStackResourcesError")
-								}
+							"DescribeStackEventsErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.DescribeStackEventsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("DescribeStackEventsError")
 							},
 						),
 						middleware.Before,
 					)
-					return err
 				},
 			},
 			want: want{
-				output: []types.StackResourceSummary{
-					{
-						LogicalResourceId:  aws.String("LogicalResourceId1"),
-						ResourceStatus:     "DELETE_FAILED",
-						ResourceType:       aws.String("AWS::CloudFormation::Stack"),
-						PhysicalResourceId: aws.String("PhysicalResourceId1"),
-					},
-					{
-						LogicalResourceId:  aws.String("LogicalResourceId2"),
-						ResourceStatus:     "DELETE_FAILED",
-						ResourceType:       aws.String("AWS::S3::Bucket"),
-						PhysicalResourceId: aws.String("PhysicalResourceId2"),
-					},
-				},
+				output: []types.StackEvent{},
 				err: &ClientError{
 					ResourceName: aws.String("test"),
-					Err:          fmt.Errorf("operation error CloudFormation: ListStackResources, ListStackResourcesError"),
+					Err:          fmt.Errorf("operation error CloudFormation: DescribeStackEvents, DescribeStackEventsError"),
 				},
 			},
 			wantErr: true,
@@ -984,13 +962,12 @@ func TestCloudFormation_ListStackResources(t *testing.T) {
 			}
 
 			client := cloudformation.NewFromConfig(cfg)
-			cfnWaiter := cloudformation.NewStackDeleteCompleteWaiter(client)
 			cfnClient := NewCloudFormation(
 				client,
-				cfnWaiter,
+				DefaultStackDeletionTimeout,
 			)
 
-			output, err := cfnClient.ListStackResources(tt.args.ctx, tt.args.stackName)
+			output, err := cfnClient.DescribeStackEvents(tt.args.ctx, tt.args.stackName, tt.args.lastEventId)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
 				return
@@ -1006,17 +983,67 @@ func TestCloudFormation_ListStackResources(t *testing.T) {
 	}
 }
 
-func TestCloudFormation_ListStacks(t *testing.T) {
+func TestCloudFormation_DescribeStackEventsUntil(t *testing.T) {
 	type args struct {
 		ctx                context.Context
+		stackName          *string
+		isLast             func(event types.StackEvent) bool
 		withAPIOptionsFunc func(*middleware.Stack) error
 	}
 
 	type want struct {
-		output []types.StackSummary
+		output []types.StackEvent
 		err    error
 	}
 
+	withPagesFunc := func(stack *middleware.Stack) error {
+		err := stack.Initialize.Add(
+			middleware.InitializeMiddlewareFunc(
+				"GetNextToken",
+				getNextTokenForCloudFormationInitialize,
+			), middleware.Before,
+		)
+		if err != nil {
+			return err
+		}
+
+		return stack.Finalize.Add(
+			middleware.FinalizeMiddlewareFunc(
+				"DescribeStackEventsWithNextTokenMock",
+				func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+					token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
+
+					if token == nil {
+						return middleware.FinalizeOutput{
+							Result: &cloudformation.DescribeStackEventsOutput{
+								NextToken: aws.String("NextToken"),
+								StackEvents: []types.StackEvent{
+									{EventId: aws.String("EventId4")},
+									{EventId: aws.String("EventId3")},
+								},
+							},
+						}, middleware.Metadata{}, nil
+					}
+					return middleware.FinalizeOutput{
+						Result: &cloudformation.DescribeStackEventsOutput{
+							StackEvents: []types.StackEvent{
+								{EventId: aws.String("EventId2")},
+								{EventId: aws.String("EventId1")},
+							},
+						},
+					}, middleware.Metadata{}, nil
+				},
+			),
+			middleware.Before,
+		)
+	}
+
+	isEvent := func(eventId string) func(event types.StackEvent) bool {
+		return func(event types.StackEvent) bool {
+			return aws.ToString(event.EventId) == eventId
+		}
+	}
+
 	cases := []struct {
 		name    string
 		args    args
@@ -1024,255 +1051,109 @@ func TestCloudFormation_ListStacks(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "list stacks successfully",
+			name: "describe stack events until the last event in the next page successfully",
 			args: args{
-				ctx: context.Background(),
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"ListStacksMock",
-							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								return middleware.FinalizeOutput{
-									Result: &cloudformation.ListStacksOutput{
-										StackSummaries: []types.StackSummary{
-											{
-												StackName:   aws.String("TestStack1"),
-												StackStatus: types.StackStatusCreateComplete,
-											},
-											{
-												StackName:   aws.String("TestStack2"),
-												StackStatus: types.StackStatusCreateComplete,
-											},
-										},
-									},
-								}, middleware.Metadata{}, nil
-							},
-						),
-						middleware.Before,
-					)
-				},
+				ctx:                context.Background(),
+				stackName:          aws.String("test"),
+				isLast:             isEvent("EventId2"),
+				withAPIOptionsFunc: withPagesFunc,
 			},
 			want: want{
-				output: []types.StackSummary{
-					{
-						StackName:   aws.String("TestStack1"),
-						StackStatus: types.StackStatusCreateComplete,
-					},
-					{
-						StackName:   aws.String("TestStack2"),
-						StackStatus: types.StackStatusCreateComplete,
-					},
+				output: []types.StackEvent{
+					{EventId: aws.String("EventId4")},
+					{EventId: aws.String("EventId3")},
+					{EventId: aws.String("EventId2")},
 				},
 				err: nil,
 			},
 			wantErr: false,
 		},
 		{
-			name: "list stacks but empty successfully",
+			name: "describe stack events until the last event in the first page successfully",
 			args: args{
-				ctx: context.Background(),
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"ListStacksEmptyMock",
-							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								return middleware.FinalizeOutput{
-									Result: &cloudformation.ListStacksOutput{
-										StackSummaries: []types.StackSummary{},
-									},
-								}, middleware.Metadata{}, nil
-							},
-						),
-						middleware.Before,
-					)
-				},
+				ctx:                context.Background(),
+				stackName:          aws.String("test"),
+				isLast:             isEvent("EventId4"),
+				withAPIOptionsFunc: withPagesFunc,
 			},
 			want: want{
-				output: []types.StackSummary{},
-				err:    nil,
+				output: []types.StackEvent{
+					{EventId: aws.String("EventId4")},
+				},
+				err: nil,
 			},
 			wantErr: false,
 		},
 		{
-			name: "list stacks failure",
+			name: "describe all stack events without the last event successfully",
 			args: args{
-				ctx: context.Background(),
-				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					return stack.Finalize.Add(
-						middleware.FinalizeMiddlewareFunc(
-							"ListStacksErrorMock",
-							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								return middleware.FinalizeOutput{
-									Result: &cloudformation.ListStacksOutput{},
-								}, middleware.Metadata{}, fmt.Errorf("ListStacksError")
-							},
-						),
-						middleware.Before,
-					)
-				},
+				ctx:                context.Background(),
+				stackName:          aws.String("test"),
+				isLast:             isEvent("EventId0"),
+				withAPIOptionsFunc: withPagesFunc,
 			},
 			want: want{
-				output: []types.StackSummary{},
-				err: &ClientError{
-					Err: fmt.Errorf("operation error CloudFormation: ListStacks, ListStacksError"),
+				output: []types.StackEvent{
+					{EventId: aws.String("EventId4")},
+					{EventId: aws.String("EventId3")},
+					{EventId: aws.String("EventId2")},
+					{EventId: aws.String("EventId1")},
 				},
+				err: nil,
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 		{
-			name: "list stacks with next token successfully",
+			name: "describe stack events until the last event but not exist successfully",
 			args: args{
-				ctx: context.Background(),
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				isLast:    isEvent("EventId1"),
 				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					err := stack.Initialize.Add(
-						middleware.InitializeMiddlewareFunc(
-							"GetNextToken",
-							getNextTokenForCloudFormationInitialize,
-						), middleware.Before,
-					)
-					if err != nil {
-						return err
-					}
-
-					err = stack.Finalize.Add(
+					return stack.Finalize.Add(
 						middleware.FinalizeMiddlewareFunc(
-							"ListStacksWithNextTokenMock",
-							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
-
-								var nextToken *string
-								var stackSummaries []types.StackSummary
-								if token == nil {
-									nextToken = aws.String("NextToken")
-									stackSummaries = []types.StackSummary{
-										{
-											StackName:   aws.String("TestStack1"),
-											StackStatus: types.StackStatusCreateComplete,
-										},
-										{
-											StackName:   aws.String("TestStack2"),
-											StackStatus: types.StackStatusCreateComplete,
-										},
-									}
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.ListStacksOutput{
-											NextToken:      nextToken,
-											StackSummaries: stackSummaries,
-										},
-									}, middleware.Metadata{}, nil
-								} else {
-									stackSummaries = []types.StackSummary{
-										{
-											StackName:   aws.String("TestStack3"),
-											StackStatus: types.StackStatusCreateComplete,
-										},
-										{
-											StackName:   aws.String("TestStack4"),
-											StackStatus: types.StackStatusCreateComplete,
-										},
-									}
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.ListStacksOutput{
-											NextToken:      nextToken,
-											StackSummaries: stackSummaries,
-										},
-									}, middleware.Metadata{}, nil
-								}
+							"DescribeStackEventsNotExistsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.DescribeStackEventsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("Stack [test] does not exist")
 							},
 						),
 						middleware.Before,
 					)
-					return err
 				},
 			},
 			want: want{
-				output: []types.StackSummary{
-					{
-						StackName:   aws.String("TestStack1"),
-						StackStatus: types.StackStatusCreateComplete,
-					},
-					{
-						StackName:   aws.String("TestStack2"),
-						StackStatus: types.StackStatusCreateComplete,
-					},
-					{
-						StackName:   aws.String("TestStack3"),
-						StackStatus: types.StackStatusCreateComplete,
-					},
-					{
-						StackName:   aws.String("TestStack4"),
-						StackStatus: types.StackStatusCreateComplete,
-					},
-				},
-				err: nil,
+				output: []types.StackEvent{},
+				err:    nil,
 			},
 			wantErr: false,
 		},
 		{
-			name: "list stacks with next token failure",
+			name: "describe stack events until the last event failure",
 			args: args{
-				ctx: context.Background(),
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				isLast:    isEvent("EventId1"),
 				withAPIOptionsFunc: func(stack *middleware.Stack) error {
-					err := stack.Initialize.Add(
-						middleware.InitializeMiddlewareFunc(
-							"GetNextToken",
-							getNextTokenForCloudFormationInitialize,
-						), middleware.Before,
-					)
-					if err != nil {
-						return err
-					}
-
-					err = stack.Finalize.Add(
+					return stack.Finalize.Add(
 						middleware.FinalizeMiddlewareFunc(
-							"ListStacksWithNextTokenErrorMock",
-							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
-								token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
-
-								var nextToken *string
-								var stackSummaries []types.StackSummary
-								if token == nil {
-									nextToken = aws.String("NextToken")
-									stackSummaries = []types.StackSummary{
-										{
-											StackName:   aws.String("TestStack1"),
-											StackStatus: types.StackStatusCreateComplete,
-										},
-										{
-											StackName:   aws.String("TestStack2"),
-											StackStatus: types.StackStatusCreateComplete,
-										},
-									}
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.ListStacksOutput{
-											NextToken:      nextToken,
-											StackSummaries: stackSummaries,
-										},
-									}, middleware.Metadata{}, nil
-								} else {
-									return middleware.FinalizeOutput{
-										Result: &cloudformation.ListStacksOutput{},
-									}, middleware.Metadata{}, fmt.Errorf("ListStacksError")
-								}
+							"DescribeStackEventsErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.DescribeStackEventsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("DescribeStackEventsError")
 							},
 						),
 						middleware.Before,
 					)
-					return err
 				},
 			},
 			want: want{
-				output: []types.StackSummary{
-					{
-						StackName:   aws.String("TestStack1"),
-						StackStatus: types.StackStatusCreateComplete,
-					},
-					{
-						StackName:   aws.String("TestStack2"),
-						StackStatus: types.StackStatusCreateComplete,
-					},
-				}, err: &ClientError{
-					Err: fmt.Errorf("operation error CloudFormation: ListStacks, ListStacksError"),
+				output: []types.StackEvent{},
+				err: &ClientError{
+					ResourceName: aws.String("test"),
+					Err:          fmt.Errorf("operation error CloudFormation: DescribeStackEvents, DescribeStackEventsError"),
 				},
 			},
 			wantErr: true,
@@ -1291,30 +1172,12 @@ func TestCloudFormation_ListStacks(t *testing.T) {
 			}
 
 			client := cloudformation.NewFromConfig(cfg)
-			cfnWaiter := cloudformation.NewStackDeleteCompleteWaiter(client)
 			cfnClient := NewCloudFormation(
 				client,
-				cfnWaiter,
+				DefaultStackDeletionTimeout,
 			)
 
-			// Except StackStatusDeleteComplete and xxInProgress
-			stackStatusFilter := []types.StackStatus{
-				types.StackStatusCreateFailed,
-				types.StackStatusCreateComplete,
-				types.StackStatusRollbackFailed,
-				types.StackStatusRollbackComplete,
-				types.StackStatusDeleteFailed,
-				types.StackStatusUpdateComplete,
-				types.StackStatusUpdateFailed,
-				types.StackStatusUpdateRollbackFailed,
-				types.StackStatusUpdateRollbackComplete,
-				types.StackStatusImportComplete,
-				types.StackStatusImportRollbackInProgress,
-				types.StackStatusImportRollbackFailed,
-				types.StackStatusImportRollbackComplete,
-			}
-
-			output, err := cfnClient.ListStacks(tt.args.ctx, stackStatusFilter)
+			output, err := cfnClient.DescribeStackEventsUntil(tt.args.ctx, tt.args.stackName, tt.args.isLast)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
 				return
@@ -1329,3 +1192,2047 @@ func TestCloudFormation_ListStacks(t *testing.T) {
 		})
 	}
 }
+
+func Test_getStackDeletionResult(t *testing.T) {
+	type args struct {
+		events      []types.StackEvent
+		lastEventId string
+	}
+
+	cases := []struct {
+		name string
+		args args
+		want *DeleteStackResult
+	}{
+		{
+			name: "DELETE_COMPLETE of the stack",
+			args: args{
+				events: []types.StackEvent{
+					{
+						EventId:            aws.String("EventId2"),
+						StackId:            aws.String("StackId"),
+						PhysicalResourceId: aws.String("StackId"),
+						ResourceStatus:     types.ResourceStatusDeleteComplete,
+					},
+					{
+						EventId:            aws.String("EventId1"),
+						StackId:            aws.String("StackId"),
+						PhysicalResourceId: aws.String("StackId"),
+						ResourceStatus:     types.ResourceStatusDeleteInProgress,
+					},
+				},
+				lastEventId: "EventId0",
+			},
+			want: &DeleteStackResult{
+				Status: StackDeletionStatusDeleted,
+			},
+		},
+		{
+			name: "DELETE_FAILED of the stack",
+			args: args{
+				events: []types.StackEvent{
+					{
+						EventId:              aws.String("EventId2"),
+						StackId:              aws.String("StackId"),
+						PhysicalResourceId:   aws.String("StackId"),
+						ResourceStatus:       types.ResourceStatusDeleteFailed,
+						ResourceStatusReason: aws.String("Reason"),
+					},
+				},
+				lastEventId: "EventId1",
+			},
+			want: &DeleteStackResult{
+				Status:       StackDeletionStatusFailed,
+				StatusReason: "Reason",
+			},
+		},
+		{
+			name: "in progress for DELETE_FAILED of a resource",
+			args: args{
+				events: []types.StackEvent{
+					{
+						EventId:            aws.String("EventId2"),
+						StackId:            aws.String("StackId"),
+						PhysicalResourceId: aws.String("BucketName"),
+						ResourceStatus:     types.ResourceStatusDeleteFailed,
+					},
+				},
+				lastEventId: "EventId1",
+			},
+			want: nil,
+		},
+		{
+			name: "in progress for DELETE_FAILED before the last event",
+			args: args{
+				events: []types.StackEvent{
+					{
+						EventId:            aws.String("EventId2"),
+						StackId:            aws.String("StackId"),
+						PhysicalResourceId: aws.String("StackId"),
+						ResourceStatus:     types.ResourceStatusDeleteInProgress,
+					},
+					{
+						EventId:            aws.String("EventId1"),
+						StackId:            aws.String("StackId"),
+						PhysicalResourceId: aws.String("StackId"),
+						ResourceStatus:     types.ResourceStatusDeleteFailed,
+					},
+				},
+				lastEventId: "EventId1",
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getStackDeletionResult(tt.args.events, tt.args.lastEventId)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloudFormation_ContinueUpdateRollback(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		stackName          *string
+		resourcesToSkip    []string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "continue update rollback successfully",
+			args: args{
+				ctx:             context.Background(),
+				stackName:       aws.String("test"),
+				resourcesToSkip: []string{"Resource", "Child.Resource"},
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ContinueUpdateRollbackMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ContinueUpdateRollbackOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "continue update rollback failure",
+			args: args{
+				ctx:             context.Background(),
+				stackName:       aws.String("test"),
+				resourcesToSkip: []string{},
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ContinueUpdateRollbackErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ContinueUpdateRollbackOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("ContinueUpdateRollbackError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          fmt.Errorf("operation error CloudFormation: ContinueUpdateRollback, ContinueUpdateRollbackError"),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				DefaultStackDeletionTimeout,
+			)
+
+			err = cfnClient.ContinueUpdateRollback(tt.args.ctx, tt.args.stackName, tt.args.resourcesToSkip)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestCloudFormation_UpdateTerminationProtection(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		stackName          *string
+		enable             bool
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "update termination protection successfully",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				enable:    false,
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"UpdateTerminationProtectionMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.UpdateTerminationProtectionOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "update termination protection failure",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				enable:    true,
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"UpdateTerminationProtectionErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.UpdateTerminationProtectionOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("UpdateTerminationProtectionError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          fmt.Errorf("operation error CloudFormation: UpdateTerminationProtection, UpdateTerminationProtectionError"),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				DefaultStackDeletionTimeout,
+			)
+
+			err = cfnClient.UpdateTerminationProtection(tt.args.ctx, tt.args.stackName, tt.args.enable)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestCloudFormation_GetTemplate(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		stackName          *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	type want struct {
+		output *string
+		err    error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "get template successfully",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetTemplateMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.GetTemplateOutput{
+										TemplateBody: aws.String("{\"Resources\":{}}"),
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: aws.String("{\"Resources\":{}}"),
+				err:    nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "get no template for not existing stack",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetTemplateNotExistMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.GetTemplateOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("Stack with id test does not exist")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: nil,
+				err:    nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "get template failure",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetTemplateErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.GetTemplateOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("GetTemplateError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: nil,
+				err: &ClientError{
+					ResourceName: aws.String("test"),
+					Err:          fmt.Errorf("operation error CloudFormation: GetTemplate, GetTemplateError"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				DefaultStackDeletionTimeout,
+			)
+
+			output, err := cfnClient.GetTemplate(tt.args.ctx, tt.args.stackName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+			}
+			if !tt.wantErr && !reflect.DeepEqual(output, tt.want.output) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.output)
+			}
+		})
+	}
+}
+
+func TestCloudFormation_ListStackResources(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		stackName          *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	type want struct {
+		output []types.StackResourceSummary
+		err    error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "list stack resources successfully",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStackResourcesMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListStackResourcesOutput{
+										StackResourceSummaries: []types.StackResourceSummary{
+											{
+												LogicalResourceId:  aws.String("LogicalResourceId1"),
+												ResourceStatus:     "DELETE_FAILED",
+												ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+												PhysicalResourceId: aws.String("PhysicalResourceId1"),
+											},
+											{
+												LogicalResourceId:  aws.String("LogicalResourceId2"),
+												ResourceStatus:     "DELETE_FAILED",
+												ResourceType:       aws.String("AWS::S3::Bucket"),
+												PhysicalResourceId: aws.String("PhysicalResourceId2"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.StackResourceSummary{
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId1"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+						PhysicalResourceId: aws.String("PhysicalResourceId1"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId2"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::S3::Bucket"),
+						PhysicalResourceId: aws.String("PhysicalResourceId2"),
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stack resources failure",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStackResourcesErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListStackResourcesOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("ListStackResourcesError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.StackResourceSummary{},
+				err: &ClientError{
+					ResourceName: aws.String("test"),
+					Err:          fmt.Errorf("operation error CloudFormation: ListStackResources, ListStackResourcesError"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "list stack resources but not exist",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStackResourcesMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListStackResourcesOutput{
+										StackResourceSummaries: []types.StackResourceSummary{},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.StackResourceSummary{},
+				err:    nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stack resources with next token successfully",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					err := stack.Initialize.Add(
+						middleware.InitializeMiddlewareFunc(
+							"GetNextToken",
+							getNextTokenForCloudFormationInitialize,
+						), middleware.Before,
+					)
+					if err != nil {
+						return err
+					}
+
+					err = stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStackResourcesWithNextTokenMock",
+							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
+
+								var nextToken *string
+								var stackResourceSummaries []types.StackResourceSummary
+								if token == nil {
+									nextToken = aws.String("NextToken")
+									stackResourceSummaries = []types.StackResourceSummary{
+										{
+											LogicalResourceId:  aws.String("LogicalResourceId1"),
+											ResourceStatus:     "DELETE_FAILED",
+											ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+											PhysicalResourceId: aws.String("PhysicalResourceId1"),
+										},
+										{
+											LogicalResourceId:  aws.String("LogicalResourceId2"),
+											ResourceStatus:     "DELETE_FAILED",
+											ResourceType:       aws.String("AWS::S3::Bucket"),
+											PhysicalResourceId: aws.String("PhysicalResourceId2"),
+										},
+									}
+									return middleware.FinalizeOutput{
+										Result: &cloudformation.ListStackResourcesOutput{
+											NextToken:              nextToken,
+											StackResourceSummaries: stackResourceSummaries,
+										},
+									}, middleware.Metadata{}, nil
+								} else {
+									stackResourceSummaries = []types.StackResourceSummary{
+										{
+											LogicalResourceId:  aws.String("LogicalResourceId3"),
+											ResourceStatus:     "DELETE_FAILED",
+											ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+											PhysicalResourceId: aws.String("PhysicalResourceId3"),
+										},
+										{
+											LogicalResourceId:  aws.String("LogicalResourceId4"),
+											ResourceStatus:     "DELETE_FAILED",
+											ResourceType:       aws.String("AWS::S3::Bucket"),
+											PhysicalResourceId: aws.String("PhysicalResourceId4"),
+										},
+									}
+									return middleware.FinalizeOutput{
+										Result: &cloudformation.ListStackResourcesOutput{
+											NextToken:              nextToken,
+											StackResourceSummaries: stackResourceSummaries,
+										},
+									}, middleware.Metadata{}, nil
+								}
+							},
+						),
+						middleware.Before,
+					)
+					return err
+				},
+			},
+			want: want{
+				output: []types.StackResourceSummary{
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId1"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+						PhysicalResourceId: aws.String("PhysicalResourceId1"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId2"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::S3::Bucket"),
+						PhysicalResourceId: aws.String("PhysicalResourceId2"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId3"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+						PhysicalResourceId: aws.String("PhysicalResourceId3"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId4"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::S3::Bucket"),
+						PhysicalResourceId: aws.String("PhysicalResourceId4"),
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stack resources with next token failure",
+			args: args{
+				ctx:       context.Background(),
+				stackName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					err := stack.Initialize.Add(
+						middleware.InitializeMiddlewareFunc(
+							"GetNextToken",
+							getNextTokenForCloudFormationInitialize,
+						), middleware.Before,
+					)
+					if err != nil {
+						return err
+					}
+
+					err = stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStackResourcesWithNextTokenErrorMock",
+							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
+
+								var nextToken *string
+								var stackResourceSummaries []types.StackResourceSummary
+								if token == nil {
+									nextToken = aws.String("NextToken")
+									stackResourceSummaries = []types.StackResourceSummary{
+										{
+											LogicalResourceId:  aws.String("LogicalResourceId1"),
+											ResourceStatus:     "DELETE_FAILED",
+											ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+											PhysicalResourceId: aws.String("PhysicalResourceId1"),
+										},
+										{
+											LogicalResourceId:  aws.String("LogicalResourceId2"),
+											ResourceStatus:     "DELETE_FAILED",
+											ResourceType:       aws.String("AWS::S3::Bucket"),
+											PhysicalResourceId: aws.String("PhysicalResourceId2"),
+										},
+									}
+									return middleware.FinalizeOutput{
+										Result: &cloudformation.ListStackResourcesOutput{
+											NextToken:              nextToken,
+											StackResourceSummaries: stackResourceSummaries,
+										},
+									}, middleware.Metadata{}, nil
+								} else {
+									return middleware.FinalizeOutput{
+										Result: &cloudformation.ListStackResourcesOutput{},
+									}, middleware.Metadata{}, fmt.Errorf("ListStackResourcesError")
+								}
+							},
+						),
+						middleware.Before,
+					)
+					return err
+				},
+			},
+			want: want{
+				output: []types.StackResourceSummary{
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId1"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::CloudFormation::Stack"),
+						PhysicalResourceId: aws.String("PhysicalResourceId1"),
+					},
+					{
+						LogicalResourceId:  aws.String("LogicalResourceId2"),
+						ResourceStatus:     "DELETE_FAILED",
+						ResourceType:       aws.String("AWS::S3::Bucket"),
+						PhysicalResourceId: aws.String("PhysicalResourceId2"),
+					},
+				},
+				err: &ClientError{
+					ResourceName: aws.String("test"),
+					Err:          fmt.Errorf("operation error CloudFormation: ListStackResources, ListStackResourcesError"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				DefaultStackDeletionTimeout,
+			)
+
+			output, err := cfnClient.ListStackResources(tt.args.ctx, tt.args.stackName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err, tt.want)
+				return
+			}
+			if !reflect.DeepEqual(output, tt.want.output) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.output)
+			}
+		})
+	}
+}
+
+func TestCloudFormation_ListStacks(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	type want struct {
+		output []types.StackSummary
+		err    error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "list stacks successfully",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStacksMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListStacksOutput{
+										StackSummaries: []types.StackSummary{
+											{
+												StackName:   aws.String("TestStack1"),
+												StackStatus: types.StackStatusCreateComplete,
+											},
+											{
+												StackName:   aws.String("TestStack2"),
+												StackStatus: types.StackStatusCreateComplete,
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.StackSummary{
+					{
+						StackName:   aws.String("TestStack1"),
+						StackStatus: types.StackStatusCreateComplete,
+					},
+					{
+						StackName:   aws.String("TestStack2"),
+						StackStatus: types.StackStatusCreateComplete,
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stacks but empty successfully",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStacksEmptyMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListStacksOutput{
+										StackSummaries: []types.StackSummary{},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.StackSummary{},
+				err:    nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stacks failure",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStacksErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListStacksOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("ListStacksError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.StackSummary{},
+				err: &ClientError{
+					Err: fmt.Errorf("operation error CloudFormation: ListStacks, ListStacksError"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "list stacks with next token successfully",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					err := stack.Initialize.Add(
+						middleware.InitializeMiddlewareFunc(
+							"GetNextToken",
+							getNextTokenForCloudFormationInitialize,
+						), middleware.Before,
+					)
+					if err != nil {
+						return err
+					}
+
+					err = stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStacksWithNextTokenMock",
+							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
+
+								var nextToken *string
+								var stackSummaries []types.StackSummary
+								if token == nil {
+									nextToken = aws.String("NextToken")
+									stackSummaries = []types.StackSummary{
+										{
+											StackName:   aws.String("TestStack1"),
+											StackStatus: types.StackStatusCreateComplete,
+										},
+										{
+											StackName:   aws.String("TestStack2"),
+											StackStatus: types.StackStatusCreateComplete,
+										},
+									}
+									return middleware.FinalizeOutput{
+										Result: &cloudformation.ListStacksOutput{
+											NextToken:      nextToken,
+											StackSummaries: stackSummaries,
+										},
+									}, middleware.Metadata{}, nil
+								} else {
+									stackSummaries = []types.StackSummary{
+										{
+											StackName:   aws.String("TestStack3"),
+											StackStatus: types.StackStatusCreateComplete,
+										},
+										{
+											StackName:   aws.String("TestStack4"),
+											StackStatus: types.StackStatusCreateComplete,
+										},
+									}
+									return middleware.FinalizeOutput{
+										Result: &cloudformation.ListStacksOutput{
+											NextToken:      nextToken,
+											StackSummaries: stackSummaries,
+										},
+									}, middleware.Metadata{}, nil
+								}
+							},
+						),
+						middleware.Before,
+					)
+					return err
+				},
+			},
+			want: want{
+				output: []types.StackSummary{
+					{
+						StackName:   aws.String("TestStack1"),
+						StackStatus: types.StackStatusCreateComplete,
+					},
+					{
+						StackName:   aws.String("TestStack2"),
+						StackStatus: types.StackStatusCreateComplete,
+					},
+					{
+						StackName:   aws.String("TestStack3"),
+						StackStatus: types.StackStatusCreateComplete,
+					},
+					{
+						StackName:   aws.String("TestStack4"),
+						StackStatus: types.StackStatusCreateComplete,
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stacks with next token failure",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					err := stack.Initialize.Add(
+						middleware.InitializeMiddlewareFunc(
+							"GetNextToken",
+							getNextTokenForCloudFormationInitialize,
+						), middleware.Before,
+					)
+					if err != nil {
+						return err
+					}
+
+					err = stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStacksWithNextTokenErrorMock",
+							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
+
+								var nextToken *string
+								var stackSummaries []types.StackSummary
+								if token == nil {
+									nextToken = aws.String("NextToken")
+									stackSummaries = []types.StackSummary{
+										{
+											StackName:   aws.String("TestStack1"),
+											StackStatus: types.StackStatusCreateComplete,
+										},
+										{
+											StackName:   aws.String("TestStack2"),
+											StackStatus: types.StackStatusCreateComplete,
+										},
+									}
+									return middleware.FinalizeOutput{
+										Result: &cloudformation.ListStacksOutput{
+											NextToken:      nextToken,
+											StackSummaries: stackSummaries,
+										},
+									}, middleware.Metadata{}, nil
+								} else {
+									return middleware.FinalizeOutput{
+										Result: &cloudformation.ListStacksOutput{},
+									}, middleware.Metadata{}, fmt.Errorf("ListStacksError")
+								}
+							},
+						),
+						middleware.Before,
+					)
+					return err
+				},
+			},
+			want: want{
+				output: []types.StackSummary{
+					{
+						StackName:   aws.String("TestStack1"),
+						StackStatus: types.StackStatusCreateComplete,
+					},
+					{
+						StackName:   aws.String("TestStack2"),
+						StackStatus: types.StackStatusCreateComplete,
+					},
+				}, err: &ClientError{
+					Err: fmt.Errorf("operation error CloudFormation: ListStacks, ListStacksError"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				DefaultStackDeletionTimeout,
+			)
+
+			// Except StackStatusDeleteComplete and xxInProgress
+			stackStatusFilter := []types.StackStatus{
+				types.StackStatusCreateFailed,
+				types.StackStatusCreateComplete,
+				types.StackStatusRollbackFailed,
+				types.StackStatusRollbackComplete,
+				types.StackStatusDeleteFailed,
+				types.StackStatusUpdateComplete,
+				types.StackStatusUpdateFailed,
+				types.StackStatusUpdateRollbackFailed,
+				types.StackStatusUpdateRollbackComplete,
+				types.StackStatusImportComplete,
+				types.StackStatusImportRollbackInProgress,
+				types.StackStatusImportRollbackFailed,
+				types.StackStatusImportRollbackComplete,
+			}
+
+			output, err := cfnClient.ListStacks(tt.args.ctx, stackStatusFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err, tt.want)
+				return
+			}
+			if !reflect.DeepEqual(output, tt.want.output) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.output)
+			}
+		})
+	}
+}
+
+func TestCloudFormation_ListImports(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		exportName         *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	type want struct {
+		output []string
+		err    error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "list imports successfully",
+			args: args{
+				ctx:        context.Background(),
+				exportName: aws.String("ExportName"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListImportsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListImportsOutput{
+										Imports: []string{
+											"TestStack1",
+											"TestStack2",
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []string{
+					"TestStack1",
+					"TestStack2",
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list imports but not imported successfully",
+			args: args{
+				ctx:        context.Background(),
+				exportName: aws.String("ExportName"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListImportsNotImportedMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListImportsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("Export 'ExportName' is not imported by any stack.")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []string{},
+				err:    nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list imports failure",
+			args: args{
+				ctx:        context.Background(),
+				exportName: aws.String("ExportName"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListImportsErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListImportsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("ListImportsError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []string{},
+				err: &ClientError{
+					ResourceName: aws.String("ExportName"),
+					Err:          fmt.Errorf("operation error CloudFormation: ListImports, ListImportsError"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "list imports with next token successfully",
+			args: args{
+				ctx:        context.Background(),
+				exportName: aws.String("ExportName"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					err := stack.Initialize.Add(
+						middleware.InitializeMiddlewareFunc(
+							"GetNextToken",
+							getNextTokenForCloudFormationInitialize,
+						), middleware.Before,
+					)
+					if err != nil {
+						return err
+					}
+
+					err = stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListImportsWithNextTokenMock",
+							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
+
+								if token == nil {
+									return middleware.FinalizeOutput{
+										Result: &cloudformation.ListImportsOutput{
+											NextToken: aws.String("NextToken"),
+											Imports:   []string{"TestStack1"},
+										},
+									}, middleware.Metadata{}, nil
+								}
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListImportsOutput{
+										Imports: []string{"TestStack2"},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+					return err
+				},
+			},
+			want: want{
+				output: []string{
+					"TestStack1",
+					"TestStack2",
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				DefaultStackDeletionTimeout,
+			)
+
+			output, err := cfnClient.ListImports(tt.args.ctx, tt.args.exportName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err, tt.want)
+				return
+			}
+			if !reflect.DeepEqual(output, tt.want.output) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.output)
+			}
+		})
+	}
+}
+
+func TestCloudFormation_ListExports(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	type want struct {
+		output []types.Export
+		err    error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "list exports successfully",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListExportsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListExportsOutput{
+										Exports: []types.Export{
+											{
+												ExportingStackId: aws.String("StackId"),
+												Name:             aws.String("ExportName"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.Export{
+					{
+						ExportingStackId: aws.String("StackId"),
+						Name:             aws.String("ExportName"),
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list exports failure",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListExportsErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListExportsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("ListExportsError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.Export{},
+				err: &ClientError{
+					Err: fmt.Errorf("operation error CloudFormation: ListExports, ListExportsError"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "list exports with next token successfully",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					err := stack.Initialize.Add(
+						middleware.InitializeMiddlewareFunc(
+							"GetNextToken",
+							getNextTokenForCloudFormationInitialize,
+						), middleware.Before,
+					)
+					if err != nil {
+						return err
+					}
+
+					err = stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListExportsWithNextTokenMock",
+							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								token := middleware.GetStackValue(ctx, tokenKeyForCloudFormation{}).(*string)
+
+								if token == nil {
+									return middleware.FinalizeOutput{
+										Result: &cloudformation.ListExportsOutput{
+											NextToken: aws.String("NextToken"),
+											Exports: []types.Export{
+												{
+													ExportingStackId: aws.String("StackId1"),
+													Name:             aws.String("ExportName1"),
+												},
+											},
+										},
+									}, middleware.Metadata{}, nil
+								}
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListExportsOutput{
+										Exports: []types.Export{
+											{
+												ExportingStackId: aws.String("StackId2"),
+												Name:             aws.String("ExportName2"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+					return err
+				},
+			},
+			want: want{
+				output: []types.Export{
+					{
+						ExportingStackId: aws.String("StackId1"),
+						Name:             aws.String("ExportName1"),
+					},
+					{
+						ExportingStackId: aws.String("StackId2"),
+						Name:             aws.String("ExportName2"),
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				DefaultStackDeletionTimeout,
+			)
+
+			output, err := cfnClient.ListExports(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+			}
+			if !reflect.DeepEqual(output, tt.want.output) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.output)
+			}
+		})
+	}
+}
+
+func TestCloudFormation_DescribeStackSet(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		stackSetName       *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	type want struct {
+		output *types.StackSet
+		err    error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "describe stack set successfully",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeStackSetMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.DescribeStackSetOutput{
+										StackSet: &types.StackSet{
+											StackSetName:    aws.String("test"),
+											PermissionModel: types.PermissionModelsSelfManaged,
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: &types.StackSet{
+					StackSetName:    aws.String("test"),
+					PermissionModel: types.PermissionModelsSelfManaged,
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "describe no stack set for not existing stack set",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeStackSetNotExistMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.DescribeStackSetOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("StackSetNotFoundException: StackSet test not found")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: nil,
+				err:    nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "describe stack set failure",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeStackSetErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.DescribeStackSetOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("DescribeStackSetError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: nil,
+				err: &ClientError{
+					ResourceName: aws.String("test"),
+					Err:          fmt.Errorf("operation error CloudFormation: DescribeStackSet, DescribeStackSetError"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				DefaultStackDeletionTimeout,
+			)
+
+			output, err := cfnClient.DescribeStackSet(tt.args.ctx, tt.args.stackSetName, types.CallAsSelf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+			}
+			if !tt.wantErr && !reflect.DeepEqual(output, tt.want.output) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.output)
+			}
+		})
+	}
+}
+
+func TestCloudFormation_ListStackInstances(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		stackSetName       *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	type want struct {
+		output []types.StackInstanceSummary
+		err    error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "list stack instances successfully",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStackInstancesMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListStackInstancesOutput{
+										Summaries: []types.StackInstanceSummary{
+											{
+												Account: aws.String("111111111111"),
+												Region:  aws.String("us-east-1"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.StackInstanceSummary{
+					{
+						Account: aws.String("111111111111"),
+						Region:  aws.String("us-east-1"),
+					},
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list no stack instances for not existing stack set",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStackInstancesNotExistMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListStackInstancesOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("StackSetNotFoundException: StackSet test not found")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.StackInstanceSummary{},
+				err:    nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "list stack instances failure",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"ListStackInstancesErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.ListStackInstancesOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("ListStackInstancesError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []types.StackInstanceSummary{},
+				err: &ClientError{
+					ResourceName: aws.String("test"),
+					Err:          fmt.Errorf("operation error CloudFormation: ListStackInstances, ListStackInstancesError"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				DefaultStackDeletionTimeout,
+			)
+
+			output, err := cfnClient.ListStackInstances(tt.args.ctx, tt.args.stackSetName, types.CallAsSelf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+			}
+			if !tt.wantErr && !reflect.DeepEqual(output, tt.want.output) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.output)
+			}
+		})
+	}
+}
+
+func TestCloudFormation_DeleteStackInstances(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		stackSetName       *string
+		target             StackInstancesTarget
+		deletionTimeout    time.Duration
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    *StackSetOperationResult
+		wantErr error
+	}{
+		{
+			name: "delete stack instances successfully",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				target: StackInstancesTarget{
+					Accounts: []string{"111111111111"},
+					Regions:  []string{"us-east-1"},
+				},
+				withAPIOptionsFunc: newDeleteStackInstancesMiddleware(
+					nil,
+					describeStackSetOperationResult{status: types.StackSetOperationStatusRunning},
+					describeStackSetOperationResult{status: types.StackSetOperationStatusSucceeded},
+				),
+			},
+			want: &StackSetOperationResult{
+				Status: types.StackSetOperationStatusSucceeded,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete stack instances successfully for FAILED operation of organizational units",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				target: StackInstancesTarget{
+					OrganizationalUnitIds: []string{"ou-xxxx-yyyyyyyy"},
+					Regions:               []string{"us-east-1", "us-west-2"},
+				},
+				withAPIOptionsFunc: newDeleteStackInstancesMiddleware(
+					nil,
+					describeStackSetOperationResult{err: fmt.Errorf("api error Throttling: Rate exceeded")},
+					describeStackSetOperationResult{status: types.StackSetOperationStatusFailed, statusReason: aws.String("Reason")},
+				),
+			},
+			want: &StackSetOperationResult{
+				Status:       types.StackSetOperationStatusFailed,
+				StatusReason: "Reason",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete stack instances failure",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				target: StackInstancesTarget{
+					Accounts: []string{"111111111111"},
+					Regions:  []string{"us-east-1"},
+				},
+				withAPIOptionsFunc: newDeleteStackInstancesMiddleware(
+					fmt.Errorf("DeleteStackInstancesError"),
+				),
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          fmt.Errorf("operation error CloudFormation: DeleteStackInstances, DeleteStackInstancesError"),
+			},
+		},
+		{
+			name: "delete stack instances failure for wait errors",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				target: StackInstancesTarget{
+					Accounts: []string{"111111111111"},
+					Regions:  []string{"us-east-1"},
+				},
+				withAPIOptionsFunc: newDeleteStackInstancesMiddleware(
+					nil,
+					describeStackSetOperationResult{err: fmt.Errorf("WaitError")},
+				),
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          fmt.Errorf("operation error CloudFormation: DescribeStackSetOperation, WaitError"),
+			},
+		},
+		{
+			name: "delete stack instances failure for timeout",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				target: StackInstancesTarget{
+					Accounts: []string{"111111111111"},
+					Regions:  []string{"us-east-1"},
+				},
+				deletionTimeout: 10 * time.Millisecond,
+				withAPIOptionsFunc: newDeleteStackInstancesMiddleware(
+					nil,
+					describeStackSetOperationResult{status: types.StackSetOperationStatusRunning},
+				),
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          &StackDeletionTimeoutError{Timeout: 10 * time.Millisecond},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				tt.args.deletionTimeout,
+			)
+			cfnClient.pollingInterval = time.Millisecond
+
+			got, err := cfnClient.DeleteStackInstances(tt.args.ctx, tt.args.stackSetName, tt.args.target, nil, types.CallAsSelf)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+type describeStackSetOperationResult struct {
+	status       types.StackSetOperationStatus
+	statusReason *string
+	err          error
+}
+
+// newDeleteStackInstancesMiddleware returns the mock of DeleteStackInstances and DescribeStackSetOperation, which
+// returns the results in order and then repeats the last one.
+func newDeleteStackInstancesMiddleware(deleteStackInstancesErr error, describeStackSetOperationResults ...describeStackSetOperationResult) func(*middleware.Stack) error {
+	count := 0
+
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(
+			middleware.FinalizeMiddlewareFunc(
+				"DeleteStackInstancesOrDescribeStackSetOperationMock",
+				func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+					operationName := awsMiddleware.GetOperationName(ctx)
+					if operationName == "DeleteStackInstances" {
+						return middleware.FinalizeOutput{
+							Result: &cloudformation.DeleteStackInstancesOutput{
+								OperationId: aws.String("OperationId"),
+							},
+						}, middleware.Metadata{}, deleteStackInstancesErr
+					}
+					if operationName == "DescribeStackSetOperation" {
+						result := describeStackSetOperationResults[len(describeStackSetOperationResults)-1]
+						if count < len(describeStackSetOperationResults) {
+							result = describeStackSetOperationResults[count]
+						}
+						count++
+
+						return middleware.FinalizeOutput{
+							Result: &cloudformation.DescribeStackSetOperationOutput{
+								StackSetOperation: &types.StackSetOperation{
+									OperationId:  aws.String("OperationId"),
+									Status:       result.status,
+									StatusReason: result.statusReason,
+								},
+							},
+						}, middleware.Metadata{}, result.err
+					}
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, nil
+				},
+			),
+			middleware.Before,
+		)
+	}
+}
+
+func TestCloudFormation_DeleteStackSet(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		stackSetName       *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "delete stack set successfully",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DeleteStackSetMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.DeleteStackSetOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete stack set successfully for not existing stack set",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DeleteStackSetNotExistMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.DeleteStackSetOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("StackSetNotFoundException: StackSet test not found")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete stack set failure",
+			args: args{
+				ctx:          context.Background(),
+				stackSetName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DeleteStackSetErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &cloudformation.DeleteStackSetOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("OperationInProgressException")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: &ClientError{
+				ResourceName: aws.String("test"),
+				Err:          fmt.Errorf("operation error CloudFormation: DeleteStackSet, OperationInProgressException"),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := cloudformation.NewFromConfig(cfg)
+			cfnClient := NewCloudFormation(
+				client,
+				DefaultStackDeletionTimeout,
+			)
+
+			err = cfnClient.DeleteStackSet(tt.args.ctx, tt.args.stackSetName, types.CallAsSelf)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,410 @@
+//go:generate mockgen -source=$GOFILE -destination=ec2_mock.go -package=$GOPACKAGE -write_package_comment=false
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+const NetworkInterfacesPollingInterval = 10 * time.Second
+
+type IEc2 interface {
+	DescribeRegions(ctx context.Context) ([]string, error)
+	CheckSecurityGroupExists(ctx context.Context, groupId *string) (bool, error)
+	DeleteSecurityGroup(ctx context.Context, groupId *string) error
+	DescribeReferencingSecurityGroupRules(ctx context.Context, groupId *string) ([]types.SecurityGroupRule, error)
+	RevokeSecurityGroupRules(ctx context.Context, groupId *string, securityGroupRuleIds []string, isEgress bool) error
+	DescribeNetworkInterfaces(ctx context.Context, groupId *string) ([]types.NetworkInterface, error)
+	DescribeInstanceState(ctx context.Context, instanceId *string) (types.InstanceStateName, error)
+	DetachNetworkInterface(ctx context.Context, attachmentId *string) error
+	DeleteNetworkInterface(ctx context.Context, networkInterfaceId *string) error
+	WaitNetworkInterfacesAvailable(ctx context.Context, groupId *string) ([]types.NetworkInterface, error)
+}
+
+var _ IEc2 = (*Ec2)(nil)
+
+type Ec2 struct {
+	client          *ec2.Client
+	deletionTimeout time.Duration
+	pollingInterval time.Duration
+}
+
+// NewEc2 returns the client waiting for the network interfaces to be released up to deletionTimeout, or
+// DefaultStackDeletionTimeout if it is not positive.
+func NewEc2(client *ec2.Client, deletionTimeout time.Duration) *Ec2 {
+	if deletionTimeout <= 0 {
+		deletionTimeout = DefaultStackDeletionTimeout
+	}
+	return &Ec2{
+		client:          client,
+		deletionTimeout: deletionTimeout,
+		pollingInterval: NetworkInterfacesPollingInterval,
+	}
+}
+
+// DescribeRegions returns the regions enabled for the account.
+func (e *Ec2) DescribeRegions(ctx context.Context) ([]string, error) {
+	regions := []string{}
+
+	input := &ec2.DescribeRegionsInput{
+		AllRegions: aws.Bool(false),
+	}
+
+	output, err := e.client.DescribeRegions(ctx, input)
+	if err != nil {
+		return regions, &ClientError{
+			Err: err,
+		}
+	}
+
+	for _, region := range output.Regions {
+		regions = append(regions, aws.ToString(region.RegionName))
+	}
+
+	return regions, nil
+}
+
+func (e *Ec2) CheckSecurityGroupExists(ctx context.Context, groupId *string) (bool, error) {
+	input := &ec2.DescribeSecurityGroupsInput{
+		GroupIds: []string{
+			*groupId,
+		},
+	}
+
+	output, err := e.client.DescribeSecurityGroups(ctx, input)
+	if err != nil && strings.Contains(err.Error(), "InvalidGroup.NotFound") {
+		return false, nil
+	}
+	if err != nil {
+		return false, &ClientError{
+			ResourceName: groupId,
+			Err:          err,
+		}
+	}
+
+	return len(output.SecurityGroups) > 0, nil
+}
+
+func (e *Ec2) DeleteSecurityGroup(ctx context.Context, groupId *string) error {
+	input := &ec2.DeleteSecurityGroupInput{
+		GroupId: groupId,
+	}
+
+	_, err := e.client.DeleteSecurityGroup(ctx, input)
+	if err != nil && strings.Contains(err.Error(), "InvalidGroup.NotFound") {
+		return nil
+	}
+	if err != nil {
+		return &ClientError{
+			ResourceName: groupId,
+			Err:          err,
+		}
+	}
+
+	return nil
+}
+
+// DescribeReferencingSecurityGroupRules returns the rules of the other security groups that reference the
+// security group. The rules of the security group itself are deleted together with it, so they are not returned.
+func (e *Ec2) DescribeReferencingSecurityGroupRules(ctx context.Context, groupId *string) ([]types.SecurityGroupRule, error) {
+	referencingGroupIds := []string{}
+	seen := map[string]bool{*groupId: true}
+	// the filters of different names are ANDed, so the ingress and the egress rules are described separately
+	for _, filterName := range []string{"ip-permission.group-id", "egress.ip-permission.group-id"} {
+		groupIds, err := e.describeSecurityGroupIds(ctx, groupId, filterName)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range groupIds {
+			if !seen[id] {
+				seen[id] = true
+				referencingGroupIds = append(referencingGroupIds, id)
+			}
+		}
+	}
+	if len(referencingGroupIds) == 0 {
+		return []types.SecurityGroupRule{}, nil
+	}
+
+	rules := []types.SecurityGroupRule{}
+	var nextToken *string
+
+	for {
+		select {
+		case <-ctx.Done():
+			return rules, &ClientError{
+				ResourceName: groupId,
+				Err:          ctx.Err(),
+			}
+		default:
+		}
+
+		input := &ec2.DescribeSecurityGroupRulesInput{
+			Filters: []types.Filter{
+				{
+					Name:   aws.String("group-id"),
+					Values: referencingGroupIds,
+				},
+			},
+			NextToken: nextToken,
+		}
+
+		output, err := e.client.DescribeSecurityGroupRules(ctx, input)
+		if err != nil {
+			return rules, &ClientError{
+				ResourceName: groupId,
+				Err:          err,
+			}
+		}
+
+		for _, rule := range output.SecurityGroupRules {
+			if rule.ReferencedGroupInfo != nil && aws.ToString(rule.ReferencedGroupInfo.GroupId) == *groupId {
+				rules = append(rules, rule)
+			}
+		}
+
+		nextToken = output.NextToken
+
+		if nextToken == nil {
+			break
+		}
+	}
+
+	return rules, nil
+}
+
+func (e *Ec2) describeSecurityGroupIds(ctx context.Context, groupId *string, filterName string) ([]string, error) {
+	groupIds := []string{}
+	var nextToken *string
+
+	for {
+		select {
+		case <-ctx.Done():
+			return groupIds, &ClientError{
+				ResourceName: groupId,
+				Err:          ctx.Err(),
+			}
+		default:
+		}
+
+		input := &ec2.DescribeSecurityGroupsInput{
+			Filters: []types.Filter{
+				{
+					Name:   aws.String(filterName),
+					Values: []string{*groupId},
+				},
+			},
+			NextToken: nextToken,
+		}
+
+		output, err := e.client.DescribeSecurityGroups(ctx, input)
+		if err != nil {
+			return groupIds, &ClientError{
+				ResourceName: groupId,
+				Err:          err,
+			}
+		}
+
+		for _, securityGroup := range output.SecurityGroups {
+			groupIds = append(groupIds, aws.ToString(securityGroup.GroupId))
+		}
+
+		nextToken = output.NextToken
+
+		if nextToken == nil {
+			break
+		}
+	}
+
+	return groupIds, nil
+}
+
+// RevokeSecurityGroupRules revokes the ingress (or egress if isEgress) rules of the security group.
+func (e *Ec2) RevokeSecurityGroupRules(ctx context.Context, groupId *string, securityGroupRuleIds []string, isEgress bool) error {
+	var err error
+	if isEgress {
+		input := &ec2.RevokeSecurityGroupEgressInput{
+			GroupId:              groupId,
+			SecurityGroupRuleIds: securityGroupRuleIds,
+		}
+		_, err = e.client.RevokeSecurityGroupEgress(ctx, input)
+	} else {
+		input := &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:              groupId,
+			SecurityGroupRuleIds: securityGroupRuleIds,
+		}
+		_, err = e.client.RevokeSecurityGroupIngress(ctx, input)
+	}
+	if err != nil {
+		return &ClientError{
+			ResourceName: groupId,
+			Err:          err,
+		}
+	}
+
+	return nil
+}
+
+// DescribeNetworkInterfaces returns the network interfaces using the security group.
+func (e *Ec2) DescribeNetworkInterfaces(ctx context.Context, groupId *string) ([]types.NetworkInterface, error) {
+	networkInterfaces, err := e.describeNetworkInterfaces(ctx, groupId)
+	if err != nil {
+		return networkInterfaces, &ClientError{
+			ResourceName: groupId,
+			Err:          err,
+		}
+	}
+	return networkInterfaces, nil
+}
+
+func (e *Ec2) describeNetworkInterfaces(ctx context.Context, groupId *string) ([]types.NetworkInterface, error) {
+	networkInterfaces := []types.NetworkInterface{}
+	var nextToken *string
+
+	for {
+		select {
+		case <-ctx.Done():
+			return networkInterfaces, ctx.Err()
+		default:
+		}
+
+		input := &ec2.DescribeNetworkInterfacesInput{
+			Filters: []types.Filter{
+				{
+					Name:   aws.String("group-id"),
+					Values: []string{*groupId},
+				},
+			},
+			NextToken: nextToken,
+		}
+
+		output, err := e.client.DescribeNetworkInterfaces(ctx, input)
+		if err != nil {
+			return networkInterfaces, err // return non wrapping error because wrap in public callers
+		}
+
+		networkInterfaces = append(networkInterfaces, output.NetworkInterfaces...)
+
+		nextToken = output.NextToken
+
+		if nextToken == nil {
+			break
+		}
+	}
+
+	return networkInterfaces, nil
+}
+
+// DescribeInstanceState returns the state of the instance, or terminated if the instance no longer exists.
+func (e *Ec2) DescribeInstanceState(ctx context.Context, instanceId *string) (types.InstanceStateName, error) {
+	input := &ec2.DescribeInstancesInput{
+		InstanceIds: []string{*instanceId},
+	}
+
+	output, err := e.client.DescribeInstances(ctx, input)
+	if err != nil && strings.Contains(err.Error(), "InvalidInstanceID.NotFound") {
+		return types.InstanceStateNameTerminated, nil
+	}
+	if err != nil {
+		return "", &ClientError{
+			ResourceName: instanceId,
+			Err:          err,
+		}
+	}
+
+	for _, reservation := range output.Reservations {
+		for _, instance := range reservation.Instances {
+			if instance.State != nil {
+				return instance.State.Name, nil
+			}
+		}
+	}
+	return types.InstanceStateNameTerminated, nil
+}
+
+func (e *Ec2) DetachNetworkInterface(ctx context.Context, attachmentId *string) error {
+	input := &ec2.DetachNetworkInterfaceInput{
+		AttachmentId: attachmentId,
+	}
+
+	_, err := e.client.DetachNetworkInterface(ctx, input)
+	if err != nil && strings.Contains(err.Error(), "InvalidAttachmentID.NotFound") {
+		return nil
+	}
+	if err != nil {
+		return &ClientError{
+			ResourceName: attachmentId,
+			Err:          err,
+		}
+	}
+
+	return nil
+}
+
+func (e *Ec2) DeleteNetworkInterface(ctx context.Context, networkInterfaceId *string) error {
+	input := &ec2.DeleteNetworkInterfaceInput{
+		NetworkInterfaceId: networkInterfaceId,
+	}
+
+	_, err := e.client.DeleteNetworkInterface(ctx, input)
+	if err != nil && strings.Contains(err.Error(), "InvalidNetworkInterfaceID.NotFound") {
+		return nil
+	}
+	if err != nil {
+		return &ClientError{
+			ResourceName: networkInterfaceId,
+			Err:          err,
+		}
+	}
+
+	return nil
+}
+
+// WaitNetworkInterfacesAvailable waits until all the network interfaces using the security group are detached,
+// e.g. the ones of Lambda functions released some time after the functions are deleted, and returns them.
+// It returns ResourceDeletionTimeoutError if they are not detached within the timeout.
+func (e *Ec2) WaitNetworkInterfacesAvailable(ctx context.Context, groupId *string) ([]types.NetworkInterface, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, e.deletionTimeout)
+	defer cancel()
+
+	for {
+		networkInterfaces, err := e.describeNetworkInterfaces(waitCtx, groupId)
+		if err != nil && waitCtx.Err() == nil {
+			return nil, &ClientError{
+				ResourceName: groupId,
+				Err:          err,
+			}
+		}
+		if err == nil && areNetworkInterfacesAvailable(networkInterfaces) {
+			return networkInterfaces, nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, &ClientError{
+				ResourceName: groupId,
+				Err: &ResourceDeletionTimeoutError{
+					Timeout: e.deletionTimeout,
+				},
+			}
+		case <-time.After(e.pollingInterval):
+		}
+	}
+}
+
+func areNetworkInterfacesAvailable(networkInterfaces []types.NetworkInterface) bool {
+	for _, networkInterface := range networkInterfaces {
+		if networkInterface.Status != types.NetworkInterfaceStatusAvailable {
+			return false
+		}
+	}
+	return true
+}
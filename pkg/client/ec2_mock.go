@@ -0,0 +1,181 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ec2.go
+
+package client
+
+import (
+	context "context"
+	reflect "reflect"
+
+	types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIEc2 is a mock of IEc2 interface.
+type MockIEc2 struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEc2MockRecorder
+}
+
+// MockIEc2MockRecorder is the mock recorder for MockIEc2.
+type MockIEc2MockRecorder struct {
+	mock *MockIEc2
+}
+
+// NewMockIEc2 creates a new mock instance.
+func NewMockIEc2(ctrl *gomock.Controller) *MockIEc2 {
+	mock := &MockIEc2{ctrl: ctrl}
+	mock.recorder = &MockIEc2MockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEc2) EXPECT() *MockIEc2MockRecorder {
+	return m.recorder
+}
+
+// CheckSecurityGroupExists mocks base method.
+func (m *MockIEc2) CheckSecurityGroupExists(ctx context.Context, groupId *string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSecurityGroupExists", ctx, groupId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckSecurityGroupExists indicates an expected call of CheckSecurityGroupExists.
+func (mr *MockIEc2MockRecorder) CheckSecurityGroupExists(ctx, groupId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSecurityGroupExists", reflect.TypeOf((*MockIEc2)(nil).CheckSecurityGroupExists), ctx, groupId)
+}
+
+// DeleteNetworkInterface mocks base method.
+func (m *MockIEc2) DeleteNetworkInterface(ctx context.Context, networkInterfaceId *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNetworkInterface", ctx, networkInterfaceId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNetworkInterface indicates an expected call of DeleteNetworkInterface.
+func (mr *MockIEc2MockRecorder) DeleteNetworkInterface(ctx, networkInterfaceId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNetworkInterface", reflect.TypeOf((*MockIEc2)(nil).DeleteNetworkInterface), ctx, networkInterfaceId)
+}
+
+// DeleteSecurityGroup mocks base method.
+func (m *MockIEc2) DeleteSecurityGroup(ctx context.Context, groupId *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSecurityGroup", ctx, groupId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSecurityGroup indicates an expected call of DeleteSecurityGroup.
+func (mr *MockIEc2MockRecorder) DeleteSecurityGroup(ctx, groupId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSecurityGroup", reflect.TypeOf((*MockIEc2)(nil).DeleteSecurityGroup), ctx, groupId)
+}
+
+// DescribeInstanceState mocks base method.
+func (m *MockIEc2) DescribeInstanceState(ctx context.Context, instanceId *string) (types.InstanceStateName, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeInstanceState", ctx, instanceId)
+	ret0, _ := ret[0].(types.InstanceStateName)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeInstanceState indicates an expected call of DescribeInstanceState.
+func (mr *MockIEc2MockRecorder) DescribeInstanceState(ctx, instanceId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInstanceState", reflect.TypeOf((*MockIEc2)(nil).DescribeInstanceState), ctx, instanceId)
+}
+
+// DescribeNetworkInterfaces mocks base method.
+func (m *MockIEc2) DescribeNetworkInterfaces(ctx context.Context, groupId *string) ([]types.NetworkInterface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeNetworkInterfaces", ctx, groupId)
+	ret0, _ := ret[0].([]types.NetworkInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeNetworkInterfaces indicates an expected call of DescribeNetworkInterfaces.
+func (mr *MockIEc2MockRecorder) DescribeNetworkInterfaces(ctx, groupId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeNetworkInterfaces", reflect.TypeOf((*MockIEc2)(nil).DescribeNetworkInterfaces), ctx, groupId)
+}
+
+// DescribeReferencingSecurityGroupRules mocks base method.
+func (m *MockIEc2) DescribeReferencingSecurityGroupRules(ctx context.Context, groupId *string) ([]types.SecurityGroupRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeReferencingSecurityGroupRules", ctx, groupId)
+	ret0, _ := ret[0].([]types.SecurityGroupRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeReferencingSecurityGroupRules indicates an expected call of DescribeReferencingSecurityGroupRules.
+func (mr *MockIEc2MockRecorder) DescribeReferencingSecurityGroupRules(ctx, groupId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeReferencingSecurityGroupRules", reflect.TypeOf((*MockIEc2)(nil).DescribeReferencingSecurityGroupRules), ctx, groupId)
+}
+
+// DescribeRegions mocks base method.
+func (m *MockIEc2) DescribeRegions(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeRegions", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeRegions indicates an expected call of DescribeRegions.
+func (mr *MockIEc2MockRecorder) DescribeRegions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeRegions", reflect.TypeOf((*MockIEc2)(nil).DescribeRegions), ctx)
+}
+
+// DetachNetworkInterface mocks base method.
+func (m *MockIEc2) DetachNetworkInterface(ctx context.Context, attachmentId *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachNetworkInterface", ctx, attachmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachNetworkInterface indicates an expected call of DetachNetworkInterface.
+func (mr *MockIEc2MockRecorder) DetachNetworkInterface(ctx, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachNetworkInterface", reflect.TypeOf((*MockIEc2)(nil).DetachNetworkInterface), ctx, attachmentId)
+}
+
+// RevokeSecurityGroupRules mocks base method.
+func (m *MockIEc2) RevokeSecurityGroupRules(ctx context.Context, groupId *string, securityGroupRuleIds []string, isEgress bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSecurityGroupRules", ctx, groupId, securityGroupRuleIds, isEgress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSecurityGroupRules indicates an expected call of RevokeSecurityGroupRules.
+func (mr *MockIEc2MockRecorder) RevokeSecurityGroupRules(ctx, groupId, securityGroupRuleIds, isEgress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSecurityGroupRules", reflect.TypeOf((*MockIEc2)(nil).RevokeSecurityGroupRules), ctx, groupId, securityGroupRuleIds, isEgress)
+}
+
+// WaitNetworkInterfacesAvailable mocks base method.
+func (m *MockIEc2) WaitNetworkInterfacesAvailable(ctx context.Context, groupId *string) ([]types.NetworkInterface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitNetworkInterfacesAvailable", ctx, groupId)
+	ret0, _ := ret[0].([]types.NetworkInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WaitNetworkInterfacesAvailable indicates an expected call of WaitNetworkInterfacesAvailable.
+func (mr *MockIEc2MockRecorder) WaitNetworkInterfacesAvailable(ctx, groupId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitNetworkInterfacesAvailable", reflect.TypeOf((*MockIEc2)(nil).WaitNetworkInterfacesAvailable), ctx, groupId)
+}
@@ -0,0 +1,1116 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsMiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go/middleware"
+)
+
+/*
+	Test Cases
+*/
+
+func TestEc2_DescribeRegions(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	type want struct {
+		output []string
+		err    error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "describe regions successfully",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeRegionsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeRegionsOutput{
+										Regions: []types.Region{
+											{
+												RegionName: aws.String("us-east-1"),
+											},
+											{
+												RegionName: aws.String("ap-northeast-1"),
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []string{
+					"us-east-1",
+					"ap-northeast-1",
+				},
+				err: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "describe regions failure",
+			args: args{
+				ctx: context.Background(),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeRegionsErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeRegionsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("DescribeRegionsError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				output: []string{},
+				err: &ClientError{
+					Err: fmt.Errorf("operation error EC2: DescribeRegions, DescribeRegionsError"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := ec2.NewFromConfig(cfg)
+			ec2Client := NewEc2(client, 0)
+
+			output, err := ec2Client.DescribeRegions(tt.args.ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err, tt.want)
+				return
+			}
+			if !reflect.DeepEqual(output, tt.want.output) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.output)
+			}
+		})
+	}
+}
+
+func TestEc2_CheckSecurityGroupExists(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		groupId            *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "check security group exists successfully",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeSecurityGroupsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeSecurityGroupsOutput{SecurityGroups: []types.SecurityGroup{{GroupId: aws.String("sg-1")}}},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    true,
+			wantErr: nil,
+		},
+		{
+			name: "check security group exists successfully for security group not exists",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeSecurityGroupsNotExistsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeSecurityGroupsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("api error InvalidGroup.NotFound: The security group 'sg-1' does not exist")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    false,
+			wantErr: nil,
+		},
+		{
+			name: "check security group exists failure",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeSecurityGroupsErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeSecurityGroupsOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("DescribeSecurityGroupsError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: false,
+			wantErr: &ClientError{
+				ResourceName: aws.String("sg-1"),
+				Err:          fmt.Errorf("operation error EC2: DescribeSecurityGroups, DescribeSecurityGroupsError"),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := ec2.NewFromConfig(cfg)
+			ec2Client := NewEc2(client, 0)
+
+			got, err := ec2Client.CheckSecurityGroupExists(tt.args.ctx, tt.args.groupId)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+				return
+			}
+			if got != tt.want {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEc2_DeleteSecurityGroup(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		groupId            *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "delete security group successfully",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DeleteSecurityGroupMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DeleteSecurityGroupOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete security group successfully for security group not exists",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DeleteSecurityGroupNotExistsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DeleteSecurityGroupOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("api error InvalidGroup.NotFound: The security group 'sg-1' does not exist")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete security group failure",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DeleteSecurityGroupErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DeleteSecurityGroupOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("api error DependencyViolation: resource sg-1 has a dependent object")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: &ClientError{
+				ResourceName: aws.String("sg-1"),
+				Err:          fmt.Errorf("operation error EC2: DeleteSecurityGroup, api error DependencyViolation: resource sg-1 has a dependent object"),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := ec2.NewFromConfig(cfg)
+			ec2Client := NewEc2(client, 0)
+
+			err = ec2Client.DeleteSecurityGroup(tt.args.ctx, tt.args.groupId)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestEc2_DescribeReferencingSecurityGroupRules(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		groupId            *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	referencingRule := types.SecurityGroupRule{
+		GroupId:             aws.String("sg-2"),
+		SecurityGroupRuleId: aws.String("sgr-1"),
+		ReferencedGroupInfo: &types.ReferencedSecurityGroup{GroupId: aws.String("sg-1")},
+	}
+	otherRule := types.SecurityGroupRule{
+		GroupId:             aws.String("sg-2"),
+		SecurityGroupRuleId: aws.String("sgr-2"),
+		CidrIpv4:            aws.String("0.0.0.0/0"),
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    []types.SecurityGroupRule
+		wantErr error
+	}{
+		{
+			name: "describe referencing security group rules successfully",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeSecurityGroupsOrDescribeSecurityGroupRulesMock",
+							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								if awsMiddleware.GetOperationName(ctx) == "DescribeSecurityGroupRules" {
+									return middleware.FinalizeOutput{
+										Result: &ec2.DescribeSecurityGroupRulesOutput{
+											SecurityGroupRules: []types.SecurityGroupRule{referencingRule, otherRule},
+										},
+									}, middleware.Metadata{}, nil
+								}
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeSecurityGroupsOutput{
+										SecurityGroups: []types.SecurityGroup{{GroupId: aws.String("sg-1")}, {GroupId: aws.String("sg-2")}},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    []types.SecurityGroupRule{referencingRule},
+			wantErr: nil,
+		},
+		{
+			name: "describe referencing security group rules successfully for no referencing security groups",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeSecurityGroupsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeSecurityGroupsOutput{SecurityGroups: []types.SecurityGroup{}},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    []types.SecurityGroupRule{},
+			wantErr: nil,
+		},
+		{
+			name: "describe referencing security group rules failure",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeSecurityGroupsOrDescribeSecurityGroupRulesErrorMock",
+							func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								if awsMiddleware.GetOperationName(ctx) == "DescribeSecurityGroupRules" {
+									return middleware.FinalizeOutput{
+										Result: &ec2.DescribeSecurityGroupRulesOutput{},
+									}, middleware.Metadata{}, fmt.Errorf("DescribeSecurityGroupRulesError")
+								}
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeSecurityGroupsOutput{
+										SecurityGroups: []types.SecurityGroup{{GroupId: aws.String("sg-2")}},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("sg-1"),
+				Err:          fmt.Errorf("operation error EC2: DescribeSecurityGroupRules, DescribeSecurityGroupRulesError"),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := ec2.NewFromConfig(cfg)
+			ec2Client := NewEc2(client, 0)
+
+			got, err := ec2Client.DescribeReferencingSecurityGroupRules(tt.args.ctx, tt.args.groupId)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEc2_RevokeSecurityGroupRules(t *testing.T) {
+	type args struct {
+		ctx                  context.Context
+		groupId              *string
+		securityGroupRuleIds []string
+		isEgress             bool
+		withAPIOptionsFunc   func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "revoke ingress rules successfully",
+			args: args{
+				ctx:                  context.Background(),
+				groupId:              aws.String("sg-2"),
+				securityGroupRuleIds: []string{"sgr-1"},
+				isEgress:             false,
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"RevokeSecurityGroupIngressMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.RevokeSecurityGroupIngressOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "revoke egress rules successfully",
+			args: args{
+				ctx:                  context.Background(),
+				groupId:              aws.String("sg-2"),
+				securityGroupRuleIds: []string{"sgr-1"},
+				isEgress:             true,
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"RevokeSecurityGroupEgressMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.RevokeSecurityGroupEgressOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "revoke ingress rules failure",
+			args: args{
+				ctx:                  context.Background(),
+				groupId:              aws.String("sg-2"),
+				securityGroupRuleIds: []string{"sgr-1"},
+				isEgress:             false,
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"RevokeSecurityGroupIngressErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.RevokeSecurityGroupIngressOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("RevokeSecurityGroupIngressError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: &ClientError{
+				ResourceName: aws.String("sg-2"),
+				Err:          fmt.Errorf("operation error EC2: RevokeSecurityGroupIngress, RevokeSecurityGroupIngressError"),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := ec2.NewFromConfig(cfg)
+			ec2Client := NewEc2(client, 0)
+
+			err = ec2Client.RevokeSecurityGroupRules(tt.args.ctx, tt.args.groupId, tt.args.securityGroupRuleIds, tt.args.isEgress)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestEc2_DescribeInstanceState(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		instanceId         *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    types.InstanceStateName
+		wantErr error
+	}{
+		{
+			name: "describe instance state successfully",
+			args: args{
+				ctx:        context.Background(),
+				instanceId: aws.String("i-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeInstancesMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeInstancesOutput{
+										Reservations: []types.Reservation{
+											{
+												Instances: []types.Instance{
+													{
+														InstanceId: aws.String("i-1"),
+														State:      &types.InstanceState{Name: types.InstanceStateNameRunning},
+													},
+												},
+											},
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    types.InstanceStateNameRunning,
+			wantErr: nil,
+		},
+		{
+			name: "describe instance state successfully as terminated for instance not exists",
+			args: args{
+				ctx:        context.Background(),
+				instanceId: aws.String("i-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeInstancesNotExistsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeInstancesOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("api error InvalidInstanceID.NotFound: not found")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    types.InstanceStateNameTerminated,
+			wantErr: nil,
+		},
+		{
+			name: "describe instance state failure",
+			args: args{
+				ctx:        context.Background(),
+				instanceId: aws.String("i-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeInstancesErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeInstancesOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("DescribeInstancesError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: "",
+			wantErr: &ClientError{
+				ResourceName: aws.String("i-1"),
+				Err:          fmt.Errorf("operation error EC2: DescribeInstances, DescribeInstancesError"),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := ec2.NewFromConfig(cfg)
+			ec2Client := NewEc2(client, 0)
+
+			got, err := ec2Client.DescribeInstanceState(tt.args.ctx, tt.args.instanceId)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+				return
+			}
+			if got != tt.want {
+				t.Errorf("output = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEc2_DetachNetworkInterface(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		attachmentId       *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "detach network interface successfully",
+			args: args{
+				ctx:          context.Background(),
+				attachmentId: aws.String("eni-attach-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DetachNetworkInterfaceMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DetachNetworkInterfaceOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "detach network interface successfully for attachment not exists",
+			args: args{
+				ctx:          context.Background(),
+				attachmentId: aws.String("eni-attach-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DetachNetworkInterfaceNotExistsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DetachNetworkInterfaceOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("api error InvalidAttachmentID.NotFound: not found")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "detach network interface failure",
+			args: args{
+				ctx:          context.Background(),
+				attachmentId: aws.String("eni-attach-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DetachNetworkInterfaceErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DetachNetworkInterfaceOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("DetachNetworkInterfaceError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: &ClientError{
+				ResourceName: aws.String("eni-attach-1"),
+				Err:          fmt.Errorf("operation error EC2: DetachNetworkInterface, DetachNetworkInterfaceError"),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := ec2.NewFromConfig(cfg)
+			ec2Client := NewEc2(client, 0)
+
+			err = ec2Client.DetachNetworkInterface(tt.args.ctx, tt.args.attachmentId)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestEc2_DeleteNetworkInterface(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		networkInterfaceId *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "delete network interface successfully",
+			args: args{
+				ctx:                context.Background(),
+				networkInterfaceId: aws.String("eni-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DeleteNetworkInterfaceMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DeleteNetworkInterfaceOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete network interface successfully for network interface not exists",
+			args: args{
+				ctx:                context.Background(),
+				networkInterfaceId: aws.String("eni-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DeleteNetworkInterfaceNotExistsMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DeleteNetworkInterfaceOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("api error InvalidNetworkInterfaceID.NotFound: not found")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete network interface failure",
+			args: args{
+				ctx:                context.Background(),
+				networkInterfaceId: aws.String("eni-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DeleteNetworkInterfaceErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DeleteNetworkInterfaceOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("DeleteNetworkInterfaceError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			wantErr: &ClientError{
+				ResourceName: aws.String("eni-1"),
+				Err:          fmt.Errorf("operation error EC2: DeleteNetworkInterface, DeleteNetworkInterfaceError"),
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := ec2.NewFromConfig(cfg)
+			ec2Client := NewEc2(client, 0)
+
+			err = ec2Client.DeleteNetworkInterface(tt.args.ctx, tt.args.networkInterfaceId)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil && err.Error() != tt.wantErr.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestEc2_WaitNetworkInterfacesAvailable(t *testing.T) {
+	type args struct {
+		ctx                context.Context
+		groupId            *string
+		deletionTimeout    time.Duration
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	inUse := types.NetworkInterface{NetworkInterfaceId: aws.String("eni-1"), Status: types.NetworkInterfaceStatusInUse}
+	available := types.NetworkInterface{NetworkInterfaceId: aws.String("eni-1"), Status: types.NetworkInterfaceStatusAvailable}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    []types.NetworkInterface
+		wantErr error
+	}{
+		{
+			name: "wait network interfaces available successfully",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: newDescribeNetworkInterfacesMiddleware(
+					describeNetworkInterfacesResult{networkInterfaces: []types.NetworkInterface{inUse}},
+					describeNetworkInterfacesResult{networkInterfaces: []types.NetworkInterface{available}},
+				),
+			},
+			want:    []types.NetworkInterface{available},
+			wantErr: nil,
+		},
+		{
+			name: "wait network interfaces available successfully for no network interfaces",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeNetworkInterfacesMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeNetworkInterfacesOutput{},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want:    []types.NetworkInterface{},
+			wantErr: nil,
+		},
+		{
+			name: "wait network interfaces available failure",
+			args: args{
+				ctx:     context.Background(),
+				groupId: aws.String("sg-1"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeNetworkInterfacesErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeNetworkInterfacesOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("DescribeNetworkInterfacesError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("sg-1"),
+				Err:          fmt.Errorf("operation error EC2: DescribeNetworkInterfaces, DescribeNetworkInterfacesError"),
+			},
+		},
+		{
+			name: "wait network interfaces available failure for timeout",
+			args: args{
+				ctx:             context.Background(),
+				groupId:         aws.String("sg-1"),
+				deletionTimeout: 10 * time.Millisecond,
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"DescribeNetworkInterfacesMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{inUse}},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: nil,
+			wantErr: &ClientError{
+				ResourceName: aws.String("sg-1"),
+				Err:          &ResourceDeletionTimeoutError{Timeout: 10 * time.Millisecond},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := ec2.NewFromConfig(cfg)
+			ec2Client := NewEc2(client, tt.args.deletionTimeout)
+			ec2Client.pollingInterval = time.Millisecond
+
+			got, err := ec2Client.WaitNetworkInterfacesAvailable(tt.args.ctx, tt.args.groupId)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("err = %#v, want %#v", err.Error(), tt.wantErr.Error())
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+type describeNetworkInterfacesResult struct {
+	networkInterfaces []types.NetworkInterface
+	err               error
+}
+
+// newDescribeNetworkInterfacesMiddleware returns the mock of DescribeNetworkInterfaces, which returns the results in
+// order and then repeats the last one.
+func newDescribeNetworkInterfacesMiddleware(describeNetworkInterfacesResults ...describeNetworkInterfacesResult) func(*middleware.Stack) error {
+	count := 0
+
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Add(
+			middleware.FinalizeMiddlewareFunc(
+				"DescribeNetworkInterfacesMock",
+				func(ctx context.Context, input middleware.FinalizeInput, handler middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+					result := describeNetworkInterfacesResults[len(describeNetworkInterfacesResults)-1]
+					if count < len(describeNetworkInterfacesResults) {
+						result = describeNetworkInterfacesResults[count]
+					}
+					count++
+
+					return middleware.FinalizeOutput{
+						Result: &ec2.DescribeNetworkInterfacesOutput{
+							NetworkInterfaces: result.networkInterfaces,
+						},
+					}, middleware.Metadata{}, result.err
+				},
+			),
+			middleware.Before,
+		)
+	}
+}
@@ -1,8 +1,13 @@
 package client
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 var _ error = (*ClientError)(nil)
+var _ error = (*StackDeletionTimeoutError)(nil)
+var _ error = (*ResourceDeletionTimeoutError)(nil)
 
 // ClientError provides the error with a resource name
 type ClientError struct {
@@ -23,3 +28,21 @@ func (e *ClientError) Error() string {
 func (e *ClientError) Unwrap() error {
 	return e.Err
 }
+
+// StackDeletionTimeoutError is returned when the stack deletion does not finish within the timeout
+type StackDeletionTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *StackDeletionTimeoutError) Error() string {
+	return fmt.Sprintf("StackDeletionTimeoutError: the stack deletion did not finish within %v", e.Timeout)
+}
+
+// ResourceDeletionTimeoutError is returned when the resource deletion by Cloud Control does not finish within the timeout
+type ResourceDeletionTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *ResourceDeletionTimeoutError) Error() string {
+	return fmt.Sprintf("ResourceDeletionTimeoutError: the resource deletion did not finish within %v", e.Timeout)
+}
@@ -17,6 +17,7 @@ type IIam interface {
 	DetachRolePolicies(ctx context.Context, roleName *string, policies []types.AttachedPolicy) error
 	DetachRolePolicy(ctx context.Context, roleName *string, PolicyArn *string) error
 	CheckRoleExists(ctx context.Context, roleName *string) (bool, error)
+	GetRole(ctx context.Context, roleName *string) (*types.Role, error)
 }
 
 var _ IIam = (*Iam)(nil)
@@ -157,3 +158,26 @@ func (i *Iam) CheckRoleExists(ctx context.Context, roleName *string) (bool, erro
 
 	return true, nil
 }
+
+func (i *Iam) GetRole(ctx context.Context, roleName *string) (*types.Role, error) {
+	input := &iam.GetRoleInput{
+		RoleName: roleName,
+	}
+
+	retryable := func(err error) bool {
+		return strings.Contains(err.Error(), "api error Throttling: Rate exceeded")
+	}
+	optFn := func(o *iam.Options) {
+		o.Retryer = NewRetryer(retryable, SleepTimeSecForIam)
+	}
+
+	output, err := i.client.GetRole(ctx, input, optFn)
+	if err != nil {
+		return nil, &ClientError{
+			ResourceName: roleName,
+			Err:          err,
+		}
+	}
+
+	return output.Role, nil
+}
@@ -91,6 +91,21 @@ func (mr *MockIIamMockRecorder) DetachRolePolicy(ctx, roleName, PolicyArn interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachRolePolicy", reflect.TypeOf((*MockIIam)(nil).DetachRolePolicy), ctx, roleName, PolicyArn)
 }
 
+// GetRole mocks base method.
+func (m *MockIIam) GetRole(ctx context.Context, roleName *string) (*types.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, roleName)
+	ret0, _ := ret[0].(*types.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockIIamMockRecorder) GetRole(ctx, roleName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockIIam)(nil).GetRole), ctx, roleName)
+}
+
 // ListAttachedRolePolicies mocks base method.
 func (m *MockIIam) ListAttachedRolePolicies(ctx context.Context, roleName *string) ([]types.AttachedPolicy, error) {
 	m.ctrl.T.Helper()
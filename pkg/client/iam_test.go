@@ -1005,3 +1005,115 @@ func TestIam_CheckRoleExists(t *testing.T) {
 		})
 	}
 }
+
+func TestIam_GetRole(t *testing.T) {
+	SleepTimeSecForIam = 1
+	type args struct {
+		ctx                context.Context
+		roleName           *string
+		withAPIOptionsFunc func(*middleware.Stack) error
+	}
+
+	type want struct {
+		role *types.Role
+		err     error
+	}
+
+	cases := []struct {
+		name    string
+		args    args
+		want    want
+		wantErr bool
+	}{
+		{
+			name: "get role successfully",
+			args: args{
+				ctx:      context.Background(),
+				roleName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetRoleMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &iam.GetRoleOutput{
+										Role: &types.Role{
+											RoleName: aws.String("test"),
+											Arn:      aws.String("arn:aws:iam::123456789012:role/path/test"),
+										},
+									},
+								}, middleware.Metadata{}, nil
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				role: &types.Role{
+					RoleName: aws.String("test"),
+					Arn:      aws.String("arn:aws:iam::123456789012:role/path/test"),
+				},
+				err:     nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "get role failure",
+			args: args{
+				ctx:      context.Background(),
+				roleName: aws.String("test"),
+				withAPIOptionsFunc: func(stack *middleware.Stack) error {
+					return stack.Finalize.Add(
+						middleware.FinalizeMiddlewareFunc(
+							"GetRoleErrorMock",
+							func(context.Context, middleware.FinalizeInput, middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+								return middleware.FinalizeOutput{
+									Result: &iam.GetRoleOutput{},
+								}, middleware.Metadata{}, fmt.Errorf("GetRoleError")
+							},
+						),
+						middleware.Before,
+					)
+				},
+			},
+			want: want{
+				role: nil,
+				err: &ClientError{
+					ResourceName: aws.String("test"),
+					Err:          fmt.Errorf("operation error IAM: GetRole, GetRoleError"),
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := config.LoadDefaultConfig(
+				tt.args.ctx,
+				config.WithRegion("ap-northeast-1"),
+				config.WithAPIOptions([]func(*middleware.Stack) error{tt.args.withAPIOptionsFunc}),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			client := iam.NewFromConfig(cfg)
+			iamClient := NewIam(client)
+
+			output, err := iamClient.GetRole(tt.args.ctx, tt.args.roleName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %#v, wantErr %#v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err.Error() != tt.want.err.Error() {
+				t.Errorf("err = %#v, want %#v", err.Error(), tt.want.err.Error())
+				return
+			}
+			if !reflect.DeepEqual(output, tt.want.role) {
+				t.Errorf("output = %#v, want %#v", output, tt.want.role)
+			}
+		})
+	}
+}
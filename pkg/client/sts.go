@@ -0,0 +1,36 @@
+//go:generate mockgen -source=$GOFILE -destination=sts_mock.go -package=$GOPACKAGE -write_package_comment=false
+package client
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+type ISts interface {
+	GetCallerIdentityArn(ctx context.Context) (*string, error)
+}
+
+var _ ISts = (*Sts)(nil)
+
+type Sts struct {
+	client *sts.Client
+}
+
+func NewSts(client *sts.Client) *Sts {
+	return &Sts{
+		client,
+	}
+}
+
+// GetCallerIdentityArn returns the ARN of the caller, such as arn:aws:sts::123456789012:assumed-role/RoleName/SessionName.
+func (s *Sts) GetCallerIdentityArn(ctx context.Context) (*string, error) {
+	output, err := s.client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, &ClientError{
+			Err: err,
+		}
+	}
+
+	return output.Arn, nil
+}
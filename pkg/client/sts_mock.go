@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sts.go
+
+package client
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockISts is a mock of ISts interface.
+type MockISts struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStsMockRecorder
+}
+
+// MockIStsMockRecorder is the mock recorder for MockISts.
+type MockIStsMockRecorder struct {
+	mock *MockISts
+}
+
+// NewMockISts creates a new mock instance.
+func NewMockISts(ctrl *gomock.Controller) *MockISts {
+	mock := &MockISts{ctrl: ctrl}
+	mock.recorder = &MockIStsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISts) EXPECT() *MockIStsMockRecorder {
+	return m.recorder
+}
+
+// GetCallerIdentityArn mocks base method.
+func (m *MockISts) GetCallerIdentityArn(ctx context.Context) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCallerIdentityArn", ctx)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCallerIdentityArn indicates an expected call of GetCallerIdentityArn.
+func (mr *MockIStsMockRecorder) GetCallerIdentityArn(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCallerIdentityArn", reflect.TypeOf((*MockISts)(nil).GetCallerIdentityArn), ctx)
+}